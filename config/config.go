@@ -0,0 +1,480 @@
+// Package config loads service settings from environment variables.
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TimestampPolicyFlag   = "flag"
+	TimestampPolicyReject = "reject"
+
+	HeaderModeDenylist  = "denylist"
+	HeaderModeAllowlist = "allowlist"
+)
+
+// DefaultRedactedHeaders are credential headers whose values are never stored.
+var DefaultRedactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+	"X-Auth-Token",
+	"X-Csrf-Token",
+	"X-Amz-Security-Token",
+}
+
+// DefaultStatsRecorderExclude are the paths the request recorder skips by default.
+var DefaultStatsRecorderExclude = []string{
+	"/",
+	"/health",
+	"/metrics",
+	"/swagger/*",
+	"/api/v1/stats*",
+}
+
+type Config struct {
+	// Client supplied activity timestamps further than these bounds from the
+	// server receive time are rejected or flagged depending on TimestampPolicy.
+	TimestampMaxPast   time.Duration
+	TimestampMaxFuture time.Duration
+	TimestampPolicy    string
+
+	// StreamBufferSize is the number of activities buffered per live stream
+	// subscriber before it is evicted as a slow consumer.
+	StreamBufferSize int
+	// StreamAllowedOrigins are the origins, such as https://app.example.com,
+	// whose pages may open a stream WebSocket besides the API's own origin.
+	// "*" allows every origin.
+	StreamAllowedOrigins []string
+
+	// Retention deletes activities older than RetentionMaxAge, or older than
+	// the per-grid override for grids listed in RetentionGridMaxAge, and keeps
+	// at most RetentionMaxRows activities. Zero disables a limit.
+	RetentionMaxAge     time.Duration
+	RetentionGridMaxAge map[string]time.Duration
+	RetentionMaxRows    int
+	RetentionInterval   time.Duration
+	RetentionBatchSize  int
+	// TrashRetention is the grace period after which soft-deleted activities
+	// are purged for good. Zero keeps them until restored or hard deleted.
+	TrashRetention time.Duration
+
+	// AdminToken, when set, must be sent as X-Admin-Token to use admin-only
+	// operations such as hard deletes. Without it those operations are disabled.
+	AdminToken string
+
+	// Header capture stores either every request header except HeaderDeny
+	// (denylist mode) or only HeaderAllow (allowlist mode). Values of
+	// HeaderRedact are replaced, HeaderHash values are stored as a hash keyed
+	// with HeaderHashKey, which is required and takes precedence over
+	// redaction, and HeaderMaskPatterns are masked inside any remaining value.
+	HeaderMode         string
+	HeaderAllow        []string
+	HeaderDeny         []string
+	HeaderRedact       []string
+	HeaderHash         []string
+	HeaderHashKey      string
+	HeaderMaskPatterns []*regexp.Regexp
+	// HeaderMultiValue keeps every value of a repeated header instead of the first.
+	HeaderMultiValue bool
+
+	// IdempotencyWindow is how long the response to a create request with an
+	// Idempotency-Key header or EventId is replayed for retries. Zero disables it.
+	IdempotencyWindow time.Duration
+
+	// TrustedProxies lists the networks whose ForwardedHeader is believed when
+	// deriving an activity's source IP. ForwardedHeader names the one header
+	// those proxies set, X-Forwarded-For or Forwarded; the other is ignored.
+	TrustedProxies  []*net.IPNet
+	ForwardedHeader string
+
+	// HeartbeatInterval is the default time a device may stay silent before it
+	// is marked offline; devices and grids can override it. Zero disables
+	// detection for devices without an override.
+	HeartbeatInterval      time.Duration
+	HeartbeatCheckInterval time.Duration
+
+	// Alerts are re-evaluated every AlertEvaluationInterval so that they
+	// resolve once their window drains. Webhook deliveries are attempted up to
+	// AlertWebhookMaxAttempts times, doubling AlertWebhookBackoff in between.
+	AlertEvaluationInterval time.Duration
+	AlertWebhookTimeout     time.Duration
+	AlertWebhookMaxAttempts int
+	AlertWebhookBackoff     time.Duration
+	AlertQueueSize          int
+
+	// SessionPairs maps each start action to the action that ends its
+	// session. Open sessions time out after SessionIdleTimeout without
+	// activity; new activities are folded in every SessionBuildInterval, in
+	// Timestamp order once their Timestamp is SessionLateness old.
+	SessionPairs         map[string]string
+	SessionIdleTimeout   time.Duration
+	SessionBuildInterval time.Duration
+	SessionLateness      time.Duration
+
+	// ChangeFeedMaxWait caps how long a change feed request may long-poll.
+	ChangeFeedMaxWait time.Duration
+	// ChangelogRetention is how long changes are kept once every checkpoint
+	// has passed them. Zero keeps them forever.
+	ChangelogRetention time.Duration
+
+	// Webhook deliveries are attempted up to WebhookMaxAttempts times, waiting
+	// WebhookBackoff doubled after every failure but at most WebhookMaxBackoff,
+	// before they are dead-lettered. Finished deliveries are kept in the
+	// delivery log for WebhookDeliveryRetention.
+	WebhookTimeout           time.Duration
+	WebhookMaxAttempts       int
+	WebhookBackoff           time.Duration
+	WebhookMaxBackoff        time.Duration
+	WebhookPollInterval      time.Duration
+	WebhookDeliveryRetention time.Duration
+
+	// StatsRetention is how long usage statistics are kept. Zero keeps them forever.
+	StatsRetention time.Duration
+
+	// StatsRecorderEnabled records a UsageStats entry for every request whose
+	// path is not excluded, keeping StatsRecorderSampleRate of them (0 to 1).
+	// Entries are queued and written in batches of StatsRecorderBatchSize at
+	// least every StatsRecorderFlushInterval; they are dropped while the queue
+	// is full. Exclusions match the path exactly, or as a prefix when they end in *.
+	StatsRecorderEnabled       bool
+	StatsRecorderSampleRate    float64
+	StatsRecorderExclude       []string
+	StatsRecorderBatchSize     int
+	StatsRecorderFlushInterval time.Duration
+	StatsRecorderQueueSize     int
+
+	// SLOs are evaluated every SLOEvaluationInterval. The fast burn alert of
+	// an SLO fires while its error budget burns faster than SLOFastBurnRate
+	// over both the last hour and 5 minutes, the slow burn alert while it
+	// burns faster than SLOSlowBurnRate over both the last 6 hours and 30
+	// minutes. Alerts are logged and posted to SLOAlertWebhooks.
+	SLOEvaluationInterval time.Duration
+	SLOFastBurnRate       float64
+	SLOSlowBurnRate       float64
+	SLOAlertWebhooks      []string
+}
+
+// Load reads the configuration, falling back to defaults for unset variables.
+func Load() (Config, error) {
+	var cfg Config
+	var err error
+
+	if cfg.TimestampMaxPast, err = envDuration("ACTIVITY_TIMESTAMP_MAX_PAST", 7*24*time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.TimestampMaxFuture, err = envDuration("ACTIVITY_TIMESTAMP_MAX_FUTURE", 5*time.Minute); err != nil {
+		return cfg, err
+	}
+	cfg.TimestampPolicy = envString("ACTIVITY_TIMESTAMP_POLICY", TimestampPolicyFlag)
+	if cfg.TimestampPolicy != TimestampPolicyFlag && cfg.TimestampPolicy != TimestampPolicyReject {
+		return cfg, fmt.Errorf("ACTIVITY_TIMESTAMP_POLICY must be %q or %q", TimestampPolicyFlag, TimestampPolicyReject)
+	}
+
+	if cfg.StreamBufferSize, err = envInt("ACTIVITY_STREAM_BUFFER_SIZE", 256); err != nil {
+		return cfg, err
+	}
+	cfg.StreamAllowedOrigins = envList("ACTIVITY_STREAM_ALLOWED_ORIGINS", ",", nil)
+	for i, origin := range cfg.StreamAllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			return cfg, fmt.Errorf("ACTIVITY_STREAM_ALLOWED_ORIGINS: %q is not an origin such as https://app.example.com", origin)
+		}
+		cfg.StreamAllowedOrigins[i] = strings.ToLower(u.Scheme + "://" + u.Host)
+	}
+
+	if cfg.RetentionMaxAge, err = envDuration("ACTIVITY_RETENTION_MAX_AGE", 0); err != nil {
+		return cfg, err
+	}
+	if cfg.RetentionGridMaxAge, err = envDurationMap("ACTIVITY_RETENTION_GRID_MAX_AGE"); err != nil {
+		return cfg, err
+	}
+	if cfg.RetentionMaxRows, err = envInt("ACTIVITY_RETENTION_MAX_ROWS", 0); err != nil {
+		return cfg, err
+	}
+	if cfg.RetentionInterval, err = envDuration("ACTIVITY_RETENTION_INTERVAL", time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.RetentionBatchSize, err = envInt("ACTIVITY_RETENTION_BATCH_SIZE", 1000); err != nil {
+		return cfg, err
+	}
+	if cfg.TrashRetention, err = envDuration("ACTIVITY_TRASH_RETENTION", 30*24*time.Hour); err != nil {
+		return cfg, err
+	}
+	cfg.AdminToken = envString("ADMIN_TOKEN", "")
+
+	cfg.HeaderMode = envString("ACTIVITY_HEADERS_MODE", HeaderModeDenylist)
+	if cfg.HeaderMode != HeaderModeDenylist && cfg.HeaderMode != HeaderModeAllowlist {
+		return cfg, fmt.Errorf("ACTIVITY_HEADERS_MODE must be %q or %q", HeaderModeDenylist, HeaderModeAllowlist)
+	}
+	cfg.HeaderAllow = envList("ACTIVITY_HEADERS_ALLOW", ",", nil)
+	cfg.HeaderDeny = envList("ACTIVITY_HEADERS_DENY", ",", nil)
+	cfg.HeaderRedact = envList("ACTIVITY_HEADERS_REDACT", ",", DefaultRedactedHeaders)
+	cfg.HeaderHash = envList("ACTIVITY_HEADERS_HASH", ",", nil)
+	cfg.HeaderHashKey = envString("ACTIVITY_HEADERS_HASH_KEY", "")
+	if len(cfg.HeaderHash) > 0 && cfg.HeaderHashKey == "" {
+		return cfg, fmt.Errorf("ACTIVITY_HEADERS_HASH requires ACTIVITY_HEADERS_HASH_KEY")
+	}
+	// Patterns are separated by ';' because ',' is common inside regular expressions.
+	for _, pattern := range envList("ACTIVITY_HEADERS_MASK_PATTERNS", ";", nil) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return cfg, fmt.Errorf("ACTIVITY_HEADERS_MASK_PATTERNS: %w", err)
+		}
+		cfg.HeaderMaskPatterns = append(cfg.HeaderMaskPatterns, re)
+	}
+	if cfg.HeaderMultiValue, err = envBool("ACTIVITY_HEADERS_MULTI_VALUE", false); err != nil {
+		return cfg, err
+	}
+
+	if cfg.IdempotencyWindow, err = envDuration("ACTIVITY_IDEMPOTENCY_WINDOW", 24*time.Hour); err != nil {
+		return cfg, err
+	}
+
+	for _, entry := range envList("ACTIVITY_TRUSTED_PROXIES", ",", nil) {
+		network, err := parseNetwork(entry)
+		if err != nil {
+			return cfg, fmt.Errorf("ACTIVITY_TRUSTED_PROXIES: %w", err)
+		}
+		cfg.TrustedProxies = append(cfg.TrustedProxies, network)
+	}
+	cfg.ForwardedHeader = http.CanonicalHeaderKey(envString("ACTIVITY_FORWARDED_HEADER", "X-Forwarded-For"))
+	if cfg.ForwardedHeader != "X-Forwarded-For" && cfg.ForwardedHeader != "Forwarded" {
+		return cfg, fmt.Errorf("ACTIVITY_FORWARDED_HEADER must be %q or %q", "X-Forwarded-For", "Forwarded")
+	}
+
+	if cfg.HeartbeatInterval, err = envDuration("DEVICE_HEARTBEAT_INTERVAL", 0); err != nil {
+		return cfg, err
+	}
+	if cfg.HeartbeatCheckInterval, err = envDuration("DEVICE_HEARTBEAT_CHECK_INTERVAL", 30*time.Second); err != nil {
+		return cfg, err
+	}
+
+	if cfg.AlertEvaluationInterval, err = envDuration("ALERT_EVALUATION_INTERVAL", 10*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.AlertWebhookTimeout, err = envDuration("ALERT_WEBHOOK_TIMEOUT", 10*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.AlertWebhookMaxAttempts, err = envInt("ALERT_WEBHOOK_MAX_ATTEMPTS", 5); err != nil {
+		return cfg, err
+	}
+	if cfg.AlertWebhookBackoff, err = envDuration("ALERT_WEBHOOK_BACKOFF", time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.AlertQueueSize, err = envInt("ALERT_QUEUE_SIZE", 1000); err != nil {
+		return cfg, err
+	}
+	if cfg.SessionPairs, err = envPairs("SESSION_ACTION_PAIRS", []string{"login:logout"}); err != nil {
+		return cfg, err
+	}
+	if cfg.SessionIdleTimeout, err = envDuration("SESSION_IDLE_TIMEOUT", 30*time.Minute); err != nil {
+		return cfg, err
+	}
+	if cfg.SessionBuildInterval, err = envDuration("SESSION_BUILD_INTERVAL", 30*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.SessionLateness, err = envDuration("SESSION_LATENESS", time.Minute); err != nil {
+		return cfg, err
+	}
+	if cfg.SessionLateness < 0 {
+		return cfg, fmt.Errorf("SESSION_LATENESS must not be negative")
+	}
+	if cfg.ChangeFeedMaxWait, err = envDuration("CHANGE_FEED_MAX_WAIT", time.Minute); err != nil {
+		return cfg, err
+	}
+	if cfg.ChangelogRetention, err = envDuration("CHANGELOG_RETENTION", 7*24*time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.WebhookTimeout, err = envDuration("WEBHOOK_TIMEOUT", 10*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.WebhookMaxAttempts, err = envInt("WEBHOOK_MAX_ATTEMPTS", 8); err != nil {
+		return cfg, err
+	}
+	if cfg.WebhookBackoff, err = envDuration("WEBHOOK_BACKOFF", 5*time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.WebhookMaxBackoff, err = envDuration("WEBHOOK_MAX_BACKOFF", time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.WebhookPollInterval, err = envDuration("WEBHOOK_POLL_INTERVAL", time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.WebhookDeliveryRetention, err = envDuration("WEBHOOK_DELIVERY_RETENTION", 7*24*time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.StatsRetention, err = envDuration("STATS_RETENTION", 30*24*time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.StatsRecorderEnabled, err = envBool("STATS_RECORDER_ENABLED", false); err != nil {
+		return cfg, err
+	}
+	if cfg.StatsRecorderSampleRate, err = envFloat("STATS_RECORDER_SAMPLE_RATE", 1); err != nil {
+		return cfg, err
+	}
+	if cfg.StatsRecorderSampleRate < 0 || cfg.StatsRecorderSampleRate > 1 {
+		return cfg, fmt.Errorf("STATS_RECORDER_SAMPLE_RATE must be between 0 and 1")
+	}
+	cfg.StatsRecorderExclude = envList("STATS_RECORDER_EXCLUDE", ",", DefaultStatsRecorderExclude)
+	if cfg.StatsRecorderBatchSize, err = envInt("STATS_RECORDER_BATCH_SIZE", 100); err != nil {
+		return cfg, err
+	}
+	if cfg.StatsRecorderFlushInterval, err = envDuration("STATS_RECORDER_FLUSH_INTERVAL", time.Second); err != nil {
+		return cfg, err
+	}
+	if cfg.StatsRecorderQueueSize, err = envInt("STATS_RECORDER_QUEUE_SIZE", 10000); err != nil {
+		return cfg, err
+	}
+	if cfg.SLOEvaluationInterval, err = envDuration("SLO_EVALUATION_INTERVAL", time.Minute); err != nil {
+		return cfg, err
+	}
+	if cfg.SLOFastBurnRate, err = envFloat("SLO_FAST_BURN_RATE", 14.4); err != nil {
+		return cfg, err
+	}
+	if cfg.SLOSlowBurnRate, err = envFloat("SLO_SLOW_BURN_RATE", 6); err != nil {
+		return cfg, err
+	}
+	cfg.SLOAlertWebhooks = envList("SLO_ALERT_WEBHOOKS", ",", nil)
+
+	return cfg, nil
+}
+
+// parseNetwork accepts a CIDR or a single IP address.
+func parseNetwork(value string) (*net.IPNet, error) {
+	if !strings.Contains(value, "/") {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", value)
+		}
+		bits := 8 * len(ip.To16())
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 32
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, network, err := net.ParseCIDR(value)
+	return network, err
+}
+
+func envString(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+func envDuration(key string, fallback time.Duration) (time.Duration, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	return d, nil
+}
+
+func envInt(key string, fallback int) (int, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	return n, nil
+}
+
+func envFloat(key string, fallback float64) (float64, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	return f, nil
+}
+
+func envBool(key string, fallback bool) (bool, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", key, err)
+	}
+	return b, nil
+}
+
+// envList splits a variable on sep, dropping empty items. An unset variable
+// returns fallback; a variable set to "-" returns an empty list.
+func envList(key, sep string, fallback []string) []string {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback
+	}
+	var result []string
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" && item != "-" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// envPairs parses "start:end" pairs separated by commas, e.g. "login:logout,open:close".
+func envPairs(key string, fallback []string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, pair := range envList(key, ",", fallback) {
+		start, end, found := strings.Cut(pair, ":")
+		start, end = strings.TrimSpace(start), strings.TrimSpace(end)
+		if !found || start == "" || end == "" || start == end {
+			return nil, fmt.Errorf("%s: expected start:end with different actions, got %q", key, pair)
+		}
+		if _, exists := result[start]; exists {
+			return nil, fmt.Errorf("%s: start action %q is listed twice", key, start)
+		}
+		result[start] = end
+	}
+	return result, nil
+}
+
+// envDurationMap parses "key=duration" pairs separated by commas, e.g. "grid-east=720h,grid-west=24h".
+func envDurationMap(key string) (map[string]time.Duration, error) {
+	result := make(map[string]time.Duration)
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return result, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		name, raw, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("%s: expected name=duration, got %q", key, pair)
+		}
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", key, name, err)
+		}
+		result[name] = d
+	}
+	return result, nil
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"go-rest-api/repositories"
+	"go-rest-api/services"
+	"net/http"
+	"time"
+	// Embed the time zone database; the runtime image does not ship one.
+	_ "time/tzdata"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AggregateResponse is the result of an activity aggregation.
+type AggregateResponse struct {
+	From     time.Time               `json:"from"`
+	To       time.Time               `json:"to"`
+	Bucket   services.BucketSize     `json:"bucket"`
+	Timezone string                  `json:"timezone"`
+	GroupBy  []string                `json:"group_by"`
+	Rows     []services.AggregateRow `json:"rows"`
+}
+
+// AggregateActivities godoc
+// @Summary Aggregate activity counts
+// @Description Counts activities in [from, to) per time bucket, optionally grouped by grid, device, action and/or source_ip.
+// @Description Bucket boundaries follow the wall clock of the given IANA time zone. Empty buckets are omitted.
+// @Tags activities
+// @Produce json
+// @Param from query string false "Start of the range (RFC 3339, default 24h before to)"
+// @Param to query string false "End of the range (RFC 3339, default now)"
+// @Param bucket query string false "Bucket size" Enums(minute, hour, day, week) default(hour)
+// @Param tz query string false "IANA time zone for bucket boundaries" default(UTC)
+// @Param group_by query []string false "Fields to group by" collectionFormat(csv)
+// @Param device query []string false "Device names or globs" collectionFormat(multi)
+// @Param grid query []string false "Grid names or globs" collectionFormat(multi)
+// @Param descendants query bool false "Also match the descendants of each grid name"
+// @Param action query []string false "Actions" collectionFormat(multi)
+// @Param source_ip_mismatch query bool false "Only activities whose claimed source IP does (true) or does not (false) match"
+// @Success 200 {object} AggregateResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /activities/aggregate [get]
+func AggregateActivities(c *gin.Context) {
+	req, err := parseAggregateRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rows, err := services.AggregateActivities(activityController.repo, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	groupBy := make([]string, len(req.GroupBy))
+	for i, field := range req.GroupBy {
+		groupBy[i] = string(field)
+	}
+	c.JSON(http.StatusOK, AggregateResponse{
+		From:     req.From.In(req.Location),
+		To:       req.To.In(req.Location),
+		Bucket:   req.Bucket,
+		Timezone: req.Location.String(),
+		GroupBy:  groupBy,
+		Rows:     rows,
+	})
+}
+
+func parseAggregateRequest(c *gin.Context) (services.AggregateRequest, error) {
+	var req services.AggregateRequest
+	var err error
+
+	req.To = time.Now()
+	if value := c.Query("to"); value != "" {
+		if req.To, err = time.Parse(time.RFC3339, value); err != nil {
+			return req, err
+		}
+	}
+	req.From = req.To.Add(-24 * time.Hour)
+	if value := c.Query("from"); value != "" {
+		if req.From, err = time.Parse(time.RFC3339, value); err != nil {
+			return req, err
+		}
+	}
+
+	if req.Bucket, err = services.ParseBucketSize(c.DefaultQuery("bucket", string(services.BucketHour))); err != nil {
+		return req, err
+	}
+
+	if req.Location, err = time.LoadLocation(c.DefaultQuery("tz", "UTC")); err != nil {
+		return req, err
+	}
+
+	for _, name := range queryList(c, "group_by") {
+		field, err := repositories.ParseActivityField(name)
+		if err != nil {
+			return req, err
+		}
+		req.GroupBy = append(req.GroupBy, field)
+	}
+
+	if req.Filter, err = parseFilterParams(c); err != nil {
+		return req, err
+	}
+	return req, req.Validate()
+}
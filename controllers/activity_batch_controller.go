@@ -0,0 +1,309 @@
+package controllers
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-rest-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxBatchSize     = 10000
+	maxBatchLineSize = 1 << 20
+)
+
+// BatchItemResult reports the outcome for one item of a batch. Line is the
+// 1-based NDJSON line number, or the position of the element in a JSON array.
+// Duplicate items repeat the EventId of an activity created before, Id and
+// UniqueId are then those of the original.
+type BatchItemResult struct {
+	Line      int    `json:"line"`
+	Id        uint64 `json:"id,omitempty"`
+	UniqueId  string `json:"unique_id,omitempty"`
+	Duplicate bool   `json:"duplicate,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BatchResponse summarises a batch ingestion request.
+type BatchResponse struct {
+	Created    int               `json:"created"`
+	Duplicates int               `json:"duplicates"`
+	Failed     int               `json:"failed"`
+	Results    []BatchItemResult `json:"results"`
+}
+
+type batchItem struct {
+	line     int
+	raw      []byte
+	activity models.DeviceActivity
+	err      error
+}
+
+// ActivityCustomMethod dispatches custom methods such as POST /activities:batch
+// and POST /activities:bulkDelete.
+// Gin has no literal ':' in route paths, so the verb arrives as a wildcard value.
+func ActivityCustomMethod(c *gin.Context) {
+	switch c.Param("verb") {
+	case ":batch":
+		CreateActivitiesBatch(c)
+	case ":bulkDelete":
+		DeleteActivitiesByFilter(c)
+	default:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown activity method"})
+	}
+}
+
+// CreateActivitiesBatch godoc
+// @Summary Create activities in bulk
+// @Description Records many activities in one transaction. The body is either NDJSON (Content-Type application/x-ndjson)
+// @Description or a JSON array. Without partial=true the batch is all-or-nothing; with it, valid items are stored and
+// @Description invalid ones are reported by line number. Items with an EventId are deduplicated like single creates:
+// @Description a device and EventId seen within the idempotency window, before or earlier in the batch, is reported as
+// @Description a duplicate with the original's id and not stored again. Reusing it for a different item is an error (422).
+// @Tags activities
+// @Accept json
+// @Accept application/x-ndjson
+// @Produce json
+// @Param activities body []models.DeviceActivity true "Activities"
+// @Param partial query bool false "Store valid items even if some items are invalid"
+// @Success 201 {object} BatchResponse
+// @Success 207 {object} BatchResponse
+// @Failure 400 {object} BatchResponse
+// @Failure 413 {object} map[string]string
+// @Failure 422 {object} BatchResponse
+// @Failure 500 {object} map[string]string
+// @Router /activities:batch [post]
+func CreateActivitiesBatch(c *gin.Context) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ActivityLatency.WithLabelValues("create_batch").Observe(duration)
+	}()
+
+	partial, _ := strconv.ParseBool(c.Query("partial"))
+
+	items, err := decodeBatch(c)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errBatchTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	if len(items) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "batch is empty"})
+		return
+	}
+
+	headers := captureHeaders(c)
+	now := time.Now()
+	clientTime := make([]bool, len(items))
+	response := BatchResponse{Results: make([]BatchItemResult, len(items))}
+	valid := make([]*models.DeviceActivity, 0, len(items))
+	validIndex := make([]int, 0, len(items))
+	requests := make([]repositories.IdempotencyRequest, 0, len(items))
+
+	for i := range items {
+		item := &items[i]
+		response.Results[i].Line = item.line
+		if item.err == nil {
+			item.err = validateBatchActivity(&item.activity)
+		}
+		var key string
+		if item.err == nil {
+			key, item.err = eventKey(item.activity)
+		}
+		if item.err == nil {
+			item.err = item.activity.SetHeaders(headers)
+		}
+		if item.err == nil {
+			clientTime[i], item.err = activityController.timestamps.Apply(&item.activity, now)
+		}
+		if item.err != nil {
+			response.Results[i].Error = item.err.Error()
+			response.Failed++
+			continue
+		}
+		item.activity.Id = 0
+		item.activity.UniqueId = utils.GenerateUUID()
+		item.activity.Synthetic = false
+		activityController.clientIPs.Apply(&item.activity, c.Request)
+		requests = append(requests, batchIdempotencyRequest(key, item.raw))
+		valid = append(valid, &item.activity)
+		validIndex = append(validIndex, i)
+	}
+
+	if response.Failed > 0 && (!partial || len(valid) == 0) {
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	results, err := activityController.repo.CreateMany(valid, requests, !partial)
+	if err != nil && !errors.Is(err, repositories.ErrIdempotencyKeyMismatch) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for n, i := range validIndex {
+		result := results[n]
+		if result.Err != nil {
+			response.Results[i].Error = result.Err.Error()
+			response.Failed++
+			continue
+		}
+		if err != nil {
+			// The batch was rolled back.
+			continue
+		}
+		response.Results[i].Id = result.Id
+		response.Results[i].UniqueId = result.UniqueId
+		if result.Duplicate {
+			response.Results[i].Duplicate = true
+			response.Duplicates++
+			metrics.ActivityOperationsTotal.WithLabelValues("replay", valid[n].GridName, valid[n].DeviceName).Inc()
+			continue
+		}
+		response.Created++
+		metrics.ActivityOperationsTotal.WithLabelValues("create", valid[n].GridName, valid[n].DeviceName).Inc()
+		if clientTime[i] {
+			activityController.clockOffsets.Observe(*valid[n])
+		}
+	}
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	if response.Failed > 0 {
+		c.JSON(http.StatusMultiStatus, response)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+// batchIdempotencyRequest deduplicates a batch item under key, comparing
+// items by the hash of their raw JSON. An empty key is not deduplicated.
+func batchIdempotencyRequest(key string, raw []byte) repositories.IdempotencyRequest {
+	if key == "" {
+		return repositories.IdempotencyRequest{}
+	}
+	sum := sha256.Sum256(raw)
+	return repositories.IdempotencyRequest{
+		Key:         key,
+		RequestHash: hex.EncodeToString(sum[:]),
+		StatusCode:  http.StatusCreated,
+		TTL:         activityController.idempotencyWindow,
+	}
+}
+
+var errBatchTooLarge = fmt.Errorf("batch exceeds %d items", maxBatchSize)
+
+// decodeBatch reads the request body as NDJSON or as a JSON array. Malformed
+// items are returned with their error so they can be reported individually.
+func decodeBatch(c *gin.Context) ([]batchItem, error) {
+	reader := bufio.NewReader(c.Request.Body)
+	contentType := c.ContentType()
+	if contentType == "application/x-ndjson" || contentType == "application/jsonl" {
+		return decodeNDJSON(reader)
+	}
+
+	first, err := peekNonSpace(reader)
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if first != '[' {
+		return decodeNDJSON(reader)
+	}
+	return decodeJSONArray(reader)
+}
+
+func decodeNDJSON(reader io.Reader) ([]batchItem, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBatchLineSize)
+
+	var items []batchItem
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		if len(items) == maxBatchSize {
+			return nil, errBatchTooLarge
+		}
+		item := batchItem{line: line, raw: bytes.Clone(raw)}
+		item.err = json.Unmarshal(raw, &item.activity)
+		items = append(items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("line %d: %w", line+1, err)
+	}
+	return items, nil
+}
+
+func decodeJSONArray(reader io.Reader) ([]batchItem, error) {
+	decoder := json.NewDecoder(reader)
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	var items []batchItem
+	for decoder.More() {
+		if len(items) == maxBatchSize {
+			return nil, errBatchTooLarge
+		}
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("item %d: %w", len(items)+1, err)
+		}
+		item := batchItem{line: len(items) + 1, raw: raw}
+		item.err = json.Unmarshal(raw, &item.activity)
+		items = append(items, item)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func peekNonSpace(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if !strings.ContainsRune(" \t\r\n", rune(b)) {
+			return b, reader.UnreadByte()
+		}
+	}
+}
+
+func validateBatchActivity(activity *models.DeviceActivity) error {
+	if activity.DeviceName == "" {
+		return errors.New("DeviceName is required")
+	}
+	if activity.Action == "" {
+		return errors.New("Action is required")
+	}
+	return nil
+}
@@ -1,30 +1,98 @@
 package controllers
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-rest-api/config"
 	"go-rest-api/metrics"
 	"go-rest-api/models"
+	"io"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"go-rest-api/repositories"
+	"go-rest-api/services"
 	"go-rest-api/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
 	"github.com/objectbox/objectbox-go/objectbox"
 )
 
 type ActivityController struct {
-	repo *repositories.ActivityRepository
+	repo         *repositories.ActivityRepository
+	grids        *repositories.GridRepository
+	timestamps   services.TimestampPolicy
+	headers      services.HeaderPolicy
+	clientIPs    services.ClientIPResolver
+	clockOffsets *services.ClockOffsetTracker
+	hub          *services.ActivityHub
+	retention    *services.RetentionWorker
+	heartbeats   *services.HeartbeatMonitor
+	alertRules   *repositories.AlertRepository
+	alerts       *services.AlertEngine
+	// sessions are derived from activities by sessionBuilder.
+	sessions       *repositories.SessionRepository
+	sessionBuilder *services.SessionBuilder
+	// webhooks are fanned out from the changelog by webhookDispatcher.
+	webhooks          *repositories.WebhookRepository
+	webhookDispatcher *services.WebhookDispatcher
+	// idempotencyWindow is how long create responses are replayed for retries.
+	idempotencyWindow time.Duration
+	adminToken        string
+	changeFeedMaxWait time.Duration
+	// streamOrigins are the origins allowed to open a stream WebSocket
+	// besides the API's own.
+	streamOrigins []string
+
+	// changelogRetention is how long changes every consumer has passed are kept.
+	changelogRetention time.Duration
 }
 
 var activityController ActivityController
 
 // InitActivityController initializes the controller after DB setup
-func InitActivityController(ob *objectbox.ObjectBox) {
+func InitActivityController(ob *objectbox.ObjectBox, cfg config.Config) {
 	activityController = ActivityController{
-		repo: repositories.NewActivityRepository(ob),
+		repo:         repositories.NewActivityRepository(ob),
+		grids:        repositories.NewGridRepository(ob),
+		timestamps:   services.NewTimestampPolicy(cfg),
+		headers:      services.NewHeaderPolicy(cfg),
+		clientIPs:    services.NewClientIPResolver(cfg),
+		clockOffsets: services.NewClockOffsetTracker(),
+		hub:          services.NewActivityHub(cfg.StreamBufferSize),
+
+		idempotencyWindow: cfg.IdempotencyWindow,
+		adminToken:        cfg.AdminToken,
+		changeFeedMaxWait: cfg.ChangeFeedMaxWait,
+		streamOrigins:     cfg.StreamAllowedOrigins,
+
+		changelogRetention: cfg.ChangelogRetention,
+	}
+	activityController.retention = services.NewRetentionWorker(activityController.repo, cfg)
+	activityController.heartbeats = services.NewHeartbeatMonitor(activityController.repo,
+		repositories.NewDeviceRepository(ob), activityController.grids, cfg)
+	activityController.alertRules = repositories.NewAlertRepository(ob)
+	activityController.alerts = services.NewAlertEngine(activityController.alertRules,
+		services.NewAlertNotifier(activityController.alertRules, &http.Client{Timeout: cfg.AlertWebhookTimeout}, cfg), cfg)
+	if err := activityController.alerts.Load(); err != nil {
+		log.Printf("loading alert rules failed: %v", err)
 	}
+	activityController.sessions = repositories.NewSessionRepository(ob)
+	activityController.sessionBuilder = services.NewSessionBuilder(activityController.sessions, cfg)
+	activityController.webhooks = repositories.NewWebhookRepository(ob)
+	activityController.webhookDispatcher = services.NewWebhookDispatcher(activityController.webhooks,
+		activityController.repo.Changelog(), &http.Client{Timeout: cfg.WebhookTimeout}, cfg)
+	activityController.repo.OnCreate(activityController.hub.Publish)
+	activityController.repo.OnCreate(activityController.alerts.Observe)
 
 	// Sample activity data
 	sampleActivities := []models.DeviceActivity{
@@ -35,6 +103,7 @@ func InitActivityController(ob *objectbox.ObjectBox) {
 			GridName:   "grid-east",
 			Action:     "login",
 			Timestamp:  time.Now().Add(-1 * time.Hour),
+			ReceivedAt: time.Now(),
 		},
 		{
 			UniqueId:   utils.GenerateUUID(),
@@ -43,6 +112,7 @@ func InitActivityController(ob *objectbox.ObjectBox) {
 			GridName:   "grid-west",
 			Action:     "data_sync",
 			Timestamp:  time.Now().Add(-30 * time.Minute),
+			ReceivedAt: time.Now(),
 		},
 	}
 
@@ -50,28 +120,94 @@ func InitActivityController(ob *objectbox.ObjectBox) {
 		activityController.repo.Create(activity)
 	}
 
-	updateActivityMetrics()
+	metrics.RegisterActivityCounts(services.NewActivityCounter(activityController.repo, activityController.grids).Counts)
 }
 
-// Helper function to update Prometheus metrics
-func updateActivityMetrics() {
-	// Reset all metrics
-	metrics.ActivityCount.Reset()
+// StartBackgroundWorkers runs the activity and stats maintenance jobs until ctx is done.
+func StartBackgroundWorkers(ctx context.Context) {
+	go activityController.retention.Run(ctx)
+	go linkDevices(ctx)
+	go activityController.heartbeats.Run(ctx)
+	go activityController.alerts.Run(ctx)
+	go purgeIdempotencyKeys(ctx)
+	go pruneChangelog(ctx)
+	go activityController.sessionBuilder.Run(ctx)
+	go activityController.webhookDispatcher.Run(ctx)
+	go pruneStats(ctx)
+	go statsController.sloEvaluator.Run(ctx)
+}
 
-	// Get all activities from repository
-	activities, err := activityController.repo.GetAll()
-	if err != nil {
+// purgeIdempotencyKeys deletes expired idempotency keys periodically.
+func purgeIdempotencyKeys(ctx context.Context) {
+	ticker := time.NewTicker(idempotencyPurgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for ctx.Err() == nil {
+				n, err := activityController.repo.RemoveExpiredKeys(now, 1000)
+				if err != nil {
+					log.Printf("purging idempotency keys failed: %v", err)
+				}
+				if err != nil || n == 0 {
+					break
+				}
+			}
+		}
+	}
+}
+
+// pruneChangelog deletes changes older than the changelog retention that
+// every consumer has processed, periodically.
+func pruneChangelog(ctx context.Context) {
+	if activityController.changelogRetention <= 0 {
 		return
 	}
+	ticker := time.NewTicker(changelogPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			changelog := activityController.repo.Changelog()
+			floor, err := services.ChangelogFloor(changelog, activityController.webhooks)
+			if err != nil {
+				log.Printf("pruning changelog failed: %v", err)
+				continue
+			}
+			for ctx.Err() == nil {
+				n, err := changelog.RemoveBefore(now.Add(-activityController.changelogRetention), floor, 1000)
+				if err != nil {
+					log.Printf("pruning changelog failed: %v", err)
+				}
+				if err != nil || n == 0 {
+					break
+				}
+			}
+		}
+	}
+}
 
-	// Count activities by grid and device
-	gridCounts := make(map[string]int)
-	deviceCounts := make(map[string]int)
-	
-	for _, activity := range activities {
-		metrics.ActivityCount.WithLabelValues(activity.GridName, activity.DeviceName).Inc()
-		gridCounts[activity.GridName]++
-		deviceCounts[activity.DeviceName]++
+// linkDevices registers the devices of activities stored before the device
+// registry existed.
+func linkDevices(ctx context.Context) {
+	total := 0
+	for ctx.Err() == nil {
+		n, err := activityController.repo.LinkDevices(1000)
+		if err != nil {
+			log.Printf("linking activities to devices failed: %v", err)
+			return
+		}
+		total += n
+		if n == 0 {
+			break
+		}
+	}
+	if total > 0 {
+		log.Printf("linked %d existing activities to devices", total)
 	}
 }
 
@@ -86,15 +222,78 @@ func validateUUID(id string) bool {
 	return err == nil
 }
 
+// maxIdempotencyKeyLength bounds Idempotency-Key headers and EventIds.
+const maxIdempotencyKeyLength = 255
+
+const idempotencyPurgeInterval = 10 * time.Minute
+
+const changelogPruneInterval = time.Hour
+
+// idempotencyKey returns the key under which a create request is deduplicated:
+// the Idempotency-Key header, else the device's EventId, else "" when the
+// request is not idempotent or idempotency is disabled.
+func idempotencyKey(c *gin.Context, activity models.DeviceActivity) (string, error) {
+	if activityController.idempotencyWindow <= 0 {
+		return "", nil
+	}
+	if key := strings.TrimSpace(c.GetHeader("Idempotency-Key")); key != "" {
+		if len(key) > maxIdempotencyKeyLength {
+			return "", fmt.Errorf("Idempotency-Key must be at most %d characters", maxIdempotencyKeyLength)
+		}
+		return "key:" + key, nil
+	}
+	return eventKey(activity)
+}
+
+// eventKey returns the key under which an activity with an EventId is
+// deduplicated, or "" without one or when idempotency is disabled.
+func eventKey(activity models.DeviceActivity) (string, error) {
+	if activityController.idempotencyWindow <= 0 || activity.EventId == "" {
+		return "", nil
+	}
+	if len(activity.EventId) > maxIdempotencyKeyLength {
+		return "", fmt.Errorf("EventId must be at most %d characters", maxIdempotencyKeyLength)
+	}
+	return "event:" + activity.DeviceName + ":" + activity.EventId, nil
+}
+
+// requestHash hashes the request body read by ShouldBindBodyWith.
+func requestHash(c *gin.Context) string {
+	var body []byte
+	if cached, ok := c.Get(gin.BodyBytesKey); ok {
+		body, _ = cached.([]byte)
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// captureHeaders returns the request headers allowed by the header policy.
+func captureHeaders(c *gin.Context) map[string]string {
+	return activityController.headers.Capture(c.Request.Header)
+}
+
+// MigrateActivityHeaders rewrites the headers of stored activities to match
+// the configured header policy.
+func MigrateActivityHeaders(ctx context.Context, batchSize int, dryRun bool) (services.HeaderMigrationResult, error) {
+	return services.MigrateHeaders(ctx, activityController.repo, activityController.headers, batchSize, dryRun)
+}
+
 // CreateActivity godoc
 // @Summary Create a new activity
-// @Description Records a new device activity with headers
+// @Description Records a new device activity with the request headers allowed by the header policy;
+// @Description credential headers are redacted by default. SourceIP is derived from the connection and trusted proxies;
+// @Description the body value is kept as ClaimedSourceIP and SourceIPMismatch flags a disagreement. Timestamp is the device's own event time; when omitted
+// @Description the server receive time is used. Timestamps outside the configured skew bounds are rejected or flagged.
+// @Description A retry carrying the same Idempotency-Key header, or the same device and EventId, within the idempotency
+// @Description window returns the original response with the Idempotent-Replayed header instead of creating a duplicate.
 // @Tags activities
 // @Accept json
 // @Produce json
 // @Param activity body models.DeviceActivity true "Activity Data"
+// @Param Idempotency-Key header string false "Client-generated key identifying this request across retries"
 // @Success 201 {object} models.DeviceActivity
 // @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /activities [post]
 func CreateActivity(c *gin.Context) {
@@ -105,29 +304,58 @@ func CreateActivity(c *gin.Context) {
 	}()
 
 	var newActivity models.DeviceActivity
-	if err := c.BindJSON(&newActivity); err != nil {
+	// The body is kept so that idempotent requests can be compared by hash.
+	if err := c.ShouldBindBodyWith(&newActivity, binding.JSON); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-
-	headers := make(map[string]string)
-	for key, values := range c.Request.Header {
-		if len(values) > 0 {
-			headers[key] = values[0]
-		}
+	key, err := idempotencyKey(c, newActivity)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
-	if err := newActivity.SetHeaders(headers); err != nil {
+
+	if err := newActivity.SetHeaders(captureHeaders(c)); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	newActivity.UniqueId = utils.GenerateUUID()
-	newActivity.Timestamp = time.Now()
+	newActivity.Synthetic = false
+	activityController.clientIPs.Apply(&newActivity, c.Request)
+	clientTime, err := activityController.timestamps.Apply(&newActivity, start)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	err := activityController.repo.Create(newActivity)
+	if key == "" {
+		err = activityController.repo.Create(newActivity)
+	} else {
+		var replay *models.IdempotencyKey
+		replay, err = activityController.repo.CreateIdempotent(&newActivity, repositories.IdempotencyRequest{
+			Key:         key,
+			RequestHash: requestHash(c),
+			StatusCode:  http.StatusCreated,
+			TTL:         activityController.idempotencyWindow,
+		})
+		if replay != nil {
+			metrics.ActivityOperationsTotal.WithLabelValues("replay", newActivity.GridName, newActivity.DeviceName).Inc()
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(replay.StatusCode, "application/json; charset=utf-8", []byte(replay.Response))
+			return
+		}
+	}
+	if errors.Is(err, repositories.ErrIdempotencyKeyMismatch) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if clientTime {
+		activityController.clockOffsets.Observe(newActivity)
+	}
 
 	metrics.ActivityOperationsTotal.WithLabelValues("create", newActivity.GridName, newActivity.DeviceName).Inc()
 	c.JSON(http.StatusCreated, newActivity)
@@ -135,19 +363,29 @@ func CreateActivity(c *gin.Context) {
 
 // GetAllActivities godoc
 // @Summary Get all activities
-// @Description Retrieves all recorded device activities
+// @Description Retrieves recorded device activities one page at a time. The next page is linked in the Link header.
 // @Tags activities
 // @Produce json
+// @Param limit query int false "Page size (default 100, max 1000)"
+// @Param cursor query string false "Opaque cursor from the previous page's Link header"
+// @Param sort query string false "Sort order" Enums(timestamp, -timestamp)
 // @Success 200 {array} models.DeviceActivity
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /activities [get]
 func GetAllActivities(c *gin.Context) {
-	activities, err := activityController.repo.GetAll()
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	result, err := activityController.repo.List(page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, activities)
+	setNextPageLink(c, result.NextCursor)
+	c.JSON(http.StatusOK, result.Activities)
 }
 
 // GetActivitiesByDevice godoc
@@ -156,28 +394,92 @@ func GetAllActivities(c *gin.Context) {
 // @Tags activities
 // @Produce json
 // @Param device path string true "Device Name"
+// @Param limit query int false "Page size (default 100, max 1000)"
+// @Param cursor query string false "Opaque cursor from the previous page's Link header"
+// @Param sort query string false "Sort order" Enums(timestamp, -timestamp)
 // @Success 200 {array} models.DeviceActivity
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /activities/device/{device} [get]
 func GetActivitiesByDevice(c *gin.Context) {
 	deviceName := c.Param("device")
-	activities, err := activityController.repo.GetByDevice(deviceName)
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	result, err := activityController.repo.GetByDevice(deviceName, page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	setNextPageLink(c, result.NextCursor)
+	c.JSON(http.StatusOK, result.Activities)
+}
+
+// ActivitySearchRequest is the body of an activity search.
+type ActivitySearchRequest struct {
+	Filter repositories.ActivityFilter `json:"filter"`
+}
+
+// SearchActivities godoc
+// @Summary Search activities
+// @Description Finds activities matching a composable filter. Clauses can match a timestamp range, a set of actions,
+// @Description a source IP prefix or device/grid globs, and can be combined with "and"/"or" groups.
+// @Tags activities
+// @Accept json
+// @Produce json
+// @Param search body ActivitySearchRequest true "Search filter"
+// @Param limit query int false "Page size (default 100, max 1000)"
+// @Param cursor query string false "Opaque cursor from the previous page's Link header"
+// @Param sort query string false "Sort order" Enums(timestamp, -timestamp)
+// @Success 200 {array} models.DeviceActivity
+// @Failure 400 {object} repositories.FilterError
+// @Failure 500 {object} map[string]string
+// @Router /activities/search [post]
+func SearchActivities(c *gin.Context) {
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var request ActivitySearchRequest
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := activityController.repo.Search(request.Filter, page)
 	if err != nil {
+		var filterErr *repositories.FilterError
+		if errors.As(err, &filterErr) {
+			c.JSON(http.StatusBadRequest, filterErr)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, activities)
+	setNextPageLink(c, result.NextCursor)
+	c.JSON(http.StatusOK, result.Activities)
 }
 
 // DeleteActivity godoc
 // @Summary Delete an activity
-// @Description Deletes a specific activity by ID
+// @Description Moves a specific activity by ID to the trash, from where it can be restored until it is purged.
+// @Description With hard=true and the admin token in X-Admin-Token the activity is removed permanently instead.
 // @Tags activities
 // @Produce json
 // @Param id path string true "Activity ID"
+// @Param hard query bool false "Delete permanently (admin only)"
+// @Param X-Admin-Token header string false "Admin token, required for hard deletes"
 // @Success 204 "No Content"
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
 // @Router /activities/{id} [delete]
 func DeleteActivity(c *gin.Context) {
 	id := c.Param("id")
@@ -185,29 +487,70 @@ func DeleteActivity(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
 		return
 	}
-	err := activityController.repo.Delete(id)
+	hard, err := strconv.ParseBool(c.DefaultQuery("hard", "false"))
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "hard must be a boolean"})
+		return
+	}
+
+	if hard {
+		if !isAdmin(c) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "hard delete requires the admin token"})
+			return
+		}
+		err = activityController.repo.HardDelete(id)
+	} else {
+		err = activityController.repo.Delete(id)
+	}
+	if errors.Is(err, repositories.ErrActivityNotFound) {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	c.Status(http.StatusNoContent)
 }
 
 // GetActivitiesByGrid godoc
 // @Summary Get activities by grid
-// @Description Retrieves activities for a specific grid
+// @Description Retrieves activities for a specific grid, and with descendants=true for all grids below it
 // @Tags activities
 // @Produce json
 // @Param grid path string true "Grid Name"
+// @Param descendants query bool false "Include activities of descendant grids"
+// @Param limit query int false "Page size (default 100, max 1000)"
+// @Param cursor query string false "Opaque cursor from the previous page's Link header"
+// @Param sort query string false "Sort order" Enums(timestamp, -timestamp)
 // @Success 200 {array} models.DeviceActivity
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /activities/grid/{grid} [get]
 func GetActivitiesByGrid(c *gin.Context) {
 	gridName := c.Param("grid")
-	activities, err := activityController.repo.GetByGrid(gridName)
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	descendants, err := parseDescendants(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	grids := []string{gridName}
+	if descendants {
+		if grids, err = activityController.grids.Subtree(gridName); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	result, err := activityController.repo.GetByGrids(grids, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, activities)
+	setNextPageLink(c, result.NextCursor)
+	c.JSON(http.StatusOK, result.Activities)
 } 
\ No newline at end of file
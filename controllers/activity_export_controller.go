@@ -0,0 +1,356 @@
+package controllers
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const exportChunkSize = 1000
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+)
+
+// exportedActivity nests the stored Headers JSON string as an object.
+type exportedActivity struct {
+	models.DeviceActivity
+	Headers map[string]string
+}
+
+// ExportActivities godoc
+// @Summary Export activities
+// @Description Streams activities as CSV or NDJSON, selected by the format parameter or the Accept header.
+// @Description The export pages through the store in chunks and is gzip compressed when the client accepts it.
+// @Description In CSV the listed header parameters become "header.<Name>" columns, otherwise Headers is one JSON column;
+// @Description in NDJSON Headers is a nested object. Both carry the same fields; DeletedAt is empty in CSV for live activities.
+// @Description In the Accept header q-values are respected and an exact media type beats a wildcard; */* alone means CSV.
+// @Tags activities
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "Export format" Enums(csv, ndjson)
+// @Param from query string false "Only activities at or after this time (RFC 3339)"
+// @Param to query string false "Only activities before this time (RFC 3339)"
+// @Param device query []string false "Device names or globs" collectionFormat(multi)
+// @Param grid query []string false "Grid names or globs" collectionFormat(multi)
+// @Param descendants query bool false "Also match the descendants of each grid name"
+// @Param action query []string false "Actions" collectionFormat(multi)
+// @Param source_ip_mismatch query bool false "Only activities whose claimed source IP does (true) or does not (false) match"
+// @Param header query []string false "Request headers to expand into CSV columns" collectionFormat(multi)
+// @Param sort query string false "Sort order" Enums(timestamp, -timestamp)
+// @Success 200 {string} string "export"
+// @Failure 400 {object} map[string]string
+// @Failure 406 {object} map[string]string
+// @Router /activities/export [get]
+func ExportActivities(c *gin.Context) {
+	format := exportFormat(c)
+	if format == "" {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": "format must be csv or ndjson"})
+		return
+	}
+
+	filter, err := parseExportFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sort, err := repositories.ParseSortOrder(c.Query("sort"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	headerColumns := queryList(c, "header")
+
+	// Fetch the first chunk before committing to a 200 so query errors can still be reported.
+	page := repositories.PageRequest{Limit: exportChunkSize, Sort: sort}
+	result, err := activityController.repo.Search(filter, page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := "activities." + format
+	if format == exportFormatCSV {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+	} else {
+		c.Header("Content-Type", "application/x-ndjson")
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Vary", "Accept, Accept-Encoding")
+
+	var out io.Writer = c.Writer
+	if strings.Contains(c.GetHeader("Accept-Encoding"), "gzip") {
+		c.Header("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(c.Writer)
+		defer gz.Close()
+		out = gz
+	}
+	c.Status(http.StatusOK)
+
+	var writer activityWriter
+	if format == exportFormatCSV {
+		writer = newCSVActivityWriter(out, headerColumns)
+	} else {
+		writer = &ndjsonActivityWriter{encoder: json.NewEncoder(out)}
+	}
+
+	for {
+		for _, activity := range result.Activities {
+			if err := writer.Write(activity); err != nil {
+				log.Printf("activity export aborted: %v", err)
+				return
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			log.Printf("activity export aborted: %v", err)
+			return
+		}
+		if gz, ok := out.(*gzip.Writer); ok {
+			gz.Flush()
+		}
+		c.Writer.Flush()
+
+		if result.NextCursor == "" || c.Request.Context().Err() != nil {
+			return
+		}
+		page.Cursor, _ = repositories.DecodeCursor(result.NextCursor)
+		if result, err = activityController.repo.Search(filter, page); err != nil {
+			log.Printf("activity export aborted: %v", err)
+			return
+		}
+	}
+}
+
+// exportFormat picks the format from the format parameter, then the Accept header.
+func exportFormat(c *gin.Context) string {
+	switch format := c.Query("format"); format {
+	case exportFormatCSV, exportFormatNDJSON:
+		return format
+	case "":
+	default:
+		return ""
+	}
+	return negotiateExportFormat(c.GetHeader("Accept"))
+}
+
+// exportMediaTypes are the media types an export can be served as, in order
+// of preference when the client accepts several equally.
+var exportMediaTypes = []struct{ mediaType, format string }{
+	{"text/csv", exportFormatCSV},
+	{"application/x-ndjson", exportFormatNDJSON},
+	{"application/jsonl", exportFormatNDJSON},
+}
+
+// negotiateExportFormat picks the export format an Accept header prefers, or
+// "" if it accepts none. Each media type takes the q-value of the most
+// specific range matching it, so an exact type beats type/* and */*, and a
+// type with q=0 is refused. Ties go to the more specific match, then to CSV.
+func negotiateExportFormat(accept string) string {
+	if strings.TrimSpace(accept) == "" {
+		return exportFormatCSV
+	}
+	ranges := parseAccept(accept)
+
+	best, bestQuality, bestSpecificity := "", 0.0, -1
+	for _, offer := range exportMediaTypes {
+		quality, specificity := 0.0, -1
+		for _, r := range ranges {
+			if s := r.matches(offer.mediaType); s > specificity {
+				quality, specificity = r.quality, s
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		if quality > bestQuality || (quality == bestQuality && specificity > bestSpecificity) {
+			best, bestQuality, bestSpecificity = offer.format, quality, specificity
+		}
+	}
+	return best
+}
+
+// acceptRange is one media range of an Accept header.
+type acceptRange struct {
+	mediaType string
+	quality   float64
+}
+
+func parseAccept(accept string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		r := acceptRange{mediaType: strings.ToLower(strings.TrimSpace(params[0])), quality: 1}
+		if r.mediaType == "" {
+			continue
+		}
+		for _, param := range params[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if !strings.EqualFold(strings.TrimSpace(name), "q") {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || q < 0 || q > 1 {
+				q = 0
+			}
+			r.quality = q
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// matches returns how specifically the range matches mediaType: 2 for the
+// exact type, 1 for type/*, 0 for */* and -1 if it does not match.
+func (r acceptRange) matches(mediaType string) int {
+	switch {
+	case r.mediaType == mediaType:
+		return 2
+	case r.mediaType == "*/*":
+		return 0
+	case strings.HasSuffix(r.mediaType, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(r.mediaType, "*")):
+		return 1
+	}
+	return -1
+}
+
+// parseExportFilter combines the from/to range with the device, grid and action parameters.
+func parseExportFilter(c *gin.Context) (repositories.ActivityFilter, error) {
+	filter, err := parseFilterParams(c)
+	if err != nil {
+		return filter, err
+	}
+
+	var timeRange repositories.TimeRange
+	if value := c.Query("from"); value != "" {
+		from, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, err
+		}
+		timeRange.From = &from
+	}
+	if value := c.Query("to"); value != "" {
+		to, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, err
+		}
+		timeRange.To = &to
+	}
+	if timeRange.From == nil && timeRange.To == nil {
+		return filter, nil
+	}
+
+	clauses := []repositories.ActivityFilter{{Timestamp: &timeRange}}
+	if !filter.IsEmpty() {
+		clauses = append(clauses, filter)
+	}
+	filter = repositories.ActivityFilter{And: clauses}
+	_, err = filter.Condition()
+	return filter, err
+}
+
+type activityWriter interface {
+	Write(activity models.DeviceActivity) error
+	Flush() error
+}
+
+type ndjsonActivityWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonActivityWriter) Write(activity models.DeviceActivity) error {
+	headers, err := activity.GetHeaders()
+	if err != nil {
+		headers = nil
+	}
+	return w.encoder.Encode(exportedActivity{DeviceActivity: activity, Headers: headers})
+}
+
+func (w *ndjsonActivityWriter) Flush() error {
+	return nil
+}
+
+type csvActivityWriter struct {
+	writer        *csv.Writer
+	headerColumns []string
+	wroteHeader   bool
+}
+
+func newCSVActivityWriter(out io.Writer, headerColumns []string) *csvActivityWriter {
+	for i, name := range headerColumns {
+		headerColumns[i] = http.CanonicalHeaderKey(name)
+	}
+	return &csvActivityWriter{writer: csv.NewWriter(out), headerColumns: headerColumns}
+}
+
+// writeHeader writes the column names once, so an empty export still has them.
+func (w *csvActivityWriter) writeHeader() error {
+	if w.wroteHeader {
+		return nil
+	}
+	w.wroteHeader = true
+	columns := []string{"Id", "UniqueId", "Timestamp", "ReceivedAt", "TimestampFlagged", "DeviceName", "GridName", "Action",
+		"SourceIP", "ClaimedSourceIP", "SourceIPMismatch", "DeviceId", "Synthetic", "EventId", "DeletedAt"}
+	if len(w.headerColumns) == 0 {
+		columns = append(columns, "Headers")
+	}
+	for _, name := range w.headerColumns {
+		columns = append(columns, "header."+name)
+	}
+	return w.writer.Write(columns)
+}
+
+func (w *csvActivityWriter) Write(activity models.DeviceActivity) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	record := []string{
+		strconv.FormatUint(activity.Id, 10),
+		activity.UniqueId,
+		activity.Timestamp.Format(time.RFC3339Nano),
+		activity.ReceivedAt.Format(time.RFC3339Nano),
+		strconv.FormatBool(activity.TimestampFlagged),
+		activity.DeviceName,
+		activity.GridName,
+		activity.Action,
+		activity.SourceIP,
+		activity.ClaimedSourceIP,
+		strconv.FormatBool(activity.SourceIPMismatch),
+		strconv.FormatUint(activity.DeviceId, 10),
+		strconv.FormatBool(activity.Synthetic),
+		activity.EventId,
+		"",
+	}
+	if activity.IsDeleted() {
+		record[len(record)-1] = activity.DeletedAt.Format(time.RFC3339Nano)
+	}
+	if len(w.headerColumns) == 0 {
+		record = append(record, activity.Headers)
+	} else {
+		headers, _ := activity.GetHeaders()
+		for _, name := range w.headerColumns {
+			record = append(record, headers[name])
+		}
+	}
+	return w.writer.Write(record)
+}
+
+func (w *csvActivityWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"go-rest-api/models"
+	"testing"
+	"time"
+)
+
+func TestNegotiateExportFormat(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", exportFormatCSV},
+		{"*/*", exportFormatCSV},
+		{"text/csv", exportFormatCSV},
+		{"application/x-ndjson", exportFormatNDJSON},
+		{"application/jsonl", exportFormatNDJSON},
+		{"application/x-ndjson, */*", exportFormatNDJSON},
+		{"*/*, application/x-ndjson", exportFormatNDJSON},
+		{"text/*, application/x-ndjson", exportFormatNDJSON},
+		{"text/*", exportFormatCSV},
+		{"text/csv;q=0.5, application/x-ndjson", exportFormatNDJSON},
+		{"text/csv, application/x-ndjson;q=0.9", exportFormatCSV},
+		{"text/csv, application/x-ndjson", exportFormatCSV},
+		{"text/csv;q=0, */*", exportFormatNDJSON},
+		{"application/x-ndjson;q=0, */*;q=0.1", exportFormatCSV},
+		{"Application/X-NDJSON; Q=0.8, text/csv; q=0.3", exportFormatNDJSON},
+		{"application/json", ""},
+		{"text/csv;q=0", ""},
+		{"text/csv;q=x", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			if got := negotiateExportFormat(tt.accept); got != tt.want {
+				t.Errorf("negotiateExportFormat(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVActivityWriter(t *testing.T) {
+	var out bytes.Buffer
+	writer := newCSVActivityWriter(&out, nil)
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	activities := []models.DeviceActivity{
+		{Id: 1, DeviceName: "d1", DeviceId: 7, EventId: "e1", Synthetic: true, Timestamp: at, Headers: "{}"},
+		{Id: 2, DeviceName: "d1", Timestamp: at, DeletedAt: at.Add(time.Hour)},
+	}
+	for _, activity := range activities {
+		if err := writer.Write(activity); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want a header and 2 rows", len(records))
+	}
+	row := func(i int) map[string]string {
+		values := make(map[string]string)
+		for j, column := range records[0] {
+			values[column] = records[i][j]
+		}
+		return values
+	}
+	if first := row(1); first["DeviceId"] != "7" || first["EventId"] != "e1" || first["Synthetic"] != "true" || first["DeletedAt"] != "" {
+		t.Errorf("first row = %v", first)
+	}
+	if second := row(2); second["DeletedAt"] != "2026-01-02T04:04:05Z" {
+		t.Errorf("DeletedAt = %q", second["DeletedAt"])
+	}
+}
@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	streamReplayBatch = 500
+	streamKeepAlive   = 15 * time.Second
+
+	// websocketTryAgainLater is the close code sent to evicted WebSocket subscribers.
+	websocketTryAgainLater = 1013
+)
+
+var (
+	errStreamEvicted         = errors.New("subscriber evicted for falling behind")
+	errStreamOriginForbidden = errors.New("origin is not allowed to open a stream")
+)
+
+// StreamActivities godoc
+// @Summary Stream new activities
+// @Description Streams activities as they are created, as Server-Sent Events or over a WebSocket when the request asks
+// @Description for an upgrade. Each event id is the activity Id; reconnect with Last-Event-ID (or last_event_id) to
+// @Description replay missed activities. Subscribers that fall behind are disconnected and should resume.
+// @Description If activities after the one resumed from are no longer stored, because retention pruned them, the
+// @Description answer is 410 with first_available_id instead; resume after first_available_id - 1 to accept the gap.
+// @Description WebSocket upgrades from a browser page are refused with 403 unless the page has the API's own origin or
+// @Description one listed in ACTIVITY_STREAM_ALLOWED_ORIGINS.
+// @Tags activities
+// @Produce text/event-stream
+// @Param device query []string false "Device names or globs" collectionFormat(multi)
+// @Param grid query []string false "Grid names or globs" collectionFormat(multi)
+// @Param descendants query bool false "Also match the descendants of each grid name"
+// @Param action query []string false "Actions" collectionFormat(multi)
+// @Param source_ip_mismatch query bool false "Only activities whose claimed source IP does (true) or does not (false) match"
+// @Param last_event_id query int false "Resume after this activity Id"
+// @Param Last-Event-ID header int false "Resume after this activity Id"
+// @Success 200 {string} string "event stream"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {string} string "origin not allowed"
+// @Failure 410 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /activities/stream [get]
+func StreamActivities(c *gin.Context) {
+	filter, err := parseFilterParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	lastEventId := c.GetHeader("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = c.Query("last_event_id")
+	}
+	var resumeAfter *uint64
+	if lastEventId != "" {
+		id, err := strconv.ParseUint(lastEventId, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Last-Event-ID must be an activity Id"})
+			return
+		}
+		resumeAfter = &id
+
+		lowest, ok, err := activityController.repo.LowestIdWithDeleted()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		// Replaying would silently skip the activities pruned in between.
+		if ok && lowest > id+1 {
+			c.JSON(http.StatusGone, gin.H{
+				"error":              "activities after Last-Event-ID are no longer stored",
+				"first_available_id": lowest,
+			})
+			return
+		}
+	}
+
+	if strings.EqualFold(c.GetHeader("Upgrade"), "websocket") {
+		serveActivityWebSocket(c, filter, resumeAfter)
+		return
+	}
+	serveActivitySSE(c, filter, resumeAfter)
+}
+
+func serveActivitySSE(c *gin.Context, filter repositories.ActivityFilter, resumeAfter *uint64) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	send := func(activity models.DeviceActivity) error {
+		data, err := json.Marshal(activity)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(c.Writer, "id: %d\nevent: activity\ndata: %s\n\n", activity.Id, data); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+	keepAlive := func() error {
+		if _, err := fmt.Fprint(c.Writer, ": keep-alive\n\n"); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	err := runActivityStream(c.Request.Context(), filter, resumeAfter, send, keepAlive)
+	if errors.Is(err, errStreamEvicted) {
+		fmt.Fprintf(c.Writer, "event: evicted\ndata: {\"error\":%q}\n\n", err.Error())
+		c.Writer.Flush()
+	}
+}
+
+func serveActivityWebSocket(c *gin.Context, filter repositories.ActivityFilter, resumeAfter *uint64) {
+	server := websocket.Server{
+		// Browsers let any page open a WebSocket, so the origin is checked
+		// here; a failed handshake is answered with 403.
+		Handshake: func(_ *websocket.Config, req *http.Request) error {
+			if !streamOriginAllowed(req.Header.Get("Origin"), req.Host, activityController.streamOrigins) {
+				return errStreamOriginForbidden
+			}
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+
+			ctx, cancel := context.WithCancel(c.Request.Context())
+			defer cancel()
+			// The stream is one-way; reading only detects the client going away.
+			go func() {
+				var discard []byte
+				for websocket.Message.Receive(ws, &discard) == nil {
+				}
+				cancel()
+			}()
+
+			send := func(activity models.DeviceActivity) error {
+				return websocket.JSON.Send(ws, activity)
+			}
+			err := runActivityStream(ctx, filter, resumeAfter, send, nil)
+			if errors.Is(err, errStreamEvicted) {
+				ws.WriteClose(websocketTryAgainLater)
+			}
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// streamOriginAllowed reports whether a page from origin may open a stream
+// WebSocket on host: one from the same host or from an allowed origin. A
+// request without an Origin header does not come from a browser page.
+func streamOriginAllowed(origin, host string, allowed []string) bool {
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	if strings.EqualFold(u.Host, host) {
+		return true
+	}
+	origin = strings.ToLower(u.Scheme + "://" + u.Host)
+	for _, candidate := range allowed {
+		if candidate == "*" || candidate == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// runActivityStream replays stored activities after resumeAfter and then
+// forwards live activities from the hub until ctx ends or the subscriber is
+// evicted. Subscribing before the replay guarantees nothing written in between
+// is lost; live activities already sent by the replay are skipped.
+func runActivityStream(ctx context.Context, filter repositories.ActivityFilter, resumeAfter *uint64,
+	send func(models.DeviceActivity) error, keepAlive func() error) error {
+	sub := activityController.hub.Subscribe(filter)
+	defer activityController.hub.Unsubscribe(sub)
+
+	var replayedUpTo uint64
+	if resumeAfter != nil {
+		replayedUpTo = *resumeAfter
+		for {
+			batch, err := activityController.repo.GetAfterId(replayedUpTo, filter, streamReplayBatch)
+			if err != nil {
+				return err
+			}
+			for _, activity := range batch {
+				if err := send(activity); err != nil {
+					return err
+				}
+				replayedUpTo = activity.Id
+			}
+			if len(batch) < streamReplayBatch {
+				break
+			}
+		}
+	}
+
+	ticker := time.NewTicker(streamKeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case activity, ok := <-sub.C:
+			if !ok {
+				if sub.Evicted() {
+					return errStreamEvicted
+				}
+				return nil
+			}
+			if activity.Id <= replayedUpTo {
+				continue
+			}
+			if err := send(activity); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if keepAlive != nil {
+				if err := keepAlive(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package controllers
+
+import "testing"
+
+func TestStreamOriginAllowed(t *testing.T) {
+	allowed := []string{"https://app.example.com"}
+	tests := []struct {
+		name    string
+		origin  string
+		host    string
+		allowed []string
+		want    bool
+	}{
+		{"no origin", "", "api.example.com", nil, true},
+		{"same host", "https://api.example.com", "api.example.com", nil, true},
+		{"same host and port", "http://localhost:8080", "localhost:8080", nil, true},
+		{"other port", "http://localhost:3000", "localhost:8080", nil, false},
+		{"listed", "https://app.example.com", "api.example.com", allowed, true},
+		{"listed, other case", "HTTPS://App.Example.com", "api.example.com", allowed, true},
+		{"other scheme", "http://app.example.com", "api.example.com", allowed, false},
+		{"not listed", "https://evil.example", "api.example.com", allowed, false},
+		{"wildcard", "https://evil.example", "api.example.com", []string{"*"}, true},
+		{"opaque origin", "null", "api.example.com", allowed, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := streamOriginAllowed(tt.origin, tt.host, tt.allowed); got != tt.want {
+				t.Errorf("streamOriginAllowed(%q, %q) = %v, want %v", tt.origin, tt.host, got, tt.want)
+			}
+		})
+	}
+}
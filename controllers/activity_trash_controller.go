@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"go-rest-api/repositories"
+	"go-rest-api/services"
+	"go-rest-api/utils"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTrash godoc
+// @Summary List deleted activities
+// @Description Lists activities in the trash one page at a time. They are purged for good after the trash retention period.
+// @Tags activities
+// @Produce json
+// @Param limit query int false "Page size (default 100, max 1000)"
+// @Param cursor query string false "Opaque cursor from the previous page's Link header"
+// @Param sort query string false "Sort order" Enums(timestamp, -timestamp)
+// @Success 200 {array} models.DeviceActivity
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /activities/trash [get]
+func GetTrash(c *gin.Context) {
+	page, err := parsePageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	result, err := activityController.repo.GetTrash(page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	setNextPageLink(c, result.NextCursor)
+	c.JSON(http.StatusOK, result.Activities)
+}
+
+// RestoreActivity godoc
+// @Summary Restore a deleted activity
+// @Description Takes an activity out of the trash so that it shows up in queries again
+// @Tags activities
+// @Produce json
+// @Param id path string true "Activity ID"
+// @Success 200 {object} models.DeviceActivity
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /activities/trash/{id}/restore [post]
+func RestoreActivity(c *gin.Context) {
+	id := c.Param("id")
+	if !utils.ValidateUUID(id) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID format"})
+		return
+	}
+	activity, err := activityController.repo.Restore(id)
+	if errors.Is(err, repositories.ErrActivityNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "activity not found in trash"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, activity)
+}
+
+const (
+	bulkDeleteBatchSize         = 1000
+	defaultBulkDeleteSampleSize = 10
+)
+
+// BulkDeleteRequest is the body of a delete by filter.
+type BulkDeleteRequest struct {
+	Filter repositories.ActivityFilter `json:"filter"`
+	// DryRun only counts and samples the matching activities.
+	DryRun bool `json:"dry_run"`
+	// Hard removes the activities permanently instead of moving them to the trash. Admin only.
+	Hard bool `json:"hard"`
+	// SampleSize is the number of matching activities returned, newest first (default 10).
+	SampleSize *int `json:"sample_size"`
+}
+
+// DeleteActivitiesByFilter godoc
+// @Summary Delete activities by filter
+// @Description Moves every activity matching a search filter to the trash, in batches of separate transactions.
+// @Description With dry_run the matches are only counted and sampled. With hard and the admin token in X-Admin-Token
+// @Description the activities are removed permanently, including matching ones already in the trash. An empty filter
+// @Description is rejected.
+// @Tags activities
+// @Accept json
+// @Produce json
+// @Param request body BulkDeleteRequest true "Filter and options"
+// @Param X-Admin-Token header string false "Admin token, required for hard deletes"
+// @Success 200 {object} services.BulkDeleteResult
+// @Failure 400 {object} repositories.FilterError
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /activities:bulkDelete [post]
+func DeleteActivitiesByFilter(c *gin.Context) {
+	var request BulkDeleteRequest
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if request.Filter.IsEmpty() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "filter must not be empty"})
+		return
+	}
+	sampleSize := defaultBulkDeleteSampleSize
+	if request.SampleSize != nil {
+		sampleSize = *request.SampleSize
+		if sampleSize < 0 || sampleSize > repositories.MaxPageLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "sample_size must be between 0 and 1000"})
+			return
+		}
+	}
+	if request.Hard && !request.DryRun && !isAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "hard delete requires the admin token"})
+		return
+	}
+
+	result, err := services.BulkDelete(c.Request.Context(), activityController.repo, request.Filter,
+		bulkDeleteBatchSize, sampleSize, request.Hard, request.DryRun)
+	if err != nil {
+		var filterErr *repositories.FilterError
+		if errors.As(err, &filterErr) {
+			c.JSON(http.StatusBadRequest, filterErr)
+			return
+		}
+		log.Printf("bulk delete stopped after %d of %d activities: %v", result.Deleted, result.Matched, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "deleted": result.Deleted})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// isAdmin reports whether the request carries the configured admin token.
+// Admin-only operations are disabled while no token is configured.
+func isAdmin(c *gin.Context) bool {
+	token := activityController.adminToken
+	if token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(c.GetHeader("X-Admin-Token")), []byte(token)) == 1
+}
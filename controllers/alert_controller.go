@@ -0,0 +1,312 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"go-rest-api/services"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AlertRuleRequest is the writable part of an alert rule. The rule fires when
+// more than Threshold activities matching Filter arrive within WindowSeconds,
+// counted separately for every combination of GroupBy values.
+type AlertRuleRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Enabled defaults to true.
+	Enabled       *bool                        `json:"enabled"`
+	Filter        *repositories.ActivityFilter `json:"filter"`
+	GroupBy       []string                     `json:"group_by"`
+	WindowSeconds int64                        `json:"window_seconds"`
+	Threshold     int64                        `json:"threshold"`
+	Webhooks      []string                     `json:"webhooks"`
+}
+
+// AlertRuleResponse is a rule with its filter, grouping and webhooks decoded.
+type AlertRuleResponse struct {
+	models.AlertRule
+	Filter   repositories.ActivityFilter
+	GroupBy  []string
+	Webhooks []string
+}
+
+// AlertResponse is an alert with its group decoded.
+type AlertResponse struct {
+	models.Alert
+	Group map[string]string
+}
+
+func newAlertRuleResponse(rule models.AlertRule) (AlertRuleResponse, error) {
+	response := AlertRuleResponse{AlertRule: rule, GroupBy: []string{}}
+	if rule.Filter != "" {
+		if err := json.Unmarshal([]byte(rule.Filter), &response.Filter); err != nil {
+			return response, err
+		}
+	}
+	if rule.GroupBy != "" {
+		response.GroupBy = strings.Split(rule.GroupBy, ",")
+	}
+	webhooks, err := rule.GetWebhooks()
+	response.Webhooks = webhooks
+	return response, err
+}
+
+// apply copies the request onto a rule and validates the result.
+func (req AlertRuleRequest) apply(rule *models.AlertRule) error {
+	rule.Description = req.Description
+	rule.Enabled = req.Enabled == nil || *req.Enabled
+	rule.WindowSeconds = req.WindowSeconds
+	rule.Threshold = req.Threshold
+	rule.GroupBy = strings.Join(req.GroupBy, ",")
+
+	rule.Filter = ""
+	if req.Filter != nil && !req.Filter.IsEmpty() {
+		filter, err := json.Marshal(req.Filter)
+		if err != nil {
+			return err
+		}
+		rule.Filter = string(filter)
+	}
+	if err := rule.SetWebhooks(req.Webhooks); err != nil {
+		return err
+	}
+	return services.ValidateAlertRule(*rule)
+}
+
+// GetAlertRules godoc
+// @Summary List alert rules
+// @Description Lists every alert rule ordered by name
+// @Tags alerts
+// @Produce json
+// @Success 200 {array} AlertRuleResponse
+// @Failure 500 {object} map[string]string
+// @Router /alerts/rules [get]
+func GetAlertRules(c *gin.Context) {
+	rules, err := activityController.alertRules.GetRules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := make([]AlertRuleResponse, len(rules))
+	for i, rule := range rules {
+		if response[i], err = newAlertRuleResponse(rule); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetAlertRule godoc
+// @Summary Get an alert rule
+// @Tags alerts
+// @Produce json
+// @Param name path string true "Rule Name"
+// @Success 200 {object} AlertRuleResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/rules/{name} [get]
+func GetAlertRule(c *gin.Context) {
+	rule, err := activityController.alertRules.GetRule(c.Param("name"))
+	respondWithAlertRule(c, http.StatusOK, rule, err)
+}
+
+// CreateAlertRule godoc
+// @Summary Create an alert rule
+// @Description Creates a rule evaluated against every new activity. Use threshold 0 to alert on any matching activity.
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param rule body AlertRuleRequest true "Alert Rule"
+// @Success 201 {object} AlertRuleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/rules [post]
+func CreateAlertRule(c *gin.Context) {
+	var req AlertRuleRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule := &models.AlertRule{Name: req.Name}
+	if err := req.apply(rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err := activityController.alertRules.CreateRule(rule)
+	if err == nil {
+		reloadAlertRules()
+	}
+	respondWithAlertRule(c, http.StatusCreated, rule, err)
+}
+
+// UpdateAlertRule godoc
+// @Summary Update an alert rule
+// @Description Replaces an alert rule. Its sliding windows start over, so firing alerts resolve unless the threshold is exceeded again.
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param name path string true "Rule Name"
+// @Param rule body AlertRuleRequest true "Alert Rule"
+// @Success 200 {object} AlertRuleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/rules/{name} [put]
+func UpdateAlertRule(c *gin.Context) {
+	var req AlertRuleRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	name := c.Param("name")
+	if req.Name != "" && req.Name != name {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "alert rules cannot be renamed"})
+		return
+	}
+
+	var invalid error
+	rule, err := activityController.alertRules.UpdateRule(name, func(rule *models.AlertRule) error {
+		invalid = req.apply(rule)
+		return invalid
+	})
+	if invalid != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalid.Error()})
+		return
+	}
+	if err == nil {
+		reloadAlertRules()
+	}
+	respondWithAlertRule(c, http.StatusOK, rule, err)
+}
+
+// DeleteAlertRule godoc
+// @Summary Delete an alert rule
+// @Description Deletes a rule and resolves its firing alerts. Past alerts are kept.
+// @Tags alerts
+// @Param name path string true "Rule Name"
+// @Success 204 "No Content"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/rules/{name} [delete]
+func DeleteAlertRule(c *gin.Context) {
+	err := activityController.alertRules.DeleteRule(c.Param("name"))
+	if errors.Is(err, repositories.ErrAlertRuleNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	reloadAlertRules()
+	c.Status(http.StatusNoContent)
+}
+
+// TestAlertRule godoc
+// @Summary Send a test notification
+// @Description Posts a test notification with "test": true to every webhook of the rule once and reports the outcome per webhook
+// @Tags alerts
+// @Produce json
+// @Param name path string true "Rule Name"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/rules/{name}/test [post]
+func TestAlertRule(c *gin.Context) {
+	rule, err := activityController.alertRules.GetRule(c.Param("name"))
+	if errors.Is(err, repositories.ErrAlertRuleNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	results, err := activityController.alerts.Test(c.Request.Context(), *rule)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, results)
+}
+
+// GetAlerts godoc
+// @Summary List alerts
+// @Description Lists alerts, most recent first
+// @Tags alerts
+// @Produce json
+// @Param status query string false "firing or resolved"
+// @Param limit query int false "Maximum number of alerts (default 100, max 1000)"
+// @Success 200 {array} AlertResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts [get]
+func GetAlerts(c *gin.Context) {
+	status := c.Query("status")
+	if status != "" && status != models.AlertFiring && status != models.AlertResolved {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be firing or resolved"})
+		return
+	}
+	limit, err := parseLimit(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if limit == 0 {
+		limit = repositories.DefaultPageLimit
+	}
+
+	alerts, err := activityController.alertRules.GetAlerts(status, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	response := make([]AlertResponse, len(alerts))
+	for i, alert := range alerts {
+		group, err := alert.GetGroup()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response[i] = AlertResponse{Alert: alert, Group: group}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func reloadAlertRules() {
+	if err := activityController.alerts.Reload(); err != nil {
+		log.Printf("reloading alert rules failed: %v", err)
+	}
+}
+
+func respondWithAlertRule(c *gin.Context, status int, rule *models.AlertRule, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrAlertRuleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, repositories.ErrAlertRuleExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := newAlertRuleResponse(*rule)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(status, response)
+}
@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"go-rest-api/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChangeResponse is a changelog entry with the activity decoded.
+type ChangeResponse struct {
+	Sequence   uint64          `json:"sequence"`
+	Operation  string          `json:"operation"`
+	ActivityId uint64          `json:"activity_id"`
+	UniqueId   string          `json:"unique_id"`
+	ChangedAt  time.Time       `json:"changed_at"`
+	Activity   json.RawMessage `json:"activity,omitempty" swaggertype:"object"`
+}
+
+// ChangeFeedResponse is a page of the change feed. Next is the sequence to
+// pass as after for the following request; it equals after when there were
+// no new changes.
+type ChangeFeedResponse struct {
+	Changes []ChangeResponse `json:"changes"`
+	Next    uint64           `json:"next"`
+}
+
+// ChangeCheckpointRequest moves a consumer checkpoint.
+type ChangeCheckpointRequest struct {
+	Sequence uint64 `json:"sequence"`
+}
+
+// GetChanges godoc
+// @Summary Read the activity change feed
+// @Description Returns created, updated, deleted, restored and purged activities after a sequence number, oldest first.
+// @Description Every mutation is recorded in the same transaction as the change itself, so reading from the last
+// @Description processed sequence yields each change exactly once and in order. With wait the request long-polls
+// @Description until a change arrives or the wait elapses.
+// @Tags changes
+// @Produce json
+// @Param after query int false "Return changes after this sequence (default 0)"
+// @Param checkpoint query string false "Start after the sequence stored in this checkpoint instead of after"
+// @Param limit query int false "Maximum number of changes (default 100, max 1000)"
+// @Param wait query string false "How long to wait for new changes, e.g. 30s (capped by CHANGE_FEED_MAX_WAIT)"
+// @Success 200 {object} ChangeFeedResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /changes [get]
+func GetChanges(c *gin.Context) {
+	changelog := activityController.repo.Changelog()
+
+	var after uint64
+	var err error
+	if value := c.Query("after"); value != "" {
+		if after, err = strconv.ParseUint(value, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "after must be a non-negative integer"})
+			return
+		}
+	}
+	if name := c.Query("checkpoint"); name != "" {
+		if c.Query("after") != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "use either after or checkpoint"})
+			return
+		}
+		checkpoint, err := changelog.GetCheckpoint(name)
+		if errors.Is(err, repositories.ErrChangeCheckpointNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		after = checkpoint.Sequence
+	}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if limit == 0 {
+		limit = repositories.DefaultPageLimit
+	}
+
+	var wait time.Duration
+	if value := c.Query("wait"); value != "" {
+		if wait, err = time.ParseDuration(value); err != nil || wait < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("wait must be a duration such as 30s, got %q", value)})
+			return
+		}
+		if wait > activityController.changeFeedMaxWait {
+			wait = activityController.changeFeedMaxWait
+		}
+	}
+
+	changes, err := services.WaitForChanges(c.Request.Context(), changelog, after, limit, wait)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	response := ChangeFeedResponse{Changes: make([]ChangeResponse, len(changes)), Next: after}
+	for i, change := range changes {
+		response.Changes[i] = newChangeResponse(change)
+		response.Next = change.Id
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func newChangeResponse(change models.ActivityChange) ChangeResponse {
+	response := ChangeResponse{
+		Sequence:   change.Id,
+		Operation:  change.Operation,
+		ActivityId: change.ActivityId,
+		UniqueId:   change.UniqueId,
+		ChangedAt:  change.ChangedAt,
+	}
+	if change.Activity != "" {
+		response.Activity = json.RawMessage(change.Activity)
+	}
+	return response
+}
+
+// GetChangeCheckpoints godoc
+// @Summary List change feed checkpoints
+// @Tags changes
+// @Produce json
+// @Success 200 {array} models.ChangeCheckpoint
+// @Failure 500 {object} map[string]string
+// @Router /changes/checkpoints [get]
+func GetChangeCheckpoints(c *gin.Context) {
+	checkpoints, err := activityController.repo.Changelog().GetCheckpoints()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, checkpoints)
+}
+
+// GetChangeCheckpoint godoc
+// @Summary Get a change feed checkpoint
+// @Tags changes
+// @Produce json
+// @Param name path string true "Consumer Name"
+// @Success 200 {object} models.ChangeCheckpoint
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /changes/checkpoints/{name} [get]
+func GetChangeCheckpoint(c *gin.Context) {
+	checkpoint, err := activityController.repo.Changelog().GetCheckpoint(c.Param("name"))
+	if errors.Is(err, repositories.ErrChangeCheckpointNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, checkpoint)
+}
+
+// PutChangeCheckpoint godoc
+// @Summary Store a change feed checkpoint
+// @Description Creates or moves the named consumer's checkpoint to the last sequence it has processed
+// @Tags changes
+// @Accept json
+// @Produce json
+// @Param name path string true "Consumer Name"
+// @Param checkpoint body ChangeCheckpointRequest true "Checkpoint"
+// @Success 200 {object} models.ChangeCheckpoint
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /changes/checkpoints/{name} [put]
+func PutChangeCheckpoint(c *gin.Context) {
+	var req ChangeCheckpointRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	checkpoint, err := activityController.repo.Changelog().SetCheckpoint(c.Param("name"), req.Sequence)
+	if errors.Is(err, repositories.ErrSequenceAhead) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, checkpoint)
+}
+
+// DeleteChangeCheckpoint godoc
+// @Summary Delete a change feed checkpoint
+// @Tags changes
+// @Param name path string true "Consumer Name"
+// @Success 204 "No Content"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /changes/checkpoints/{name} [delete]
+func DeleteChangeCheckpoint(c *gin.Context) {
+	err := activityController.repo.Changelog().DeleteCheckpoint(c.Param("name"))
+	if errors.Is(err, repositories.ErrChangeCheckpointNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
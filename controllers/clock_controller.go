@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetClockOffsets godoc
+// @Summary Get device clock offsets
+// @Description Returns the estimated clock offset of every device that has reported its own timestamps.
+// @Description A positive offset means the device clock is behind the server clock.
+// @Tags clock
+// @Produce json
+// @Success 200 {array} services.ClockOffset
+// @Router /clock/offsets [get]
+func GetClockOffsets(c *gin.Context) {
+	c.JSON(http.StatusOK, activityController.clockOffsets.All())
+}
+
+// GetClockOffsetByDevice godoc
+// @Summary Get a device clock offset
+// @Description Returns the estimated clock offset of a single device
+// @Tags clock
+// @Produce json
+// @Param device path string true "Device Name"
+// @Success 200 {object} services.ClockOffset
+// @Failure 404 {object} map[string]string
+// @Router /clock/offsets/{device} [get]
+func GetClockOffsetByDevice(c *gin.Context) {
+	offset, ok := activityController.clockOffsets.Get(c.Param("device"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No clock samples for device"})
+		return
+	}
+	c.JSON(http.StatusOK, offset)
+}
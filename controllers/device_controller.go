@@ -0,0 +1,277 @@
+package controllers
+
+import (
+	"errors"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+type DeviceController struct {
+	repo *repositories.DeviceRepository
+}
+
+var deviceController DeviceController
+
+// InitDeviceController initializes the controller after DB setup
+func InitDeviceController(ob *objectbox.ObjectBox) {
+	deviceController = DeviceController{
+		repo: repositories.NewDeviceRepository(ob),
+	}
+}
+
+// DeviceRequest is the writable part of a device.
+type DeviceRequest struct {
+	Name     string                 `json:"name"`
+	GridName string                 `json:"grid_name"`
+	Labels   map[string]string      `json:"labels"`
+	Metadata map[string]interface{} `json:"metadata"`
+	// HeartbeatIntervalSeconds overrides the grid or default heartbeat interval; 0 inherits it.
+	HeartbeatIntervalSeconds int64 `json:"heartbeat_interval_seconds"`
+}
+
+// DeviceResponse is a device with its labels and metadata decoded.
+type DeviceResponse struct {
+	models.Device
+	Labels   map[string]string
+	Metadata map[string]interface{}
+}
+
+func newDeviceResponse(device models.Device) (DeviceResponse, error) {
+	labels, err := device.GetLabels()
+	if err != nil {
+		return DeviceResponse{}, err
+	}
+	metadata, err := device.GetMetadata()
+	if err != nil {
+		return DeviceResponse{}, err
+	}
+	return DeviceResponse{Device: device, Labels: labels, Metadata: metadata}, nil
+}
+
+// apply copies the request's grid, labels, metadata and heartbeat interval onto a device.
+func (req DeviceRequest) apply(device *models.Device) error {
+	if req.HeartbeatIntervalSeconds < 0 {
+		return errors.New("heartbeat_interval_seconds must not be negative")
+	}
+	device.GridName = req.GridName
+	device.HeartbeatSeconds = req.HeartbeatIntervalSeconds
+	if err := device.SetLabels(req.Labels); err != nil {
+		return err
+	}
+	return device.SetMetadata(req.Metadata)
+}
+
+// GetDevices godoc
+// @Summary List devices
+// @Description Lists registered devices in registration order. The next page is linked in the Link header.
+// @Tags devices
+// @Produce json
+// @Param grid query string false "Only devices currently in this grid"
+// @Param limit query int false "Page size (default 100, max 1000)"
+// @Param cursor query string false "Opaque cursor from the previous page's Link header"
+// @Success 200 {array} DeviceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /devices [get]
+func GetDevices(c *gin.Context) {
+	limit, err := parseLimit(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if limit == 0 {
+		limit = repositories.DefaultPageLimit
+	}
+
+	page, err := deviceController.repo.List(c.Query("grid"), c.Query("cursor"), limit)
+	if errors.Is(err, repositories.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	devices := make([]DeviceResponse, len(page.Devices))
+	for i, device := range page.Devices {
+		if devices[i], err = newDeviceResponse(device); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	setNextPageLink(c, page.NextCursor)
+	c.JSON(http.StatusOK, devices)
+}
+
+// GetOfflineDevices godoc
+// @Summary List offline devices
+// @Description Lists monitored devices that have not sent an activity within their heartbeat interval
+// @Tags devices
+// @Produce json
+// @Success 200 {array} DeviceResponse
+// @Failure 500 {object} map[string]string
+// @Router /devices/offline [get]
+func GetOfflineDevices(c *gin.Context) {
+	offline, err := activityController.heartbeats.Offline()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	devices := make([]DeviceResponse, len(offline))
+	for i, device := range offline {
+		if devices[i], err = newDeviceResponse(device); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, devices)
+}
+
+// GetDevice godoc
+// @Summary Get a device
+// @Description Returns a registered device with its presence information
+// @Tags devices
+// @Produce json
+// @Param name path string true "Device Name"
+// @Success 200 {object} DeviceResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /devices/{name} [get]
+func GetDevice(c *gin.Context) {
+	device, err := deviceController.repo.GetByName(c.Param("name"))
+	respondWithDevice(c, http.StatusOK, device, err)
+}
+
+// CreateDevice godoc
+// @Summary Register a device
+// @Description Registers a device ahead of its first activity. Devices are also registered automatically.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param device body DeviceRequest true "Device"
+// @Success 201 {object} DeviceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /devices [post]
+func CreateDevice(c *gin.Context) {
+	var req DeviceRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+
+	device := &models.Device{Name: req.Name}
+	if err := req.apply(device); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err := deviceController.repo.Create(device)
+	respondWithDevice(c, http.StatusCreated, device, err)
+}
+
+// UpdateDevice godoc
+// @Summary Update a device
+// @Description Replaces the grid, labels, metadata and heartbeat interval of a device. Presence fields are maintained from activities.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param name path string true "Device Name"
+// @Param device body DeviceRequest true "Device"
+// @Success 200 {object} DeviceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /devices/{name} [put]
+func UpdateDevice(c *gin.Context) {
+	var req DeviceRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	name := c.Param("name")
+	if req.Name != "" && req.Name != name {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "devices cannot be renamed"})
+		return
+	}
+
+	device, err := deviceController.repo.Update(name, req.apply)
+	respondWithDevice(c, http.StatusOK, device, err)
+}
+
+// DeleteDevice godoc
+// @Summary Delete a device
+// @Description Removes a device from the registry; its next activity registers it again. While stored activities,
+// @Description including those in the trash, link to the device it answers 409 with their count in "activities".
+// @Description With force=true and the admin token in X-Admin-Token those activities are purged along with the
+// @Description device, in one transaction. They are not unlinked instead, since activities without a device get it
+// @Description registered again on startup.
+// @Tags devices
+// @Produce json
+// @Param name path string true "Device Name"
+// @Param force query bool false "Also purge the activities linked to the device"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /devices/{name} [delete]
+func DeleteDevice(c *gin.Context) {
+	force, err := strconv.ParseBool(c.DefaultQuery("force", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "force must be a boolean"})
+		return
+	}
+	if force && !isAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "force delete requires the admin token"})
+		return
+	}
+
+	linked, err := activityController.repo.DeleteDevice(c.Param("name"), force)
+	if errors.Is(err, repositories.ErrDeviceNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, repositories.ErrDeviceHasActivities) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "activities": linked})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func respondWithDevice(c *gin.Context, status int, device *models.Device, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrDeviceNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, repositories.ErrDeviceExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := newDeviceResponse(*device)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(status, response)
+}
@@ -0,0 +1,211 @@
+package controllers
+
+import (
+	"errors"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GridRequest is the writable part of a grid. Parent is the name of the parent grid.
+type GridRequest struct {
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Parent      string `json:"parent"`
+	Description string `json:"description"`
+	// HeartbeatIntervalSeconds applies to devices in this grid and below; 0 inherits it.
+	HeartbeatIntervalSeconds int64 `json:"heartbeat_interval_seconds"`
+}
+
+// GridResponse is a grid with the names of its parent and direct children.
+type GridResponse struct {
+	models.Grid
+	Parent   string
+	Children []string
+}
+
+func newGridResponse(tree repositories.GridTree, grid models.Grid) GridResponse {
+	return GridResponse{Grid: grid, Parent: tree.ParentName(grid), Children: tree.Children(grid.Name)}
+}
+
+// GetGrids godoc
+// @Summary List grids
+// @Description Lists every grid with its parent and children
+// @Tags grids
+// @Produce json
+// @Success 200 {array} GridResponse
+// @Failure 500 {object} map[string]string
+// @Router /grids [get]
+func GetGrids(c *gin.Context) {
+	tree, err := activityController.grids.Tree()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	grids := tree.Grids()
+	response := make([]GridResponse, len(grids))
+	for i, grid := range grids {
+		response[i] = newGridResponse(tree, grid)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetGrid godoc
+// @Summary Get a grid
+// @Description Returns a grid with its parent and children
+// @Tags grids
+// @Produce json
+// @Param name path string true "Grid Name"
+// @Success 200 {object} GridResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /grids/{name} [get]
+func GetGrid(c *gin.Context) {
+	respondWithGrid(c, http.StatusOK, c.Param("name"), nil)
+}
+
+// GetGridDescendants godoc
+// @Summary Get grid descendants
+// @Description Returns the names of all grids below a grid, breadth first
+// @Tags grids
+// @Produce json
+// @Param name path string true "Grid Name"
+// @Success 200 {array} string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /grids/{name}/descendants [get]
+func GetGridDescendants(c *gin.Context) {
+	tree, err := activityController.grids.Tree()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	name := c.Param("name")
+	if _, ok := tree.Get(name); !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": repositories.ErrGridNotFound.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tree.Subtree(name)[1:])
+}
+
+// CreateGrid godoc
+// @Summary Create a grid
+// @Description Creates a grid, optionally below a parent grid
+// @Tags grids
+// @Accept json
+// @Produce json
+// @Param grid body GridRequest true "Grid"
+// @Success 201 {object} GridResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /grids [post]
+func CreateGrid(c *gin.Context) {
+	var req GridRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+	if req.HeartbeatIntervalSeconds < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "heartbeat_interval_seconds must not be negative"})
+		return
+	}
+
+	grid := &models.Grid{Name: req.Name, Kind: req.Kind, Description: req.Description, HeartbeatSeconds: req.HeartbeatIntervalSeconds}
+	err := activityController.grids.Create(grid, req.Parent)
+	respondWithGrid(c, http.StatusCreated, req.Name, err)
+}
+
+// UpdateGrid godoc
+// @Summary Update a grid
+// @Description Replaces the kind, description, heartbeat interval and parent of a grid. Moving a grid below one of its descendants is rejected.
+// @Tags grids
+// @Accept json
+// @Produce json
+// @Param name path string true "Grid Name"
+// @Param grid body GridRequest true "Grid"
+// @Success 200 {object} GridResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /grids/{name} [put]
+func UpdateGrid(c *gin.Context) {
+	var req GridRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	name := c.Param("name")
+	if req.Name != "" && req.Name != name {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "grids cannot be renamed"})
+		return
+	}
+	if req.HeartbeatIntervalSeconds < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "heartbeat_interval_seconds must not be negative"})
+		return
+	}
+
+	_, err := activityController.grids.Update(name, req.Parent, func(grid *models.Grid) {
+		grid.Kind = req.Kind
+		grid.Description = req.Description
+		grid.HeartbeatSeconds = req.HeartbeatIntervalSeconds
+	})
+	respondWithGrid(c, http.StatusOK, name, err)
+}
+
+// DeleteGrid godoc
+// @Summary Delete a grid
+// @Description Deletes a grid without child grids. Activities keep their grid name.
+// @Tags grids
+// @Param name path string true "Grid Name"
+// @Success 204 "No Content"
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /grids/{name} [delete]
+func DeleteGrid(c *gin.Context) {
+	err := activityController.grids.Delete(c.Param("name"))
+	if err != nil {
+		c.JSON(gridErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// respondWithGrid reports err, or else reloads the hierarchy and responds with the named grid.
+func respondWithGrid(c *gin.Context, status int, name string, err error) {
+	if err != nil {
+		c.JSON(gridErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	tree, err := activityController.grids.Tree()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	grid, ok := tree.Get(name)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": repositories.ErrGridNotFound.Error()})
+		return
+	}
+	c.JSON(status, newGridResponse(tree, grid))
+}
+
+func gridErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repositories.ErrGridNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repositories.ErrGridExists), errors.Is(err, repositories.ErrGridHasChildren):
+		return http.StatusConflict
+	case errors.Is(err, repositories.ErrGridParentNotFound), errors.Is(err, repositories.ErrGridCycle):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
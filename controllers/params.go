@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"fmt"
+	"go-rest-api/repositories"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parsePageRequest reads the limit, cursor and sort query parameters shared by the list endpoints.
+func parsePageRequest(c *gin.Context) (repositories.PageRequest, error) {
+	var page repositories.PageRequest
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		return page, err
+	}
+	page.Limit = limit
+
+	cursor, err := repositories.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		return page, err
+	}
+	page.Cursor = cursor
+
+	sort, err := repositories.ParseSortOrder(c.Query("sort"))
+	if err != nil {
+		return page, err
+	}
+	page.Sort = sort
+
+	return page, nil
+}
+
+// parseLimit reads the limit query parameter, returning 0 when it is absent.
+func parseLimit(c *gin.Context) (int, error) {
+	value := c.Query("limit")
+	if value == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > repositories.MaxPageLimit {
+		return 0, fmt.Errorf("limit must be an integer between 1 and %d", repositories.MaxPageLimit)
+	}
+	return limit, nil
+}
+
+// setNextPageLink advertises the next page through the Link and X-Next-Cursor headers,
+// keeping the response body a plain array for existing clients.
+func setNextPageLink(c *gin.Context, nextCursor string) {
+	if nextCursor == "" {
+		return
+	}
+	next := url.URL{Path: c.Request.URL.Path}
+	query := c.Request.URL.Query()
+	query.Set("cursor", nextCursor)
+	next.RawQuery = query.Encode()
+
+	c.Header("Link", fmt.Sprintf("<%s>; rel=\"next\"", next.String()))
+	c.Header("X-Next-Cursor", nextCursor)
+}
+
+// parseFilterParams builds a filter from the repeatable (or comma separated)
+// device, grid and action query parameters and the source_ip_mismatch flag.
+// With descendants=true each grid name also matches its descendant grids.
+func parseFilterParams(c *gin.Context) (repositories.ActivityFilter, error) {
+	var clauses []repositories.ActivityFilter
+
+	if devices := queryList(c, "device"); len(devices) > 0 {
+		group := make([]repositories.ActivityFilter, len(devices))
+		for i, device := range devices {
+			group[i] = repositories.ActivityFilter{Device: &repositories.GlobMatch{Glob: device}}
+		}
+		clauses = append(clauses, repositories.ActivityFilter{Or: group})
+	}
+	grids, err := expandGrids(c, queryList(c, "grid"))
+	if err != nil {
+		return repositories.ActivityFilter{}, err
+	}
+	if len(grids) > 0 {
+		group := make([]repositories.ActivityFilter, len(grids))
+		for i, grid := range grids {
+			group[i] = repositories.ActivityFilter{Grid: &repositories.GlobMatch{Glob: grid}}
+		}
+		clauses = append(clauses, repositories.ActivityFilter{Or: group})
+	}
+	if actions := queryList(c, "action"); len(actions) > 0 {
+		clauses = append(clauses, repositories.ActivityFilter{Action: &repositories.SetMatch{In: actions}})
+	}
+	if value := c.Query("source_ip_mismatch"); value != "" {
+		mismatch, err := strconv.ParseBool(value)
+		if err != nil {
+			return repositories.ActivityFilter{}, fmt.Errorf("source_ip_mismatch must be true or false")
+		}
+		clauses = append(clauses, repositories.ActivityFilter{SourceIPMismatch: &mismatch})
+	}
+
+	var filter repositories.ActivityFilter
+	if len(clauses) > 0 {
+		filter.And = clauses
+	}
+	if _, err := filter.Condition(); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// expandGrids replaces every grid name with its subtree when the descendants
+// query parameter is set. Glob patterns are left as they are.
+func expandGrids(c *gin.Context, grids []string) ([]string, error) {
+	if len(grids) == 0 {
+		return grids, nil
+	}
+	descendants, err := parseDescendants(c)
+	if err != nil || !descendants {
+		return grids, err
+	}
+
+	tree, err := activityController.grids.Tree()
+	if err != nil {
+		return nil, err
+	}
+	var expanded []string
+	for _, grid := range grids {
+		if strings.Contains(grid, "*") {
+			expanded = append(expanded, grid)
+			continue
+		}
+		expanded = append(expanded, tree.Subtree(grid)...)
+	}
+	return expanded, nil
+}
+
+// parseDescendants reads the descendants query parameter, false when absent.
+func parseDescendants(c *gin.Context) (bool, error) {
+	value := c.Query("descendants")
+	if value == "" {
+		return false, nil
+	}
+	descendants, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("descendants must be true or false")
+	}
+	return descendants, nil
+}
+
+func queryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, value := range c.QueryArray(key) {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+	}
+	return values
+}
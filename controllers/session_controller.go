@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"errors"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"go-rest-api/services"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionStatsResponse is the session summary of a grid.
+type SessionStatsResponse struct {
+	Grid     string              `json:"grid"`
+	From     time.Time           `json:"from"`
+	To       time.Time           `json:"to"`
+	Bucket   services.BucketSize `json:"bucket"`
+	Timezone string              `json:"timezone"`
+	services.SessionStats
+}
+
+// GetDeviceSessions godoc
+// @Summary List the sessions of a device
+// @Description Lists sessions derived from the configured start/end action pairs, newest first
+// @Tags devices
+// @Produce json
+// @Param name path string true "Device Name"
+// @Param status query string false "Only sessions with this status" Enums(open, closed, timed_out, superseded)
+// @Param limit query int false "Page size (default 100, max 1000)"
+// @Param cursor query string false "Opaque cursor from the previous page's Link header"
+// @Success 200 {array} models.Session
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /devices/{name}/sessions [get]
+func GetDeviceSessions(c *gin.Context) {
+	status := c.Query("status")
+	switch status {
+	case "", models.SessionOpen, models.SessionClosed, models.SessionTimedOut, models.SessionSuperseded:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be open, closed, timed_out or superseded"})
+		return
+	}
+	limit, err := parseLimit(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if limit == 0 {
+		limit = repositories.DefaultPageLimit
+	}
+
+	if err := activityController.sessionBuilder.CatchUp(c.Request.Context(), time.Now()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	page, err := activityController.sessions.GetByDevice(c.Param("name"), status, c.Query("cursor"), limit)
+	if errors.Is(err, repositories.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	setNextPageLink(c, page.NextCursor)
+	c.JSON(http.StatusOK, page.Sessions)
+}
+
+// GetGridSessionStats godoc
+// @Summary Session statistics of a grid
+// @Description Counts the sessions started in [from, to), reports the mean and p95 duration of those that ended,
+// @Description and the peak number of concurrent sessions per time bucket.
+// @Tags grids
+// @Produce json
+// @Param name path string true "Grid Name"
+// @Param from query string false "Start of the range (RFC 3339, default 24h before to)"
+// @Param to query string false "End of the range (RFC 3339, default now)"
+// @Param bucket query string false "Bucket size for concurrency" Enums(minute, hour, day, week) default(hour)
+// @Param tz query string false "IANA time zone for bucket boundaries" default(UTC)
+// @Param descendants query bool false "Include sessions of descendant grids"
+// @Success 200 {object} SessionStatsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /grids/{name}/sessions/stats [get]
+func GetGridSessionStats(c *gin.Context) {
+	gridName := c.Param("name")
+	req, err := parseSessionStatsRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	descendants, err := parseDescendants(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	grids := []string{gridName}
+	if descendants {
+		if grids, err = activityController.grids.Subtree(gridName); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	now := time.Now()
+	if err := activityController.sessionBuilder.CatchUp(c.Request.Context(), now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	sessions, err := activityController.sessions.GetByGridsBetween(grids, req.From, req.To)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	stats := services.ComputeSessionStats(sessions, req, now)
+	for i := range stats.Concurrency {
+		stats.Concurrency[i].BucketStart = stats.Concurrency[i].BucketStart.In(req.Location)
+	}
+	c.JSON(http.StatusOK, SessionStatsResponse{
+		Grid:         gridName,
+		From:         req.From.In(req.Location),
+		To:           req.To.In(req.Location),
+		Bucket:       req.Bucket,
+		Timezone:     req.Location.String(),
+		SessionStats: stats,
+	})
+}
+
+func parseSessionStatsRequest(c *gin.Context) (services.SessionStatsRequest, error) {
+	var req services.SessionStatsRequest
+	var err error
+
+	req.To = time.Now()
+	if value := c.Query("to"); value != "" {
+		if req.To, err = time.Parse(time.RFC3339, value); err != nil {
+			return req, err
+		}
+	}
+	req.From = req.To.Add(-24 * time.Hour)
+	if value := c.Query("from"); value != "" {
+		if req.From, err = time.Parse(time.RFC3339, value); err != nil {
+			return req, err
+		}
+	}
+
+	if req.Bucket, err = services.ParseBucketSize(c.DefaultQuery("bucket", string(services.BucketHour))); err != nil {
+		return req, err
+	}
+	if req.Location, err = time.LoadLocation(c.DefaultQuery("tz", "UTC")); err != nil {
+		return req, err
+	}
+	return req, req.Validate()
+}
@@ -0,0 +1,226 @@
+package controllers
+
+import (
+	"errors"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"go-rest-api/services"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SLORequest is the writable part of an SLO.
+type SLORequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Endpoint    string `json:"endpoint"`
+	// Method is empty for every method of the endpoint.
+	Method string `json:"method"`
+	// Objective is availability or latency.
+	Objective          string  `json:"objective"`
+	Target             float64 `json:"target"`
+	LatencyThresholdMs float64 `json:"latency_threshold_ms"`
+	// WindowDays defaults to 28. It is at most 90 and at most the days of
+	// stats kept, see STATS_RETENTION.
+	WindowDays int `json:"window_days"`
+}
+
+// apply copies the request onto an SLO and validates the result.
+func (req SLORequest) apply(slo *models.SLO) error {
+	slo.Description = req.Description
+	slo.Endpoint = req.Endpoint
+	slo.Method = strings.ToUpper(req.Method)
+	slo.Objective = req.Objective
+	slo.Target = req.Target
+	slo.LatencyThresholdMs = 0
+	if req.Objective == models.SLOLatency {
+		slo.LatencyThresholdMs = req.LatencyThresholdMs
+	}
+	slo.WindowDays = req.WindowDays
+	if slo.WindowDays == 0 {
+		slo.WindowDays = 28
+	}
+	return services.ValidateSLO(*slo, statsController.retention)
+}
+
+// GetSLOs godoc
+// @Summary List SLOs
+// @Tags slos
+// @Produce json
+// @Success 200 {array} models.SLO
+// @Failure 500 {object} map[string]string
+// @Router /slos [get]
+func GetSLOs(c *gin.Context) {
+	slos, err := statsController.slos.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, slos)
+}
+
+// GetSLO godoc
+// @Summary Get an SLO
+// @Tags slos
+// @Produce json
+// @Param name path string true "SLO Name"
+// @Success 200 {object} models.SLO
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /slos/{name} [get]
+func GetSLO(c *gin.Context) {
+	slo, err := statsController.slos.Get(c.Param("name"))
+	respondWithSLO(c, http.StatusOK, slo, err)
+}
+
+// CreateSLO godoc
+// @Summary Create an SLO
+// @Description Declares an availability SLO, under which 5xx responses are bad, or a latency SLO, under which
+// @Description responses slower than latency_threshold_ms are bad, for an endpoint over the last window_days.
+// @Description window_days is at most 90 and may not exceed the days STATS_RETENTION keeps.
+// @Tags slos
+// @Accept json
+// @Produce json
+// @Param slo body SLORequest true "SLO"
+// @Success 201 {object} models.SLO
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /slos [post]
+func CreateSLO(c *gin.Context) {
+	var req SLORequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	slo := &models.SLO{Name: req.Name}
+	if err := req.apply(slo); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err := statsController.slos.Create(slo)
+	respondWithSLO(c, http.StatusCreated, slo, err)
+}
+
+// UpdateSLO godoc
+// @Summary Update an SLO
+// @Tags slos
+// @Accept json
+// @Produce json
+// @Param name path string true "SLO Name"
+// @Param slo body SLORequest true "SLO"
+// @Success 200 {object} models.SLO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /slos/{name} [put]
+func UpdateSLO(c *gin.Context) {
+	var req SLORequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	name := c.Param("name")
+	if req.Name != "" && req.Name != name {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "SLOs cannot be renamed"})
+		return
+	}
+
+	var invalid error
+	slo, err := statsController.slos.Update(name, func(slo *models.SLO) error {
+		invalid = req.apply(slo)
+		return invalid
+	})
+	if invalid != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalid.Error()})
+		return
+	}
+	respondWithSLO(c, http.StatusOK, slo, err)
+}
+
+// DeleteSLO godoc
+// @Summary Delete an SLO
+// @Description Deletes an SLO. Its firing alerts resolve on the next evaluation.
+// @Tags slos
+// @Param name path string true "SLO Name"
+// @Success 204 "No Content"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /slos/{name} [delete]
+func DeleteSLO(c *gin.Context) {
+	err := statsController.slos.Delete(c.Param("name"))
+	if errors.Is(err, repositories.ErrSLONotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetSLOReports godoc
+// @Summary Report on every SLO
+// @Description Evaluates every SLO now: compliance, remaining error budget, burn rates over 5m, 30m, 1h and 6h,
+// @Description and whether its fast or slow burn alert condition holds.
+// @Tags slos
+// @Produce json
+// @Success 200 {array} services.SLOReport
+// @Failure 500 {object} map[string]string
+// @Router /slos/reports [get]
+func GetSLOReports(c *gin.Context) {
+	reports, err := statsController.sloEvaluator.Reports(time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, reports)
+}
+
+// GetSLOReport godoc
+// @Summary Report on an SLO
+// @Description Evaluates an SLO now: compliance, remaining error budget, burn rates over 5m, 30m, 1h and 6h,
+// @Description and whether its fast or slow burn alert condition holds.
+// @Tags slos
+// @Produce json
+// @Param name path string true "SLO Name"
+// @Success 200 {object} services.SLOReport
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /slos/{name}/report [get]
+func GetSLOReport(c *gin.Context) {
+	slo, err := statsController.slos.Get(c.Param("name"))
+	if errors.Is(err, repositories.ErrSLONotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := statsController.sloEvaluator.Report(*slo, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func respondWithSLO(c *gin.Context, status int, slo *models.SLO, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrSLONotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrSLOExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(status, slo)
+	}
+}
@@ -1,9 +1,18 @@
 package controllers
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"go-rest-api/config"
 	"go-rest-api/metrics"
 	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"go-rest-api/services"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"go-rest-api/utils"
@@ -12,11 +21,37 @@ import (
 	"github.com/google/uuid"
 )
 
-// In-memory storage for stats
-var statsStore = make(map[string]models.UsageStats)
+const statsPruneInterval = time.Hour
 
-func init() {
-	// Add sample stats
+type StatsSummaryResponse struct {
+	From      time.Time                  `json:"from"`
+	To        time.Time                  `json:"to"`
+	Endpoints []services.EndpointSummary `json:"endpoints"`
+}
+
+type StatsController struct {
+	store     repositories.StatsStore
+	retention time.Duration
+
+	slos         *repositories.SLORepository
+	sloEvaluator *services.SLOEvaluator
+}
+
+var statsController StatsController
+
+// InitStatsController sets the stores used by the stats and SLO handlers and
+// seeds the stats store with sample stats when it is empty.
+func InitStatsController(store repositories.StatsStore, slos *repositories.SLORepository, cfg config.Config) {
+	statsController = StatsController{
+		store:        store,
+		retention:    cfg.StatsRetention,
+		slos:         slos,
+		sloEvaluator: services.NewSLOEvaluator(slos, store, services.NewSLONotifier(cfg), cfg),
+	}
+
+	if count, err := store.Count(); err != nil || count > 0 {
+		return
+	}
 	sampleStats := []models.UsageStats{
 		{
 			ID:        uuid.New().String(),
@@ -41,8 +76,47 @@ func init() {
 		},
 	}
 
-	for _, stat := range sampleStats {
-		statsStore[stat.ID] = stat
+	for i := range sampleStats {
+		if err := store.Create(&sampleStats[i]); err != nil {
+			log.Printf("seeding sample stats failed: %v", err)
+			return
+		}
+	}
+}
+
+// pruneStats deletes stats older than the retention period periodically.
+func pruneStats(ctx context.Context) {
+	if statsController.retention <= 0 {
+		return
+	}
+	ticker := time.NewTicker(statsPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			cutoff := now.Add(-statsController.retention)
+			pruneInBatches(ctx, "stats", func() (uint64, error) {
+				return statsController.store.RemoveOlderThan(cutoff, 1000)
+			})
+			pruneInBatches(ctx, "stats rollups", func() (uint64, error) {
+				return statsController.store.RemoveRollupsBefore(cutoff, 1000)
+			})
+		}
+	}
+}
+
+// pruneInBatches calls remove until it removes nothing, fails or ctx is done.
+func pruneInBatches(ctx context.Context, what string, remove func() (uint64, error)) {
+	for ctx.Err() == nil {
+		n, err := remove()
+		if err != nil {
+			log.Printf("pruning %s failed: %v", what, err)
+		}
+		if err != nil || n == 0 {
+			return
+		}
 	}
 }
 
@@ -66,69 +140,230 @@ func CreateStats(c *gin.Context) {
 
 	newStats.ID = utils.GenerateUUID()
 	newStats.Timestamp = time.Now()
-	statsStore[newStats.ID] = newStats
+	if err := statsController.store.Create(&newStats); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusCreated, newStats)
 }
 
 // GetAllStats godoc
 // @Summary Get all statistics
-// @Description Retrieves all usage statistics
+// @Description Retrieves all usage statistics, or those selected by the query parameters
 // @Tags stats
 // @Produce json
+// @Param endpoint query string false "Endpoint, or pattern when match is set"
+// @Param match query string false "How endpoint is matched" Enums(exact, prefix, glob, route) default(exact)
+// @Param method query string false "HTTP methods, comma separated"
+// @Param status query string false "Status code (404), class (5xx) or inclusive range (400-499)"
+// @Param from query string false "Earliest timestamp (RFC 3339)"
+// @Param to query string false "Timestamp before which entries were recorded (RFC 3339)"
 // @Success 200 {array} models.UsageStats
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
 // @Router /stats [get]
 func GetAllStats(c *gin.Context) {
 	defer metrics.StatsOperationsTotal.WithLabelValues("list").Inc()
-	stats := make([]models.UsageStats, 0, len(statsStore))
-	for _, stat := range statsStore {
-		stats = append(stats, stat)
+	query, err := parseStatsQuery(c, c.Query("endpoint"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var stats []models.UsageStats
+	if query.IsEmpty() {
+		stats, err = statsController.store.GetAll()
+	} else {
+		stats, err = statsController.store.Find(query)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 	c.JSON(http.StatusOK, stats)
 }
 
+// parseStatsQuery reads the stats filters of the query string for endpoint.
+func parseStatsQuery(c *gin.Context, endpoint string) (repositories.StatsQuery, error) {
+	query := repositories.StatsQuery{Endpoint: endpoint, Match: c.Query("match")}
+	var err error
+
+	for _, method := range strings.Split(c.Query("method"), ",") {
+		if method = strings.TrimSpace(method); method != "" {
+			query.Methods = append(query.Methods, strings.ToUpper(method))
+		}
+	}
+	if value := c.Query("status"); value != "" {
+		if query.StatusMin, query.StatusMax, err = parseStatusRange(value); err != nil {
+			return query, err
+		}
+	}
+	if value := c.Query("from"); value != "" {
+		if query.From, err = time.Parse(time.RFC3339, value); err != nil {
+			return query, err
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if query.To, err = time.Parse(time.RFC3339, value); err != nil {
+			return query, err
+		}
+	}
+	return query, query.Validate()
+}
+
+// parseStatusRange accepts a status code (404), a class (5xx) or an
+// inclusive range (400-499).
+func parseStatusRange(value string) (int, int, error) {
+	invalid := fmt.Errorf("status: %q is not a status code, class or range", value)
+	if class, ok := strings.CutSuffix(strings.ToLower(value), "xx"); ok {
+		digit, err := strconv.Atoi(class)
+		if err != nil || digit < 1 || digit > 5 {
+			return 0, 0, invalid
+		}
+		return digit * 100, digit*100 + 99, nil
+	}
+	lowValue, highValue, isRange := strings.Cut(value, "-")
+	low, err := strconv.Atoi(lowValue)
+	if err != nil || low < 100 || low > 599 {
+		return 0, 0, invalid
+	}
+	if !isRange {
+		return low, low, nil
+	}
+	high, err := strconv.Atoi(highValue)
+	if err != nil || high < low || high > 599 {
+		return 0, 0, invalid
+	}
+	return low, high, nil
+}
+
+// GetStatsSummary godoc
+// @Summary Summarise statistics
+// @Description Reports, per endpoint and method, the request count, p50/p90/p99 latency, status classes
+// @Description and 5xx error rate over [from, to). The window is widened to whole 5 minute buckets;
+// @Description percentiles come from mergeable sketches and are accurate to within 1%. The window is at most
+// @Description 90 days and at most STATS_RETENTION.
+// @Tags stats
+// @Produce json
+// @Param from query string false "Start of the window (RFC 3339, default 24h before to)"
+// @Param to query string false "End of the window (RFC 3339, default now)"
+// @Param endpoint query string false "Only this endpoint"
+// @Param method query string false "Only this HTTP method"
+// @Success 200 {object} StatsSummaryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /stats/summary [get]
+func GetStatsSummary(c *gin.Context) {
+	defer metrics.StatsOperationsTotal.WithLabelValues("summary").Inc()
+	req, err := parseStatsSummaryRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rollups, err := statsController.store.GetRollups(req.From, req.To)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	summaries, err := services.SummarizeStats(rollups, req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, StatsSummaryResponse{From: req.From, To: req.To, Endpoints: summaries})
+}
+
+func parseStatsSummaryRequest(c *gin.Context) (services.StatsSummaryRequest, error) {
+	req := services.StatsSummaryRequest{
+		Endpoint: c.Query("endpoint"),
+		Method:   strings.ToUpper(c.Query("method")),
+	}
+	var err error
+
+	req.To = time.Now()
+	if value := c.Query("to"); value != "" {
+		if req.To, err = time.Parse(time.RFC3339, value); err != nil {
+			return req, err
+		}
+	}
+	req.From = req.To.Add(-24 * time.Hour)
+	if value := c.Query("from"); value != "" {
+		if req.From, err = time.Parse(time.RFC3339, value); err != nil {
+			return req, err
+		}
+	}
+
+	if err := req.Validate(statsController.retention); err != nil {
+		return req, err
+	}
+	return req.Align(), nil
+}
+
 // GetStatsByEndpoint godoc
 // @Summary Get statistics by endpoint
-// @Description Retrieves statistics for a specific endpoint
+// @Description Retrieves statistics for a specific endpoint. The endpoint is the rest of the path, slashes included,
+// @Description e.g. /stats/endpoints/api/v1/books, or a pattern when match is set.
 // @Tags stats
 // @Produce json
 // @Param endpoint path string true "Endpoint Path"
+// @Param match query string false "How endpoint is matched" Enums(exact, prefix, glob, route) default(exact)
+// @Param method query string false "HTTP methods, comma separated"
+// @Param status query string false "Status code (404), class (5xx) or inclusive range (400-499)"
+// @Param from query string false "Earliest timestamp (RFC 3339)"
+// @Param to query string false "Timestamp before which entries were recorded (RFC 3339)"
 // @Success 200 {array} models.UsageStats
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
 // @Router /stats/endpoints/{endpoint} [get]
 func GetStatsByEndpoint(c *gin.Context) {
 	defer metrics.StatsOperationsTotal.WithLabelValues("get_by_endpoint").Inc()
-	endpoint := c.Param("endpoint")
-	filteredStats := make([]models.UsageStats, 0)
+	query, err := parseStatsQuery(c, c.Param("endpoint"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	for _, stat := range statsStore {
-		if stat.Endpoint == endpoint {
-			filteredStats = append(filteredStats, stat)
-		}
+	stats, err := statsController.store.Find(query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
-	c.JSON(http.StatusOK, filteredStats)
+	c.JSON(http.StatusOK, stats)
 }
 
 // DeleteStatsByEndpoint godoc
 // @Summary Delete statistics by endpoint
-// @Description Deletes all statistics for a specific endpoint
+// @Description Deletes the statistics of an endpoint, or of every endpoint matching a pattern, selected by the
+// @Description query parameters. Rollups are deleted along with all statistics of an endpoint.
 // @Tags stats
 // @Produce json
 // @Param endpoint path string true "Endpoint Path"
+// @Param match query string false "How endpoint is matched" Enums(exact, prefix, glob, route) default(exact)
+// @Param method query string false "HTTP methods, comma separated"
+// @Param status query string false "Status code (404), class (5xx) or inclusive range (400-499)"
+// @Param from query string false "Earliest timestamp (RFC 3339)"
+// @Param to query string false "Timestamp before which entries were recorded (RFC 3339)"
 // @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
 // @Router /stats/endpoints/{endpoint} [delete]
 func DeleteStatsByEndpoint(c *gin.Context) {
 	defer metrics.StatsOperationsTotal.WithLabelValues("delete_by_endpoint").Inc()
-	endpoint := c.Param("endpoint")
-	deleted := false
+	query, err := parseStatsQuery(c, c.Param("endpoint"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	for id, stat := range statsStore {
-		if stat.Endpoint == endpoint {
-			delete(statsStore, id)
-			deleted = true
-		}
+	deleted, err := statsController.store.DeleteMatching(query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
-	if deleted {
+	if deleted > 0 {
 		c.Status(http.StatusNoContent)
 	} else {
 		c.JSON(http.StatusNotFound, gin.H{"error": "No stats found for endpoint"})
@@ -144,6 +379,7 @@ func DeleteStatsByEndpoint(c *gin.Context) {
 // @Success 204 "No Content"
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
 // @Router /stats/{id} [delete]
 func DeleteStats(c *gin.Context) {
 	defer metrics.StatsOperationsTotal.WithLabelValues("delete").Inc()
@@ -154,10 +390,14 @@ func DeleteStats(c *gin.Context) {
 		return
 	}
 
-	if _, exists := statsStore[id]; exists {
-		delete(statsStore, id)
-		c.Status(http.StatusNoContent)
+	err := statsController.store.Delete(id)
+	if errors.Is(err, repositories.ErrStatsNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Stats not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusNotFound, gin.H{"error": "Stats not found"})
+	c.Status(http.StatusNoContent)
 } 
\ No newline at end of file
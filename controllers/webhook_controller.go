@@ -0,0 +1,357 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"go-rest-api/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookRequest is the writable part of a webhook subscription. Every
+// activity matching Filter that is created or deleted, as selected by Events,
+// is posted to URL signed with Secret.
+type WebhookRequest struct {
+	Name   string                       `json:"name"`
+	URL    string                       `json:"url"`
+	Filter *repositories.ActivityFilter `json:"filter"`
+	// Events defaults to activity.created and activity.deleted.
+	Events []string `json:"events"`
+	// Secret is generated when a subscription is created without one. An
+	// update without a secret keeps the current one.
+	Secret string `json:"secret"`
+	// Enabled defaults to true.
+	Enabled *bool `json:"enabled"`
+}
+
+// WebhookResponse is a subscription with its filter and events decoded. The
+// secret is only returned when it was generated or changed.
+type WebhookResponse struct {
+	models.WebhookSubscription
+	Filter repositories.ActivityFilter
+	Events []string
+	Secret string `json:",omitempty"`
+}
+
+// WebhookDeliveryResponse is a delivery log entry with its payload decoded.
+type WebhookDeliveryResponse struct {
+	models.WebhookDelivery
+	Payload json.RawMessage `swaggertype:"object"`
+}
+
+func newWebhookResponse(subscription models.WebhookSubscription, showSecret bool) (WebhookResponse, error) {
+	response := WebhookResponse{WebhookSubscription: subscription, Events: subscription.GetEvents()}
+	if showSecret {
+		response.Secret = subscription.Secret
+	}
+	if subscription.Filter != "" {
+		if err := json.Unmarshal([]byte(subscription.Filter), &response.Filter); err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
+
+// apply copies the request onto a subscription and validates the result.
+func (req WebhookRequest) apply(subscription *models.WebhookSubscription) error {
+	subscription.URL = req.URL
+	subscription.Enabled = req.Enabled == nil || *req.Enabled
+	if req.Secret != "" {
+		subscription.Secret = req.Secret
+	}
+	events := req.Events
+	if len(events) == 0 {
+		events = []string{models.WebhookActivityCreated, models.WebhookActivityDeleted}
+	}
+	subscription.SetEvents(events)
+
+	subscription.Filter = ""
+	if req.Filter != nil && !req.Filter.IsEmpty() {
+		filter, err := json.Marshal(req.Filter)
+		if err != nil {
+			return err
+		}
+		subscription.Filter = string(filter)
+	}
+	return services.ValidateWebhookSubscription(*subscription)
+}
+
+// GetWebhooks godoc
+// @Summary List webhook subscriptions
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} WebhookResponse
+// @Failure 500 {object} map[string]string
+// @Router /webhooks [get]
+func GetWebhooks(c *gin.Context) {
+	subscriptions, err := activityController.webhooks.GetSubscriptions()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := make([]WebhookResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		if response[i], err = newWebhookResponse(subscription, false); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetWebhook godoc
+// @Summary Get a webhook subscription
+// @Tags webhooks
+// @Produce json
+// @Param name path string true "Subscription Name"
+// @Success 200 {object} WebhookResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{name} [get]
+func GetWebhook(c *gin.Context) {
+	subscription, err := activityController.webhooks.GetSubscription(c.Param("name"))
+	respondWithWebhook(c, http.StatusOK, subscription, false, err)
+}
+
+// CreateWebhook godoc
+// @Summary Create a webhook subscription
+// @Description Subscribes a URL to activity.created and/or activity.deleted events of activities matching the filter,
+// @Description starting with the next change. Requests are signed with HMAC-SHA256; a secret is generated and
+// @Description returned once if none is given.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param subscription body WebhookRequest true "Webhook Subscription"
+// @Success 201 {object} WebhookResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks [post]
+func CreateWebhook(c *gin.Context) {
+	var req WebhookRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		req.Secret = hex.EncodeToString(secret)
+	}
+
+	subscription := &models.WebhookSubscription{Name: req.Name}
+	if err := req.apply(subscription); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	startSequence, err := activityController.repo.Changelog().LastSequence()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	subscription.StartSequence = startSequence
+	err = activityController.webhooks.CreateSubscription(subscription)
+	respondWithWebhook(c, http.StatusCreated, subscription, true, err)
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook subscription
+// @Description Replaces a subscription's URL, filter, events and enabled flag, and its secret if one is given.
+// @Description Disabled subscriptions receive no new events; deliveries already in the outbox are still sent.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param name path string true "Subscription Name"
+// @Param subscription body WebhookRequest true "Webhook Subscription"
+// @Success 200 {object} WebhookResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{name} [put]
+func UpdateWebhook(c *gin.Context) {
+	var req WebhookRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	name := c.Param("name")
+	if req.Name != "" && req.Name != name {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "webhook subscriptions cannot be renamed"})
+		return
+	}
+
+	var invalid error
+	subscription, err := activityController.webhooks.UpdateSubscription(name, func(subscription *models.WebhookSubscription) error {
+		invalid = req.apply(subscription)
+		return invalid
+	})
+	if invalid != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalid.Error()})
+		return
+	}
+	respondWithWebhook(c, http.StatusOK, subscription, req.Secret != "", err)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook subscription
+// @Description Deletes a subscription and dead-letters its pending deliveries. The delivery log is kept.
+// @Tags webhooks
+// @Param name path string true "Subscription Name"
+// @Success 204 "No Content"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{name} [delete]
+func DeleteWebhook(c *gin.Context) {
+	err := activityController.webhooks.DeleteSubscription(c.Param("name"))
+	if errors.Is(err, repositories.ErrWebhookNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// TestWebhook godoc
+// @Summary Send a ping event
+// @Description Posts a signed ping event to the subscription's URL once and reports the outcome
+// @Tags webhooks
+// @Produce json
+// @Param name path string true "Subscription Name"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{name}/test [post]
+func TestWebhook(c *gin.Context) {
+	subscription, err := activityController.webhooks.GetSubscription(c.Param("name"))
+	if errors.Is(err, repositories.ErrWebhookNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	status, err := activityController.webhookDispatcher.Test(c.Request.Context(), *subscription)
+	result := gin.H{"status_code": status, "result": "ok"}
+	if err != nil {
+		result["result"] = err.Error()
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetWebhookDeliveries godoc
+// @Summary List a subscription's deliveries
+// @Description Lists the delivery log of a subscription, newest first, including pending and dead-lettered deliveries
+// @Tags webhooks
+// @Produce json
+// @Param name path string true "Subscription Name"
+// @Param status query string false "Only deliveries with this status" Enums(pending, delivered, dead)
+// @Param limit query int false "Page size (default 100, max 1000)"
+// @Param cursor query string false "Opaque cursor from the previous page's Link header"
+// @Success 200 {array} WebhookDeliveryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{name}/deliveries [get]
+func GetWebhookDeliveries(c *gin.Context) {
+	status := c.Query("status")
+	switch status {
+	case "", models.DeliveryPending, models.DeliveryDelivered, models.DeliveryDead:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be pending, delivered or dead"})
+		return
+	}
+	limit, err := parseLimit(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if limit == 0 {
+		limit = repositories.DefaultPageLimit
+	}
+
+	page, err := activityController.webhooks.GetDeliveries(c.Param("name"), status, c.Query("cursor"), limit)
+	if errors.Is(err, repositories.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	response := make([]WebhookDeliveryResponse, len(page.Deliveries))
+	for i, delivery := range page.Deliveries {
+		response[i] = WebhookDeliveryResponse{WebhookDelivery: delivery, Payload: json.RawMessage(delivery.Payload)}
+	}
+	setNextPageLink(c, page.NextCursor)
+	c.JSON(http.StatusOK, response)
+}
+
+// RetryWebhookDelivery godoc
+// @Summary Retry a dead delivery
+// @Description Moves a dead-lettered delivery back into the outbox with its attempts reset
+// @Tags webhooks
+// @Produce json
+// @Param name path string true "Subscription Name"
+// @Param id path int true "Delivery ID"
+// @Success 200 {object} WebhookDeliveryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{name}/deliveries/{id}/retry [post]
+func RetryWebhookDelivery(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid delivery id"})
+		return
+	}
+
+	delivery, err := activityController.webhooks.Retry(c.Param("name"), id)
+	switch {
+	case errors.Is(err, repositories.ErrWebhookNotFound), errors.Is(err, repositories.ErrDeliveryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, repositories.ErrDeliveryNotDead):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, WebhookDeliveryResponse{WebhookDelivery: *delivery, Payload: json.RawMessage(delivery.Payload)})
+}
+
+func respondWithWebhook(c *gin.Context, status int, subscription *models.WebhookSubscription, showSecret bool, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrWebhookNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, repositories.ErrWebhookExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := newWebhookResponse(*subscription, showSecret)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(status, response)
+}
@@ -1,28 +1,44 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"go-rest-api/controllers"
 	_ "go-rest-api/docs"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"go-rest-api/config"
 	"go-rest-api/db"
 	"go-rest-api/metrics"
 	"go-rest-api/middleware"
+	"go-rest-api/repositories"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+// shutdownTimeout bounds how long in-flight requests may take after a
+// shutdown signal.
+const shutdownTimeout = 10 * time.Second
+
 // @title           Activity API
 // @version         1.0
 // @description     An API for tracking device activities and usage statistics.
 // @host            localhost:8080
 // @BasePath        /api/v1
 func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Initialize database
 	if err := db.InitDB(); err != nil {
 		log.Fatal(err)
@@ -30,15 +46,25 @@ func main() {
 	defer db.CloseDB()
 
 	// Initialize activity controller
-	controllers.InitActivityController(db.OB)
+	controllers.InitActivityController(db.OB, cfg)
+	controllers.InitDeviceController(db.OB)
+	statsStore := repositories.NewStatsRepository(db.OB)
+	controllers.InitStatsController(statsStore, repositories.NewSLORepository(db.OB), cfg)
 
 	for _ , arg := range os.Args {
 		if arg == "healthcheck" {
 			fmt.Println("OK")
 			os.Exit(0)
 		}
+		if arg == "migrate-headers" {
+			os.Exit(migrateHeaders(cfg))
+		}
 	}	
 	
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	controllers.StartBackgroundWorkers(ctx)
+
 	// Initialize Prometheus metrics
 	metrics.Init()
 	
@@ -49,6 +75,21 @@ func main() {
 	})
 	// Add Prometheus middleware to all routes
 	router.Use(middleware.PrometheusMiddleware())
+	// The recorder outlives ctx so that requests finishing during the
+	// shutdown are still written.
+	recorderCtx, stopRecorder := context.WithCancel(context.Background())
+	defer stopRecorder()
+	recorderDone := make(chan struct{})
+	if cfg.StatsRecorderEnabled {
+		recorder := middleware.NewStatsRecorder(statsStore, cfg)
+		go func() {
+			defer close(recorderDone)
+			recorder.Run(recorderCtx)
+		}()
+		router.Use(recorder.Middleware())
+	} else {
+		close(recorderDone)
+	}
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
@@ -57,18 +98,91 @@ func main() {
 		{
 			stats.POST("", controllers.CreateStats)
 			stats.GET("", controllers.GetAllStats)
-			stats.GET("/endpoints/:endpoint", controllers.GetStatsByEndpoint)
-			stats.DELETE("/endpoints/:endpoint", controllers.DeleteStatsByEndpoint)
+			stats.GET("/summary", controllers.GetStatsSummary)
+			stats.GET("/endpoints/*endpoint", controllers.GetStatsByEndpoint)
+			stats.DELETE("/endpoints/*endpoint", controllers.DeleteStatsByEndpoint)
 			stats.DELETE("/:id", controllers.DeleteStats)
 		}
 		activities := v1.Group("/activities")
 		{
 			activities.POST("", controllers.CreateActivity)
 			activities.GET("", controllers.GetAllActivities)
+			activities.POST("/search", controllers.SearchActivities)
+			activities.GET("/stream", controllers.StreamActivities)
+			activities.GET("/aggregate", controllers.AggregateActivities)
+			activities.GET("/export", controllers.ExportActivities)
 			activities.GET("/device/:device", controllers.GetActivitiesByDevice)
 			activities.GET("/grid/:grid", controllers.GetActivitiesByGrid)
+			activities.GET("/trash", controllers.GetTrash)
+			activities.POST("/trash/:id/restore", controllers.RestoreActivity)
 			activities.DELETE("/:id", controllers.DeleteActivity)
 		}
+		// Custom methods such as /activities:batch
+		v1.POST("/activities:verb", controllers.ActivityCustomMethod)
+		devices := v1.Group("/devices")
+		{
+			devices.POST("", controllers.CreateDevice)
+			devices.GET("", controllers.GetDevices)
+			devices.GET("/offline", controllers.GetOfflineDevices)
+			devices.GET("/:name", controllers.GetDevice)
+			devices.GET("/:name/sessions", controllers.GetDeviceSessions)
+			devices.PUT("/:name", controllers.UpdateDevice)
+			devices.DELETE("/:name", controllers.DeleteDevice)
+		}
+		grids := v1.Group("/grids")
+		{
+			grids.POST("", controllers.CreateGrid)
+			grids.GET("", controllers.GetGrids)
+			grids.GET("/:name", controllers.GetGrid)
+			grids.GET("/:name/descendants", controllers.GetGridDescendants)
+			grids.GET("/:name/sessions/stats", controllers.GetGridSessionStats)
+			grids.PUT("/:name", controllers.UpdateGrid)
+			grids.DELETE("/:name", controllers.DeleteGrid)
+		}
+		alerts := v1.Group("/alerts")
+		{
+			alerts.GET("", controllers.GetAlerts)
+			alerts.POST("/rules", controllers.CreateAlertRule)
+			alerts.GET("/rules", controllers.GetAlertRules)
+			alerts.GET("/rules/:name", controllers.GetAlertRule)
+			alerts.PUT("/rules/:name", controllers.UpdateAlertRule)
+			alerts.DELETE("/rules/:name", controllers.DeleteAlertRule)
+			alerts.POST("/rules/:name/test", controllers.TestAlertRule)
+		}
+		slos := v1.Group("/slos")
+		{
+			slos.GET("", controllers.GetSLOs)
+			slos.POST("", controllers.CreateSLO)
+			slos.GET("/reports", controllers.GetSLOReports)
+			slos.GET("/:name", controllers.GetSLO)
+			slos.PUT("/:name", controllers.UpdateSLO)
+			slos.DELETE("/:name", controllers.DeleteSLO)
+			slos.GET("/:name/report", controllers.GetSLOReport)
+		}
+		webhooks := v1.Group("/webhooks")
+		{
+			webhooks.GET("", controllers.GetWebhooks)
+			webhooks.POST("", controllers.CreateWebhook)
+			webhooks.GET("/:name", controllers.GetWebhook)
+			webhooks.PUT("/:name", controllers.UpdateWebhook)
+			webhooks.DELETE("/:name", controllers.DeleteWebhook)
+			webhooks.POST("/:name/test", controllers.TestWebhook)
+			webhooks.GET("/:name/deliveries", controllers.GetWebhookDeliveries)
+			webhooks.POST("/:name/deliveries/:id/retry", controllers.RetryWebhookDelivery)
+		}
+		changes := v1.Group("/changes")
+		{
+			changes.GET("", controllers.GetChanges)
+			changes.GET("/checkpoints", controllers.GetChangeCheckpoints)
+			changes.GET("/checkpoints/:name", controllers.GetChangeCheckpoint)
+			changes.PUT("/checkpoints/:name", controllers.PutChangeCheckpoint)
+			changes.DELETE("/checkpoints/:name", controllers.DeleteChangeCheckpoint)
+		}
+		clock := v1.Group("/clock")
+		{
+			clock.GET("/offsets", controllers.GetClockOffsets)
+			clock.GET("/offsets/:device", controllers.GetClockOffsetByDevice)
+		}
 		v1.GET("/health", func(c *gin.Context) {
 			c.Redirect(http.StatusMovedPermanently, "/health")
 		})
@@ -83,5 +197,44 @@ func main() {
 	// Swagger documentation route
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	router.Run(":8080")
-} 
\ No newline at end of file
+	server := &http.Server{Addr: ":8080", Handler: router}
+	go func() {
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("server failed: %v", err)
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	log.Println("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("server shutdown: %v", err)
+	}
+	stopRecorder()
+	<-recorderDone
+}
+
+// migrateHeaders rewrites stored activity headers to match the current header
+// policy. Pass --dry-run to only report how many activities would change.
+func migrateHeaders(cfg config.Config) int {
+	defer db.CloseDB()
+
+	dryRun := false
+	for _, arg := range os.Args {
+		if arg == "--dry-run" {
+			dryRun = true
+		}
+	}
+
+	result, err := controllers.MigrateActivityHeaders(context.Background(), cfg.RetentionBatchSize, dryRun)
+	fmt.Printf("scanned %d activities, rewrote %d, skipped %d unreadable (dry run: %t)\n",
+		result.Scanned, result.Rewritten, result.Skipped, dryRun)
+	if err != nil {
+		log.Printf("header migration failed: %v", err)
+		return 1
+	}
+	return 0
+}
@@ -11,12 +11,18 @@ var (
 		[]string{"operation", "grid", "device"},
 	)
 
-	ActivityCount = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "activity_count",
-			Help: "Current number of activities",
-		},
-		[]string{"grid", "device"},
+	// ActivityCount and ActivitySubtreeCount are collected at scrape time by
+	// an ActivityCountCollector.
+	ActivityCount = prometheus.NewDesc(
+		"activity_count",
+		"Current number of activities of a device in a grid",
+		[]string{"grid", "device"}, nil,
+	)
+
+	ActivitySubtreeCount = prometheus.NewDesc(
+		"activity_subtree_count",
+		"Current number of activities of a device in a registered grid and all its descendant grids",
+		[]string{"grid", "device"}, nil,
 	)
 
 	ActivityLatency = prometheus.NewHistogramVec(
@@ -27,10 +33,99 @@ var (
 		},
 		[]string{"operation"},
 	)
+
+	DeviceClockOffset = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "device_clock_offset_seconds",
+			Help: "Estimated offset of the device clock behind the server clock in seconds",
+		},
+		[]string{"device"},
+	)
+
+	DeviceOnline = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "device_online",
+			Help: "Whether a monitored device has sent an activity within its heartbeat interval (1) or not (0)",
+		},
+		[]string{"device"},
+	)
+
+	SessionsOpen = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "device_sessions_open",
+			Help: "Current number of open device sessions",
+		},
+	)
+
+	ActivityStreamSubscribers = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "activity_stream_subscribers",
+			Help: "Current number of live activity stream subscribers",
+		},
+	)
+
+	ActivityStreamEvictionsTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "activity_stream_evictions_total",
+			Help: "Total number of stream subscribers evicted for falling behind",
+		},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(ActivityOperationsTotal)
-	prometheus.MustRegister(ActivityCount)
 	prometheus.MustRegister(ActivityLatency)
-} 
\ No newline at end of file
+	prometheus.MustRegister(DeviceClockOffset)
+	prometheus.MustRegister(DeviceOnline)
+	prometheus.MustRegister(SessionsOpen)
+	prometheus.MustRegister(ActivityStreamSubscribers)
+	prometheus.MustRegister(ActivityStreamEvictionsTotal)
+}
+
+// ActivityCounts are the activities per grid and device, and the subtree of
+// every registered grid, the grid itself included.
+type ActivityCounts struct {
+	ByGrid   map[string]map[string]uint64
+	Subtrees map[string][]string
+}
+
+// ActivityCountCollector exports ActivityCount and ActivitySubtreeCount from
+// the counts source returns when scraped.
+type ActivityCountCollector struct {
+	source func() (ActivityCounts, error)
+}
+
+// RegisterActivityCounts registers an ActivityCountCollector reading from source.
+func RegisterActivityCounts(source func() (ActivityCounts, error)) {
+	prometheus.MustRegister(&ActivityCountCollector{source: source})
+}
+
+func (c *ActivityCountCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- ActivityCount
+	ch <- ActivitySubtreeCount
+}
+
+func (c *ActivityCountCollector) Collect(ch chan<- prometheus.Metric) {
+	counts, err := c.source()
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(ActivityCount, err)
+		return
+	}
+
+	for grid, devices := range counts.ByGrid {
+		for device, count := range devices {
+			ch <- prometheus.MustNewConstMetric(ActivityCount, prometheus.GaugeValue, float64(count), grid, device)
+		}
+	}
+	for grid, subtree := range counts.Subtrees {
+		devices := make(map[string]uint64)
+		for _, name := range subtree {
+			for device, count := range counts.ByGrid[name] {
+				devices[device] += count
+			}
+		}
+		for device, count := range devices {
+			ch <- prometheus.MustNewConstMetric(ActivitySubtreeCount, prometheus.GaugeValue, float64(count), grid, device)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	AlertsFiring = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "alerts_firing",
+			Help: "Current number of firing alerts",
+		},
+		[]string{"rule"},
+	)
+
+	AlertNotificationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "alert_notifications_total",
+			Help: "Total number of alert webhook notifications by alert status and result",
+		},
+		[]string{"status", "result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(AlertsFiring)
+	prometheus.MustRegister(AlertNotificationsTotal)
+}
@@ -20,9 +20,18 @@ var (
 		},
 		[]string{"operation"},
 	)
+
+	UsageStatsRecordedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "usage_stats_recorded_total",
+			Help: "Total number of requests handled by the usage stats recorder by result",
+		},
+		[]string{"result"},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(BookOperationsTotal)
 	prometheus.MustRegister(StatsOperationsTotal)
+	prometheus.MustRegister(UsageStatsRecordedTotal)
 } 
\ No newline at end of file
@@ -27,10 +27,36 @@ var (
 		},
 		[]string{"entity"},
 	)
+
+	RetentionRowsPrunedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "retention_rows_pruned_total",
+			Help: "Total number of activities deleted by the retention worker",
+		},
+		[]string{"reason"},
+	)
+
+	RetentionSweepDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "retention_sweep_duration_seconds",
+			Help:    "Duration of retention sweeps in seconds",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60},
+		},
+	)
+
+	RetentionOldestTimestamp = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "retention_oldest_timestamp_seconds",
+			Help: "Unix time of the oldest activity retained after the last sweep, including the trash",
+		},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(ObjectBoxOperationsTotal)
 	prometheus.MustRegister(ObjectBoxOperationDuration)
 	prometheus.MustRegister(ObjectBoxEntityCount)
+	prometheus.MustRegister(RetentionRowsPrunedTotal)
+	prometheus.MustRegister(RetentionSweepDuration)
+	prometheus.MustRegister(RetentionOldestTimestamp)
 } 
\ No newline at end of file
@@ -0,0 +1,53 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	SLOCompliance = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "slo_compliance_ratio",
+			Help: "Share of good requests within the SLO window",
+		},
+		[]string{"slo"},
+	)
+
+	SLOErrorBudgetRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "slo_error_budget_remaining_ratio",
+			Help: "Share of the error budget left within the SLO window, negative once exhausted",
+		},
+		[]string{"slo"},
+	)
+
+	SLOBurnRate = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "slo_burn_rate",
+			Help: "Rate at which the error budget is spent, relative to spending it exactly over the SLO window",
+		},
+		[]string{"slo", "window"},
+	)
+
+	SLOAlertsFiring = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "slo_alerts_firing",
+			Help: "Whether a burn rate alert of the SLO is firing, by severity",
+		},
+		[]string{"slo", "severity"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(SLOCompliance)
+	prometheus.MustRegister(SLOErrorBudgetRemaining)
+	prometheus.MustRegister(SLOBurnRate)
+	prometheus.MustRegister(SLOAlertsFiring)
+}
+
+// ForgetSLO removes the series of a deleted SLO.
+func ForgetSLO(name string) {
+	labels := prometheus.Labels{"slo": name}
+	SLOCompliance.DeletePartialMatch(labels)
+	SLOErrorBudgetRemaining.DeletePartialMatch(labels)
+	SLOBurnRate.DeletePartialMatch(labels)
+	SLOAlertsFiring.DeletePartialMatch(labels)
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	WebhookDeliveriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_deliveries_total",
+			Help: "Total number of webhook delivery attempts by subscription and result",
+		},
+		[]string{"subscription", "result"},
+	)
+
+	WebhookDeliveryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "webhook_delivery_duration_seconds",
+			Help:    "Duration of webhook delivery attempts",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"subscription"},
+	)
+
+	WebhookOutboxPending = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "webhook_outbox_pending",
+			Help: "Current number of webhook deliveries waiting in the outbox",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(WebhookDeliveriesTotal)
+	prometheus.MustRegister(WebhookDeliveryDuration)
+	prometheus.MustRegister(WebhookOutboxPending)
+}
@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"context"
+	"go-rest-api/config"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"go-rest-api/services"
+	"go-rest-api/utils"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UnmatchedEndpoint is recorded as the endpoint of requests no route matched,
+// so that arbitrary paths do not each become an endpoint of their own.
+const UnmatchedEndpoint = "(unmatched)"
+
+// StatsRecorder records a UsageStats entry for every sampled request. The
+// handler only queues the entry; Run writes the queue to the store in batches.
+type StatsRecorder struct {
+	store         repositories.StatsStore
+	clientIPs     services.ClientIPResolver
+	sampleRate    float64
+	exclude       []string
+	batchSize     int
+	flushInterval time.Duration
+	queue         chan *models.UsageStats
+}
+
+func NewStatsRecorder(store repositories.StatsStore, cfg config.Config) *StatsRecorder {
+	batchSize := cfg.StatsRecorderBatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	queueSize := cfg.StatsRecorderQueueSize
+	if queueSize <= 0 {
+		queueSize = 1
+	}
+	flushInterval := cfg.StatsRecorderFlushInterval
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+	return &StatsRecorder{
+		store:         store,
+		clientIPs:     services.NewClientIPResolver(cfg),
+		sampleRate:    cfg.StatsRecorderSampleRate,
+		exclude:       cfg.StatsRecorderExclude,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		queue:         make(chan *models.UsageStats, queueSize),
+	}
+}
+
+func (r *StatsRecorder) excluded(path string) bool {
+	for _, pattern := range r.exclude {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		} else if path == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware returns the handler that queues the entries. It never blocks:
+// entries are dropped while the queue is full.
+func (r *StatsRecorder) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if r.excluded(c.Request.URL.Path) || (r.sampleRate < 1 && rand.Float64() >= r.sampleRate) {
+			c.Next()
+			return
+		}
+		start := time.Now()
+
+		c.Next()
+
+		endpoint := c.FullPath()
+		if endpoint == "" {
+			endpoint = UnmatchedEndpoint
+		}
+		stats := &models.UsageStats{
+			ID:           utils.GenerateUUID(),
+			Endpoint:     endpoint,
+			Method:       c.Request.Method,
+			Status:       c.Writer.Status(),
+			Timestamp:    start,
+			DurationMs:   float64(time.Since(start).Microseconds()) / 1000,
+			RequestSize:  max(c.Request.ContentLength, 0),
+			ResponseSize: int64(max(c.Writer.Size(), 0)),
+			ClientIP:     r.clientIPs.Resolve(c.Request),
+			UserAgent:    c.Request.UserAgent(),
+			Recorded:     true,
+		}
+		select {
+		case r.queue <- stats:
+		default:
+			metrics.UsageStatsRecordedTotal.WithLabelValues("dropped").Inc()
+		}
+	}
+}
+
+// Run writes queued entries until ctx is done, then flushes what is left.
+func (r *StatsRecorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*models.UsageStats, 0, r.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := r.store.CreateMany(batch); err != nil {
+			log.Printf("writing %d usage stats failed: %v", len(batch), err)
+			metrics.UsageStatsRecordedTotal.WithLabelValues("failed").Add(float64(len(batch)))
+		} else {
+			metrics.UsageStatsRecordedTotal.WithLabelValues("written").Add(float64(len(batch)))
+		}
+		batch = make([]*models.UsageStats, 0, r.batchSize)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case stats := <-r.queue:
+					batch = append(batch, stats)
+					if len(batch) >= r.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		case stats := <-r.queue:
+			batch = append(batch, stats)
+			if len(batch) >= r.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
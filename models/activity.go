@@ -15,7 +15,33 @@ type DeviceActivity struct {
 	GridName   string    `objectbox:"index"`
 	Action     string
 	Headers    string    // Store as JSON string
-	Timestamp  time.Time
+	Timestamp  time.Time `objectbox:"index"` // Event time reported by the device
+	ReceivedAt time.Time                      // Server time the activity was received
+	// TimestampFlagged is set when Timestamp fell outside the accepted clock-skew bounds.
+	TimestampFlagged bool
+	// ClaimedSourceIP is the SourceIP sent in the request body; SourceIP itself
+	// is derived by the server from the connection.
+	ClaimedSourceIP string
+	// SourceIPMismatch is set when ClaimedSourceIP differs from SourceIP.
+	SourceIPMismatch bool `objectbox:"index"`
+	// DeviceId links the activity to its registered Device.
+	DeviceId uint64 `objectbox:"link:Device"`
+	// Synthetic marks activities recorded by the server itself, such as
+	// heartbeat transitions. They do not count as device presence.
+	Synthetic bool
+	// EventId is an optional client-generated identifier of the event. A
+	// retried create with the same device and EventId returns the original.
+	EventId string `objectbox:"index"`
+	// DeletedAt is set when the activity is moved to the trash. Activities in
+	// the trash are hidden from queries until restored or purged.
+	DeletedAt time.Time `objectbox:"index"`
+}
+
+// IsDeleted reports whether the activity is in the trash. Live activities
+// carry either the zero time or, when stored before DeletedAt existed, the
+// Unix epoch.
+func (a *DeviceActivity) IsDeleted() bool {
+	return a.DeletedAt.UnixMilli() > 0
 }
 
 // Helper methods for headers
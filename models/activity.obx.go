@@ -24,14 +24,22 @@ var DeviceActivityBinding = deviceActivity_EntityInfo{
 
 // DeviceActivity_ contains type-based Property helpers to facilitate some common operations such as Queries.
 var DeviceActivity_ = struct {
-	Id         *objectbox.PropertyUint64
-	UniqueId   *objectbox.PropertyString
-	SourceIP   *objectbox.PropertyString
-	DeviceName *objectbox.PropertyString
-	GridName   *objectbox.PropertyString
-	Action     *objectbox.PropertyString
-	Headers    *objectbox.PropertyString
-	Timestamp  *objectbox.PropertyInt64
+	Id               *objectbox.PropertyUint64
+	UniqueId         *objectbox.PropertyString
+	SourceIP         *objectbox.PropertyString
+	DeviceName       *objectbox.PropertyString
+	GridName         *objectbox.PropertyString
+	Action           *objectbox.PropertyString
+	Headers          *objectbox.PropertyString
+	Timestamp        *objectbox.PropertyInt64
+	ReceivedAt       *objectbox.PropertyInt64
+	TimestampFlagged *objectbox.PropertyBool
+	ClaimedSourceIP  *objectbox.PropertyString
+	SourceIPMismatch *objectbox.PropertyBool
+	DeviceId         *objectbox.RelationToOne
+	Synthetic        *objectbox.PropertyBool
+	EventId          *objectbox.PropertyString
+	DeletedAt        *objectbox.PropertyInt64
 }{
 	Id: &objectbox.PropertyUint64{
 		BaseProperty: &objectbox.BaseProperty{
@@ -81,6 +89,55 @@ var DeviceActivity_ = struct {
 			Entity: &DeviceActivityBinding.Entity,
 		},
 	},
+	ReceivedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     9,
+			Entity: &DeviceActivityBinding.Entity,
+		},
+	},
+	TimestampFlagged: &objectbox.PropertyBool{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     10,
+			Entity: &DeviceActivityBinding.Entity,
+		},
+	},
+	ClaimedSourceIP: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     11,
+			Entity: &DeviceActivityBinding.Entity,
+		},
+	},
+	SourceIPMismatch: &objectbox.PropertyBool{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     12,
+			Entity: &DeviceActivityBinding.Entity,
+		},
+	},
+	DeviceId: &objectbox.RelationToOne{
+		Property: &objectbox.BaseProperty{
+			Id:     13,
+			Entity: &DeviceActivityBinding.Entity,
+		},
+		Target: &DeviceBinding.Entity,
+	},
+	Synthetic: &objectbox.PropertyBool{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     14,
+			Entity: &DeviceActivityBinding.Entity,
+		},
+	},
+	EventId: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     15,
+			Entity: &DeviceActivityBinding.Entity,
+		},
+	},
+	DeletedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     16,
+			Entity: &DeviceActivityBinding.Entity,
+		},
+	},
 }
 
 // GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
@@ -106,7 +163,25 @@ func (deviceActivity_EntityInfo) AddToModel(model *objectbox.Model) {
 	model.Property("Action", 9, 6, 5276800250942670244)
 	model.Property("Headers", 9, 7, 2732099102083057548)
 	model.Property("Timestamp", 10, 8, 4996867769747770200)
-	model.EntityLastPropertyId(8, 4996867769747770200)
+	model.PropertyFlags(8)
+	model.PropertyIndex(4, 1522679491939931306)
+	model.Property("ReceivedAt", 10, 9, 542070866091986163)
+	model.Property("TimestampFlagged", 1, 10, 4734146736646319865)
+	model.Property("ClaimedSourceIP", 9, 11, 5723618413500777136)
+	model.Property("SourceIPMismatch", 1, 12, 4679504286383924731)
+	model.PropertyFlags(8)
+	model.PropertyIndex(5, 3816402155144123998)
+	model.Property("DeviceId", 11, 13, 641713617195153224)
+	model.PropertyFlags(520)
+	model.PropertyRelation("Device", 6, 4818478925308030240)
+	model.Property("Synthetic", 1, 14, 3524485706182640326)
+	model.Property("EventId", 9, 15, 7830116620087314509)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(18, 9090744151930916222)
+	model.Property("DeletedAt", 10, 16, 6145013007369231494)
+	model.PropertyFlags(8)
+	model.PropertyIndex(21, 661786150998072213)
+	model.EntityLastPropertyId(16, 6145013007369231494)
 }
 
 // GetId is called by ObjectBox during Put operations to check for existing ID on an object
@@ -137,15 +212,37 @@ func (deviceActivity_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Bu
 		}
 	}
 
+	var propReceivedAt int64
+	{
+		var err error
+		propReceivedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.ReceivedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on DeviceActivity.ReceivedAt: " + err.Error())
+		}
+	}
+
+	var propDeletedAt int64
+	{
+		var err error
+		propDeletedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.DeletedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on DeviceActivity.DeletedAt: " + err.Error())
+		}
+	}
+
 	var offsetUniqueId = fbutils.CreateStringOffset(fbb, obj.UniqueId)
 	var offsetSourceIP = fbutils.CreateStringOffset(fbb, obj.SourceIP)
 	var offsetDeviceName = fbutils.CreateStringOffset(fbb, obj.DeviceName)
 	var offsetGridName = fbutils.CreateStringOffset(fbb, obj.GridName)
 	var offsetAction = fbutils.CreateStringOffset(fbb, obj.Action)
 	var offsetHeaders = fbutils.CreateStringOffset(fbb, obj.Headers)
+	var offsetClaimedSourceIP = fbutils.CreateStringOffset(fbb, obj.ClaimedSourceIP)
+	var offsetEventId = fbutils.CreateStringOffset(fbb, obj.EventId)
+
+	var rIdDeviceId = obj.DeviceId
 
 	// build the FlatBuffers object
-	fbb.StartObject(8)
+	fbb.StartObject(16)
 	fbutils.SetUint64Slot(fbb, 0, id)
 	fbutils.SetUOffsetTSlot(fbb, 1, offsetUniqueId)
 	fbutils.SetUOffsetTSlot(fbb, 2, offsetSourceIP)
@@ -154,6 +251,14 @@ func (deviceActivity_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Bu
 	fbutils.SetUOffsetTSlot(fbb, 5, offsetAction)
 	fbutils.SetUOffsetTSlot(fbb, 6, offsetHeaders)
 	fbutils.SetInt64Slot(fbb, 7, propTimestamp)
+	fbutils.SetInt64Slot(fbb, 8, propReceivedAt)
+	fbutils.SetBoolSlot(fbb, 9, obj.TimestampFlagged)
+	fbutils.SetUOffsetTSlot(fbb, 10, offsetClaimedSourceIP)
+	fbutils.SetBoolSlot(fbb, 11, obj.SourceIPMismatch)
+	fbutils.SetUint64Slot(fbb, 12, rIdDeviceId)
+	fbutils.SetBoolSlot(fbb, 13, obj.Synthetic)
+	fbutils.SetUOffsetTSlot(fbb, 14, offsetEventId)
+	fbutils.SetInt64Slot(fbb, 15, propDeletedAt)
 	return nil
 }
 
@@ -175,15 +280,33 @@ func (deviceActivity_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (in
 		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on DeviceActivity.Timestamp: " + err.Error())
 	}
 
+	propReceivedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 20))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on DeviceActivity.ReceivedAt: " + err.Error())
+	}
+
+	propDeletedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 34))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on DeviceActivity.DeletedAt: " + err.Error())
+	}
+
 	return &DeviceActivity{
-		Id:         propId,
-		UniqueId:   fbutils.GetStringSlot(table, 6),
-		SourceIP:   fbutils.GetStringSlot(table, 8),
-		DeviceName: fbutils.GetStringSlot(table, 10),
-		GridName:   fbutils.GetStringSlot(table, 12),
-		Action:     fbutils.GetStringSlot(table, 14),
-		Headers:    fbutils.GetStringSlot(table, 16),
-		Timestamp:  propTimestamp,
+		Id:               propId,
+		UniqueId:         fbutils.GetStringSlot(table, 6),
+		SourceIP:         fbutils.GetStringSlot(table, 8),
+		DeviceName:       fbutils.GetStringSlot(table, 10),
+		GridName:         fbutils.GetStringSlot(table, 12),
+		Action:           fbutils.GetStringSlot(table, 14),
+		Headers:          fbutils.GetStringSlot(table, 16),
+		Timestamp:        propTimestamp,
+		ReceivedAt:       propReceivedAt,
+		TimestampFlagged: fbutils.GetBoolSlot(table, 22),
+		ClaimedSourceIP:  fbutils.GetStringSlot(table, 24),
+		SourceIPMismatch: fbutils.GetBoolSlot(table, 26),
+		DeviceId:         fbutils.GetUint64Slot(table, 28),
+		Synthetic:        fbutils.GetBoolSlot(table, 30),
+		EventId:          fbutils.GetStringSlot(table, 32),
+		DeletedAt:        propDeletedAt,
 	}, nil
 }
 
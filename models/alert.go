@@ -0,0 +1,89 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+//go:generate go run github.com/objectbox/objectbox-go/cmd/objectbox-gogen
+
+// AlertRule fires when more than Threshold activities matching Filter arrive
+// within WindowSeconds, counted separately for every GroupBy value combination.
+type AlertRule struct {
+	Id            uint64 `objectbox:"id"`
+	Name          string `objectbox:"unique"`
+	Description   string
+	Enabled       bool
+	Filter        string // Store as JSON string
+	GroupBy       string // Comma separated activity fields
+	WindowSeconds int64
+	Threshold     int64
+	Webhooks      string // Store as JSON array of URLs
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Alert statuses
+const (
+	AlertFiring   = "firing"
+	AlertResolved = "resolved"
+)
+
+// Alert is one firing of a rule for one group. While it is firing no second
+// alert is created for the same Fingerprint.
+type Alert struct {
+	Id          uint64 `objectbox:"id"`
+	RuleId      uint64 `objectbox:"link:AlertRule"`
+	RuleName    string `objectbox:"index"`
+	Fingerprint string `objectbox:"index"` // Rule Id and group values
+	Group       string // Store as JSON string
+	Status      string `objectbox:"index"`
+	Count       int64  // Matching activities in the window when the alert fired
+	FiredAt     time.Time
+	ResolvedAt  time.Time
+	// LastActivityId is the last activity that counted towards the alert.
+	LastActivityId uint64
+	// NotifiedStatus is the last status delivered to the rule's webhooks.
+	NotifiedStatus string
+}
+
+// Helper methods for webhooks and group
+func (r *AlertRule) SetWebhooks(urls []string) error {
+	data, err := json.Marshal(urls)
+	if err != nil {
+		return err
+	}
+	r.Webhooks = string(data)
+	return nil
+}
+
+func (r *AlertRule) GetWebhooks() ([]string, error) {
+	var urls []string
+	if r.Webhooks == "" {
+		return urls, nil
+	}
+	if err := json.Unmarshal([]byte(r.Webhooks), &urls); err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+func (a *Alert) SetGroup(group map[string]string) error {
+	data, err := json.Marshal(group)
+	if err != nil {
+		return err
+	}
+	a.Group = string(data)
+	return nil
+}
+
+func (a *Alert) GetGroup() (map[string]string, error) {
+	group := map[string]string{}
+	if a.Group == "" {
+		return group, nil
+	}
+	if err := json.Unmarshal([]byte(a.Group), &group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
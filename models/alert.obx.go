@@ -0,0 +1,925 @@
+// Code generated by ObjectBox; DO NOT EDIT.
+// Learn more about defining entities and generating this file - visit https://golang.objectbox.io/entity-annotations
+
+package models
+
+import (
+	"errors"
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+)
+
+type alertRule_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var AlertRuleBinding = alertRule_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 4,
+	},
+	Uid: 8997894378465233277,
+}
+
+// AlertRule_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var AlertRule_ = struct {
+	Id            *objectbox.PropertyUint64
+	Name          *objectbox.PropertyString
+	Description   *objectbox.PropertyString
+	Enabled       *objectbox.PropertyBool
+	Filter        *objectbox.PropertyString
+	GroupBy       *objectbox.PropertyString
+	WindowSeconds *objectbox.PropertyInt64
+	Threshold     *objectbox.PropertyInt64
+	Webhooks      *objectbox.PropertyString
+	CreatedAt     *objectbox.PropertyInt64
+	UpdatedAt     *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &AlertRuleBinding.Entity,
+		},
+	},
+	Name: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &AlertRuleBinding.Entity,
+		},
+	},
+	Description: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &AlertRuleBinding.Entity,
+		},
+	},
+	Enabled: &objectbox.PropertyBool{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &AlertRuleBinding.Entity,
+		},
+	},
+	Filter: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &AlertRuleBinding.Entity,
+		},
+	},
+	GroupBy: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &AlertRuleBinding.Entity,
+		},
+	},
+	WindowSeconds: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     7,
+			Entity: &AlertRuleBinding.Entity,
+		},
+	},
+	Threshold: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     8,
+			Entity: &AlertRuleBinding.Entity,
+		},
+	},
+	Webhooks: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     9,
+			Entity: &AlertRuleBinding.Entity,
+		},
+	},
+	CreatedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     10,
+			Entity: &AlertRuleBinding.Entity,
+		},
+	},
+	UpdatedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     11,
+			Entity: &AlertRuleBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (alertRule_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (alertRule_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("AlertRule", 4, 8997894378465233277)
+	model.Property("Id", 6, 1, 1653773847478266404)
+	model.PropertyFlags(1)
+	model.Property("Name", 9, 2, 3359105473026328724)
+	model.PropertyFlags(2080)
+	model.PropertyIndex(13, 817709501644748306)
+	model.Property("Description", 9, 3, 1623687634731986684)
+	model.Property("Enabled", 1, 4, 361509899452427016)
+	model.Property("Filter", 9, 5, 8874937410351701195)
+	model.Property("GroupBy", 9, 6, 7238763318995198929)
+	model.Property("WindowSeconds", 6, 7, 4987066896908257902)
+	model.Property("Threshold", 6, 8, 3349048458669464550)
+	model.Property("Webhooks", 9, 9, 3906358150229390447)
+	model.Property("CreatedAt", 10, 10, 9006288892691944022)
+	model.Property("UpdatedAt", 10, 11, 1826770067889126506)
+	model.EntityLastPropertyId(11, 1826770067889126506)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (alertRule_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*AlertRule).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (alertRule_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*AlertRule).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (alertRule_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (alertRule_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*AlertRule)
+	var propCreatedAt int64
+	{
+		var err error
+		propCreatedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.CreatedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on AlertRule.CreatedAt: " + err.Error())
+		}
+	}
+
+	var propUpdatedAt int64
+	{
+		var err error
+		propUpdatedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.UpdatedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on AlertRule.UpdatedAt: " + err.Error())
+		}
+	}
+
+	var offsetName = fbutils.CreateStringOffset(fbb, obj.Name)
+	var offsetDescription = fbutils.CreateStringOffset(fbb, obj.Description)
+	var offsetFilter = fbutils.CreateStringOffset(fbb, obj.Filter)
+	var offsetGroupBy = fbutils.CreateStringOffset(fbb, obj.GroupBy)
+	var offsetWebhooks = fbutils.CreateStringOffset(fbb, obj.Webhooks)
+
+	// build the FlatBuffers object
+	fbb.StartObject(11)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetName)
+	fbutils.SetUOffsetTSlot(fbb, 2, offsetDescription)
+	fbutils.SetBoolSlot(fbb, 3, obj.Enabled)
+	fbutils.SetUOffsetTSlot(fbb, 4, offsetFilter)
+	fbutils.SetUOffsetTSlot(fbb, 5, offsetGroupBy)
+	fbutils.SetInt64Slot(fbb, 6, obj.WindowSeconds)
+	fbutils.SetInt64Slot(fbb, 7, obj.Threshold)
+	fbutils.SetUOffsetTSlot(fbb, 8, offsetWebhooks)
+	fbutils.SetInt64Slot(fbb, 9, propCreatedAt)
+	fbutils.SetInt64Slot(fbb, 10, propUpdatedAt)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (alertRule_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'AlertRule' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propCreatedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 22))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on AlertRule.CreatedAt: " + err.Error())
+	}
+
+	propUpdatedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 24))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on AlertRule.UpdatedAt: " + err.Error())
+	}
+
+	return &AlertRule{
+		Id:            propId,
+		Name:          fbutils.GetStringSlot(table, 6),
+		Description:   fbutils.GetStringSlot(table, 8),
+		Enabled:       fbutils.GetBoolSlot(table, 10),
+		Filter:        fbutils.GetStringSlot(table, 12),
+		GroupBy:       fbutils.GetStringSlot(table, 14),
+		WindowSeconds: fbutils.GetInt64Slot(table, 16),
+		Threshold:     fbutils.GetInt64Slot(table, 18),
+		Webhooks:      fbutils.GetStringSlot(table, 20),
+		CreatedAt:     propCreatedAt,
+		UpdatedAt:     propUpdatedAt,
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (alertRule_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*AlertRule, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (alertRule_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*AlertRule), nil)
+	}
+	return append(slice.([]*AlertRule), object.(*AlertRule))
+}
+
+// Box provides CRUD access to AlertRule objects
+type AlertRuleBox struct {
+	*objectbox.Box
+}
+
+// BoxForAlertRule opens a box of AlertRule objects
+func BoxForAlertRule(ob *objectbox.ObjectBox) *AlertRuleBox {
+	return &AlertRuleBox{
+		Box: ob.InternalBox(4),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the AlertRule.Id property on the passed object will be assigned the new ID as well.
+func (box *AlertRuleBox) Put(object *AlertRule) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the AlertRule.Id property on the passed object will be assigned the new ID as well.
+func (box *AlertRuleBox) Insert(object *AlertRule) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *AlertRuleBox) Update(object *AlertRule) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *AlertRuleBox) PutAsync(object *AlertRule) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the AlertRule.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the AlertRule.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *AlertRuleBox) PutMany(objects []*AlertRule) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *AlertRuleBox) Get(id uint64) (*AlertRule, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*AlertRule), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *AlertRuleBox) GetMany(ids ...uint64) ([]*AlertRule, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*AlertRule), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *AlertRuleBox) GetManyExisting(ids ...uint64) ([]*AlertRule, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*AlertRule), nil
+}
+
+// GetAll reads all stored objects
+func (box *AlertRuleBox) GetAll() ([]*AlertRule, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*AlertRule), nil
+}
+
+// Remove deletes a single object
+func (box *AlertRuleBox) Remove(object *AlertRule) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *AlertRuleBox) RemoveMany(objects ...*AlertRule) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the AlertRule_ struct to create conditions.
+// Keep the *AlertRuleQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *AlertRuleBox) Query(conditions ...objectbox.Condition) *AlertRuleQuery {
+	return &AlertRuleQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the AlertRule_ struct to create conditions.
+// Keep the *AlertRuleQuery if you intend to execute the query multiple times.
+func (box *AlertRuleBox) QueryOrError(conditions ...objectbox.Condition) (*AlertRuleQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &AlertRuleQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See AlertRuleAsyncBox for more information.
+func (box *AlertRuleBox) Async() *AlertRuleAsyncBox {
+	return &AlertRuleAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// AlertRuleAsyncBox provides asynchronous operations on AlertRule objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type AlertRuleAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForAlertRule creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use AlertRuleBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForAlertRule(ob *objectbox.ObjectBox, timeoutMs uint64) *AlertRuleAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 4, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 4: %s" + err.Error())
+	}
+	return &AlertRuleAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *AlertRuleAsyncBox) Put(object *AlertRule) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *AlertRuleAsyncBox) Insert(object *AlertRule) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *AlertRuleAsyncBox) Update(object *AlertRule) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *AlertRuleAsyncBox) Remove(object *AlertRule) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all AlertRule which Id is either 42 or 47:
+//
+// box.Query(AlertRule_.Id.In(42, 47)).Find()
+type AlertRuleQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *AlertRuleQuery) Find() ([]*AlertRule, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*AlertRule), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *AlertRuleQuery) Offset(offset uint64) *AlertRuleQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *AlertRuleQuery) Limit(limit uint64) *AlertRuleQuery {
+	query.Query.Limit(limit)
+	return query
+}
+
+type alert_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var AlertBinding = alert_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 5,
+	},
+	Uid: 1250771808828325615,
+}
+
+// Alert_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var Alert_ = struct {
+	Id             *objectbox.PropertyUint64
+	RuleId         *objectbox.RelationToOne
+	RuleName       *objectbox.PropertyString
+	Fingerprint    *objectbox.PropertyString
+	Group          *objectbox.PropertyString
+	Status         *objectbox.PropertyString
+	Count          *objectbox.PropertyInt64
+	FiredAt        *objectbox.PropertyInt64
+	ResolvedAt     *objectbox.PropertyInt64
+	LastActivityId *objectbox.PropertyUint64
+	NotifiedStatus *objectbox.PropertyString
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &AlertBinding.Entity,
+		},
+	},
+	RuleId: &objectbox.RelationToOne{
+		Property: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &AlertBinding.Entity,
+		},
+		Target: &AlertRuleBinding.Entity,
+	},
+	RuleName: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &AlertBinding.Entity,
+		},
+	},
+	Fingerprint: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &AlertBinding.Entity,
+		},
+	},
+	Group: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &AlertBinding.Entity,
+		},
+	},
+	Status: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &AlertBinding.Entity,
+		},
+	},
+	Count: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     7,
+			Entity: &AlertBinding.Entity,
+		},
+	},
+	FiredAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     8,
+			Entity: &AlertBinding.Entity,
+		},
+	},
+	ResolvedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     9,
+			Entity: &AlertBinding.Entity,
+		},
+	},
+	LastActivityId: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     10,
+			Entity: &AlertBinding.Entity,
+		},
+	},
+	NotifiedStatus: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     11,
+			Entity: &AlertBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (alert_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (alert_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("Alert", 5, 1250771808828325615)
+	model.Property("Id", 6, 1, 6391614517979203414)
+	model.PropertyFlags(1)
+	model.Property("RuleId", 11, 2, 2401960632898346679)
+	model.PropertyFlags(520)
+	model.PropertyRelation("AlertRule", 14, 4940828569579940001)
+	model.Property("RuleName", 9, 3, 3908492200804174097)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(15, 1138076185289173488)
+	model.Property("Fingerprint", 9, 4, 8025573950917779103)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(16, 5455738783473325670)
+	model.Property("Group", 9, 5, 4518122510379324728)
+	model.Property("Status", 9, 6, 2834662566753784722)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(17, 7274339284163021098)
+	model.Property("Count", 6, 7, 1637227031657410335)
+	model.Property("FiredAt", 10, 8, 431550146748411525)
+	model.Property("ResolvedAt", 10, 9, 4143914347142100411)
+	model.Property("LastActivityId", 6, 10, 897599091285546061)
+	model.PropertyFlags(8192)
+	model.Property("NotifiedStatus", 9, 11, 3043680099956319395)
+	model.EntityLastPropertyId(11, 3043680099956319395)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (alert_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*Alert).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (alert_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*Alert).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (alert_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (alert_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*Alert)
+	var propFiredAt int64
+	{
+		var err error
+		propFiredAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.FiredAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Alert.FiredAt: " + err.Error())
+		}
+	}
+
+	var propResolvedAt int64
+	{
+		var err error
+		propResolvedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.ResolvedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Alert.ResolvedAt: " + err.Error())
+		}
+	}
+
+	var offsetRuleName = fbutils.CreateStringOffset(fbb, obj.RuleName)
+	var offsetFingerprint = fbutils.CreateStringOffset(fbb, obj.Fingerprint)
+	var offsetGroup = fbutils.CreateStringOffset(fbb, obj.Group)
+	var offsetStatus = fbutils.CreateStringOffset(fbb, obj.Status)
+	var offsetNotifiedStatus = fbutils.CreateStringOffset(fbb, obj.NotifiedStatus)
+
+	var rIdRuleId = obj.RuleId
+
+	// build the FlatBuffers object
+	fbb.StartObject(11)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUint64Slot(fbb, 1, rIdRuleId)
+	fbutils.SetUOffsetTSlot(fbb, 2, offsetRuleName)
+	fbutils.SetUOffsetTSlot(fbb, 3, offsetFingerprint)
+	fbutils.SetUOffsetTSlot(fbb, 4, offsetGroup)
+	fbutils.SetUOffsetTSlot(fbb, 5, offsetStatus)
+	fbutils.SetInt64Slot(fbb, 6, obj.Count)
+	fbutils.SetInt64Slot(fbb, 7, propFiredAt)
+	fbutils.SetInt64Slot(fbb, 8, propResolvedAt)
+	fbutils.SetUint64Slot(fbb, 9, obj.LastActivityId)
+	fbutils.SetUOffsetTSlot(fbb, 10, offsetNotifiedStatus)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (alert_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'Alert' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propFiredAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 18))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Alert.FiredAt: " + err.Error())
+	}
+
+	propResolvedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 20))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Alert.ResolvedAt: " + err.Error())
+	}
+
+	return &Alert{
+		Id:             propId,
+		RuleId:         fbutils.GetUint64Slot(table, 6),
+		RuleName:       fbutils.GetStringSlot(table, 8),
+		Fingerprint:    fbutils.GetStringSlot(table, 10),
+		Group:          fbutils.GetStringSlot(table, 12),
+		Status:         fbutils.GetStringSlot(table, 14),
+		Count:          fbutils.GetInt64Slot(table, 16),
+		FiredAt:        propFiredAt,
+		ResolvedAt:     propResolvedAt,
+		LastActivityId: fbutils.GetUint64Slot(table, 22),
+		NotifiedStatus: fbutils.GetStringSlot(table, 24),
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (alert_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*Alert, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (alert_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*Alert), nil)
+	}
+	return append(slice.([]*Alert), object.(*Alert))
+}
+
+// Box provides CRUD access to Alert objects
+type AlertBox struct {
+	*objectbox.Box
+}
+
+// BoxForAlert opens a box of Alert objects
+func BoxForAlert(ob *objectbox.ObjectBox) *AlertBox {
+	return &AlertBox{
+		Box: ob.InternalBox(5),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the Alert.Id property on the passed object will be assigned the new ID as well.
+func (box *AlertBox) Put(object *Alert) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the Alert.Id property on the passed object will be assigned the new ID as well.
+func (box *AlertBox) Insert(object *Alert) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *AlertBox) Update(object *Alert) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *AlertBox) PutAsync(object *Alert) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the Alert.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the Alert.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *AlertBox) PutMany(objects []*Alert) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *AlertBox) Get(id uint64) (*Alert, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*Alert), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *AlertBox) GetMany(ids ...uint64) ([]*Alert, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Alert), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *AlertBox) GetManyExisting(ids ...uint64) ([]*Alert, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Alert), nil
+}
+
+// GetAll reads all stored objects
+func (box *AlertBox) GetAll() ([]*Alert, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Alert), nil
+}
+
+// Remove deletes a single object
+func (box *AlertBox) Remove(object *Alert) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *AlertBox) RemoveMany(objects ...*Alert) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the Alert_ struct to create conditions.
+// Keep the *AlertQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *AlertBox) Query(conditions ...objectbox.Condition) *AlertQuery {
+	return &AlertQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the Alert_ struct to create conditions.
+// Keep the *AlertQuery if you intend to execute the query multiple times.
+func (box *AlertBox) QueryOrError(conditions ...objectbox.Condition) (*AlertQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &AlertQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See AlertAsyncBox for more information.
+func (box *AlertBox) Async() *AlertAsyncBox {
+	return &AlertAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// AlertAsyncBox provides asynchronous operations on Alert objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type AlertAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForAlert creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use AlertBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForAlert(ob *objectbox.ObjectBox, timeoutMs uint64) *AlertAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 5, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 5: %s" + err.Error())
+	}
+	return &AlertAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *AlertAsyncBox) Put(object *Alert) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *AlertAsyncBox) Insert(object *Alert) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *AlertAsyncBox) Update(object *Alert) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *AlertAsyncBox) Remove(object *Alert) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all Alert which Id is either 42 or 47:
+//
+// box.Query(Alert_.Id.In(42, 47)).Find()
+type AlertQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *AlertQuery) Find() ([]*Alert, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Alert), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *AlertQuery) Offset(offset uint64) *AlertQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *AlertQuery) Limit(limit uint64) *AlertQuery {
+	query.Query.Limit(limit)
+	return query
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+//go:generate go run github.com/objectbox/objectbox-go/cmd/objectbox-gogen
+
+// Change operations
+const (
+	ChangeCreated  = "created"
+	ChangeUpdated  = "updated"
+	ChangeDeleted  = "deleted"  // moved to the trash
+	ChangeRestored = "restored" // taken out of the trash
+	ChangePurged   = "purged"   // removed permanently
+)
+
+// ActivityChange is an entry of the append-only changelog. It is written in
+// the same transaction as the activity mutation it records, and its Id is the
+// sequence number consumers of the change feed resume from.
+type ActivityChange struct {
+	Id         uint64 `objectbox:"id"`
+	Operation  string
+	ActivityId uint64 `objectbox:"index"`
+	UniqueId   string
+	ChangedAt  time.Time `objectbox:"index"`
+	// Activity is the activity as JSON after the change. For purged
+	// activities it only holds Id, UniqueId and DeletedAt.
+	Activity string
+}
+
+// ChangeCheckpoint is the sequence a named change feed consumer has processed.
+type ChangeCheckpoint struct {
+	Id        uint64 `objectbox:"id"`
+	Name      string `objectbox:"unique"`
+	Sequence  uint64
+	UpdatedAt time.Time
+}
@@ -0,0 +1,764 @@
+// Code generated by ObjectBox; DO NOT EDIT.
+// Learn more about defining entities and generating this file - visit https://golang.objectbox.io/entity-annotations
+
+package models
+
+import (
+	"errors"
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+)
+
+type activityChange_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var ActivityChangeBinding = activityChange_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 9,
+	},
+	Uid: 3749480975038112726,
+}
+
+// ActivityChange_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var ActivityChange_ = struct {
+	Id         *objectbox.PropertyUint64
+	Operation  *objectbox.PropertyString
+	ActivityId *objectbox.PropertyUint64
+	UniqueId   *objectbox.PropertyString
+	ChangedAt  *objectbox.PropertyInt64
+	Activity   *objectbox.PropertyString
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &ActivityChangeBinding.Entity,
+		},
+	},
+	Operation: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &ActivityChangeBinding.Entity,
+		},
+	},
+	ActivityId: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &ActivityChangeBinding.Entity,
+		},
+	},
+	UniqueId: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &ActivityChangeBinding.Entity,
+		},
+	},
+	ChangedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &ActivityChangeBinding.Entity,
+		},
+	},
+	Activity: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &ActivityChangeBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (activityChange_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (activityChange_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("ActivityChange", 9, 3749480975038112726)
+	model.Property("Id", 6, 1, 7759544524954254929)
+	model.PropertyFlags(1)
+	model.Property("Operation", 9, 2, 2089034960211309539)
+	model.Property("ActivityId", 6, 3, 628967746153635341)
+	model.PropertyFlags(8200)
+	model.PropertyIndex(27, 1483416423982468635)
+	model.Property("UniqueId", 9, 4, 8881896500016459404)
+	model.Property("ChangedAt", 10, 5, 6399642642186832680)
+	model.PropertyFlags(8)
+	model.PropertyIndex(28, 1949255842781927526)
+	model.Property("Activity", 9, 6, 6992265459869581230)
+	model.EntityLastPropertyId(6, 6992265459869581230)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (activityChange_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*ActivityChange).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (activityChange_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*ActivityChange).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (activityChange_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (activityChange_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*ActivityChange)
+	var propChangedAt int64
+	{
+		var err error
+		propChangedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.ChangedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on ActivityChange.ChangedAt: " + err.Error())
+		}
+	}
+
+	var offsetOperation = fbutils.CreateStringOffset(fbb, obj.Operation)
+	var offsetUniqueId = fbutils.CreateStringOffset(fbb, obj.UniqueId)
+	var offsetActivity = fbutils.CreateStringOffset(fbb, obj.Activity)
+
+	// build the FlatBuffers object
+	fbb.StartObject(6)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetOperation)
+	fbutils.SetUint64Slot(fbb, 2, obj.ActivityId)
+	fbutils.SetUOffsetTSlot(fbb, 3, offsetUniqueId)
+	fbutils.SetInt64Slot(fbb, 4, propChangedAt)
+	fbutils.SetUOffsetTSlot(fbb, 5, offsetActivity)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (activityChange_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'ActivityChange' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propChangedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 12))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on ActivityChange.ChangedAt: " + err.Error())
+	}
+
+	return &ActivityChange{
+		Id:         propId,
+		Operation:  fbutils.GetStringSlot(table, 6),
+		ActivityId: fbutils.GetUint64Slot(table, 8),
+		UniqueId:   fbutils.GetStringSlot(table, 10),
+		ChangedAt:  propChangedAt,
+		Activity:   fbutils.GetStringSlot(table, 14),
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (activityChange_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*ActivityChange, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (activityChange_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*ActivityChange), nil)
+	}
+	return append(slice.([]*ActivityChange), object.(*ActivityChange))
+}
+
+// Box provides CRUD access to ActivityChange objects
+type ActivityChangeBox struct {
+	*objectbox.Box
+}
+
+// BoxForActivityChange opens a box of ActivityChange objects
+func BoxForActivityChange(ob *objectbox.ObjectBox) *ActivityChangeBox {
+	return &ActivityChangeBox{
+		Box: ob.InternalBox(9),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the ActivityChange.Id property on the passed object will be assigned the new ID as well.
+func (box *ActivityChangeBox) Put(object *ActivityChange) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the ActivityChange.Id property on the passed object will be assigned the new ID as well.
+func (box *ActivityChangeBox) Insert(object *ActivityChange) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *ActivityChangeBox) Update(object *ActivityChange) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *ActivityChangeBox) PutAsync(object *ActivityChange) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the ActivityChange.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the ActivityChange.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *ActivityChangeBox) PutMany(objects []*ActivityChange) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *ActivityChangeBox) Get(id uint64) (*ActivityChange, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*ActivityChange), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *ActivityChangeBox) GetMany(ids ...uint64) ([]*ActivityChange, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*ActivityChange), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *ActivityChangeBox) GetManyExisting(ids ...uint64) ([]*ActivityChange, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*ActivityChange), nil
+}
+
+// GetAll reads all stored objects
+func (box *ActivityChangeBox) GetAll() ([]*ActivityChange, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*ActivityChange), nil
+}
+
+// Remove deletes a single object
+func (box *ActivityChangeBox) Remove(object *ActivityChange) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *ActivityChangeBox) RemoveMany(objects ...*ActivityChange) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the ActivityChange_ struct to create conditions.
+// Keep the *ActivityChangeQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *ActivityChangeBox) Query(conditions ...objectbox.Condition) *ActivityChangeQuery {
+	return &ActivityChangeQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the ActivityChange_ struct to create conditions.
+// Keep the *ActivityChangeQuery if you intend to execute the query multiple times.
+func (box *ActivityChangeBox) QueryOrError(conditions ...objectbox.Condition) (*ActivityChangeQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &ActivityChangeQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See ActivityChangeAsyncBox for more information.
+func (box *ActivityChangeBox) Async() *ActivityChangeAsyncBox {
+	return &ActivityChangeAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// ActivityChangeAsyncBox provides asynchronous operations on ActivityChange objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type ActivityChangeAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForActivityChange creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use ActivityChangeBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForActivityChange(ob *objectbox.ObjectBox, timeoutMs uint64) *ActivityChangeAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 9, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 9: %s" + err.Error())
+	}
+	return &ActivityChangeAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *ActivityChangeAsyncBox) Put(object *ActivityChange) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *ActivityChangeAsyncBox) Insert(object *ActivityChange) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *ActivityChangeAsyncBox) Update(object *ActivityChange) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *ActivityChangeAsyncBox) Remove(object *ActivityChange) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all ActivityChange which Id is either 42 or 47:
+//
+// box.Query(ActivityChange_.Id.In(42, 47)).Find()
+type ActivityChangeQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *ActivityChangeQuery) Find() ([]*ActivityChange, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*ActivityChange), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *ActivityChangeQuery) Offset(offset uint64) *ActivityChangeQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *ActivityChangeQuery) Limit(limit uint64) *ActivityChangeQuery {
+	query.Query.Limit(limit)
+	return query
+}
+
+type changeCheckpoint_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var ChangeCheckpointBinding = changeCheckpoint_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 10,
+	},
+	Uid: 6869258412051088906,
+}
+
+// ChangeCheckpoint_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var ChangeCheckpoint_ = struct {
+	Id        *objectbox.PropertyUint64
+	Name      *objectbox.PropertyString
+	Sequence  *objectbox.PropertyUint64
+	UpdatedAt *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &ChangeCheckpointBinding.Entity,
+		},
+	},
+	Name: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &ChangeCheckpointBinding.Entity,
+		},
+	},
+	Sequence: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &ChangeCheckpointBinding.Entity,
+		},
+	},
+	UpdatedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &ChangeCheckpointBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (changeCheckpoint_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (changeCheckpoint_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("ChangeCheckpoint", 10, 6869258412051088906)
+	model.Property("Id", 6, 1, 1448538094853677395)
+	model.PropertyFlags(1)
+	model.Property("Name", 9, 2, 4318113570774871530)
+	model.PropertyFlags(2080)
+	model.PropertyIndex(29, 1513904113499808802)
+	model.Property("Sequence", 6, 3, 7137392627252186898)
+	model.PropertyFlags(8192)
+	model.Property("UpdatedAt", 10, 4, 2264305835926762984)
+	model.EntityLastPropertyId(4, 2264305835926762984)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (changeCheckpoint_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*ChangeCheckpoint).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (changeCheckpoint_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*ChangeCheckpoint).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (changeCheckpoint_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (changeCheckpoint_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*ChangeCheckpoint)
+	var propUpdatedAt int64
+	{
+		var err error
+		propUpdatedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.UpdatedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on ChangeCheckpoint.UpdatedAt: " + err.Error())
+		}
+	}
+
+	var offsetName = fbutils.CreateStringOffset(fbb, obj.Name)
+
+	// build the FlatBuffers object
+	fbb.StartObject(4)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetName)
+	fbutils.SetUint64Slot(fbb, 2, obj.Sequence)
+	fbutils.SetInt64Slot(fbb, 3, propUpdatedAt)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (changeCheckpoint_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'ChangeCheckpoint' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propUpdatedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 10))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on ChangeCheckpoint.UpdatedAt: " + err.Error())
+	}
+
+	return &ChangeCheckpoint{
+		Id:        propId,
+		Name:      fbutils.GetStringSlot(table, 6),
+		Sequence:  fbutils.GetUint64Slot(table, 8),
+		UpdatedAt: propUpdatedAt,
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (changeCheckpoint_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*ChangeCheckpoint, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (changeCheckpoint_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*ChangeCheckpoint), nil)
+	}
+	return append(slice.([]*ChangeCheckpoint), object.(*ChangeCheckpoint))
+}
+
+// Box provides CRUD access to ChangeCheckpoint objects
+type ChangeCheckpointBox struct {
+	*objectbox.Box
+}
+
+// BoxForChangeCheckpoint opens a box of ChangeCheckpoint objects
+func BoxForChangeCheckpoint(ob *objectbox.ObjectBox) *ChangeCheckpointBox {
+	return &ChangeCheckpointBox{
+		Box: ob.InternalBox(10),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the ChangeCheckpoint.Id property on the passed object will be assigned the new ID as well.
+func (box *ChangeCheckpointBox) Put(object *ChangeCheckpoint) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the ChangeCheckpoint.Id property on the passed object will be assigned the new ID as well.
+func (box *ChangeCheckpointBox) Insert(object *ChangeCheckpoint) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *ChangeCheckpointBox) Update(object *ChangeCheckpoint) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *ChangeCheckpointBox) PutAsync(object *ChangeCheckpoint) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the ChangeCheckpoint.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the ChangeCheckpoint.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *ChangeCheckpointBox) PutMany(objects []*ChangeCheckpoint) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *ChangeCheckpointBox) Get(id uint64) (*ChangeCheckpoint, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*ChangeCheckpoint), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *ChangeCheckpointBox) GetMany(ids ...uint64) ([]*ChangeCheckpoint, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*ChangeCheckpoint), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *ChangeCheckpointBox) GetManyExisting(ids ...uint64) ([]*ChangeCheckpoint, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*ChangeCheckpoint), nil
+}
+
+// GetAll reads all stored objects
+func (box *ChangeCheckpointBox) GetAll() ([]*ChangeCheckpoint, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*ChangeCheckpoint), nil
+}
+
+// Remove deletes a single object
+func (box *ChangeCheckpointBox) Remove(object *ChangeCheckpoint) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *ChangeCheckpointBox) RemoveMany(objects ...*ChangeCheckpoint) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the ChangeCheckpoint_ struct to create conditions.
+// Keep the *ChangeCheckpointQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *ChangeCheckpointBox) Query(conditions ...objectbox.Condition) *ChangeCheckpointQuery {
+	return &ChangeCheckpointQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the ChangeCheckpoint_ struct to create conditions.
+// Keep the *ChangeCheckpointQuery if you intend to execute the query multiple times.
+func (box *ChangeCheckpointBox) QueryOrError(conditions ...objectbox.Condition) (*ChangeCheckpointQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &ChangeCheckpointQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See ChangeCheckpointAsyncBox for more information.
+func (box *ChangeCheckpointBox) Async() *ChangeCheckpointAsyncBox {
+	return &ChangeCheckpointAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// ChangeCheckpointAsyncBox provides asynchronous operations on ChangeCheckpoint objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type ChangeCheckpointAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForChangeCheckpoint creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use ChangeCheckpointBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForChangeCheckpoint(ob *objectbox.ObjectBox, timeoutMs uint64) *ChangeCheckpointAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 10, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 10: %s" + err.Error())
+	}
+	return &ChangeCheckpointAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *ChangeCheckpointAsyncBox) Put(object *ChangeCheckpoint) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *ChangeCheckpointAsyncBox) Insert(object *ChangeCheckpoint) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *ChangeCheckpointAsyncBox) Update(object *ChangeCheckpoint) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *ChangeCheckpointAsyncBox) Remove(object *ChangeCheckpoint) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all ChangeCheckpoint which Id is either 42 or 47:
+//
+// box.Query(ChangeCheckpoint_.Id.In(42, 47)).Find()
+type ChangeCheckpointQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *ChangeCheckpointQuery) Find() ([]*ChangeCheckpoint, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*ChangeCheckpoint), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *ChangeCheckpointQuery) Offset(offset uint64) *ChangeCheckpointQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *ChangeCheckpointQuery) Limit(limit uint64) *ChangeCheckpointQuery {
+	query.Query.Limit(limit)
+	return query
+}
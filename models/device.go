@@ -0,0 +1,69 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+//go:generate go run github.com/objectbox/objectbox-go/cmd/objectbox-gogen
+
+// Device is a registered device. It is created on the device's first activity
+// and its presence fields are updated with every activity after that.
+type Device struct {
+	Id           uint64 `objectbox:"id"`
+	Name         string `objectbox:"unique"`
+	GridName     string `objectbox:"index"` // Grid of the most recent activity
+	FirstSeen    time.Time
+	LastSeen     time.Time `objectbox:"index"`
+	LastSourceIP string
+	Labels       string // Store as JSON string
+	Metadata     string // Store as JSON string
+	// HeartbeatSeconds is the expected maximum gap between activities; 0
+	// falls back to the grid's interval and then the configured default.
+	HeartbeatSeconds int64
+	// Online is maintained by the heartbeat checker; StatusChangedAt is the
+	// time of the last online/offline transition.
+	Online          bool `objectbox:"index"`
+	StatusChangedAt time.Time
+}
+
+// Helper methods for labels and metadata
+func (d *Device) SetLabels(labels map[string]string) error {
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+	d.Labels = string(data)
+	return nil
+}
+
+func (d *Device) GetLabels() (map[string]string, error) {
+	labels := map[string]string{}
+	if d.Labels == "" {
+		return labels, nil
+	}
+	if err := json.Unmarshal([]byte(d.Labels), &labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+func (d *Device) SetMetadata(metadata map[string]interface{}) error {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	d.Metadata = string(data)
+	return nil
+}
+
+func (d *Device) GetMetadata() (map[string]interface{}, error) {
+	metadata := map[string]interface{}{}
+	if d.Metadata == "" {
+		return metadata, nil
+	}
+	if err := json.Unmarshal([]byte(d.Metadata), &metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
@@ -0,0 +1,483 @@
+// Code generated by ObjectBox; DO NOT EDIT.
+// Learn more about defining entities and generating this file - visit https://golang.objectbox.io/entity-annotations
+
+package models
+
+import (
+	"errors"
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+)
+
+type device_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var DeviceBinding = device_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 2,
+	},
+	Uid: 8251133966987525617,
+}
+
+// Device_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var Device_ = struct {
+	Id               *objectbox.PropertyUint64
+	Name             *objectbox.PropertyString
+	GridName         *objectbox.PropertyString
+	FirstSeen        *objectbox.PropertyInt64
+	LastSeen         *objectbox.PropertyInt64
+	LastSourceIP     *objectbox.PropertyString
+	Labels           *objectbox.PropertyString
+	Metadata         *objectbox.PropertyString
+	HeartbeatSeconds *objectbox.PropertyInt64
+	Online           *objectbox.PropertyBool
+	StatusChangedAt  *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &DeviceBinding.Entity,
+		},
+	},
+	Name: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &DeviceBinding.Entity,
+		},
+	},
+	GridName: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &DeviceBinding.Entity,
+		},
+	},
+	FirstSeen: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &DeviceBinding.Entity,
+		},
+	},
+	LastSeen: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &DeviceBinding.Entity,
+		},
+	},
+	LastSourceIP: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &DeviceBinding.Entity,
+		},
+	},
+	Labels: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     7,
+			Entity: &DeviceBinding.Entity,
+		},
+	},
+	Metadata: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     8,
+			Entity: &DeviceBinding.Entity,
+		},
+	},
+	HeartbeatSeconds: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     9,
+			Entity: &DeviceBinding.Entity,
+		},
+	},
+	Online: &objectbox.PropertyBool{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     10,
+			Entity: &DeviceBinding.Entity,
+		},
+	},
+	StatusChangedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     11,
+			Entity: &DeviceBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (device_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (device_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("Device", 2, 8251133966987525617)
+	model.Property("Id", 6, 1, 582420713294848242)
+	model.PropertyFlags(1)
+	model.Property("Name", 9, 2, 7367462756672610582)
+	model.PropertyFlags(2080)
+	model.PropertyIndex(7, 2509966178164588566)
+	model.Property("GridName", 9, 3, 3795059212816539629)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(8, 468484396855164281)
+	model.Property("FirstSeen", 10, 4, 1718068624215935588)
+	model.Property("LastSeen", 10, 5, 1310088967344186812)
+	model.PropertyFlags(8)
+	model.PropertyIndex(9, 5180810604294908354)
+	model.Property("LastSourceIP", 9, 6, 172625206477569670)
+	model.Property("Labels", 9, 7, 8442540250096071385)
+	model.Property("Metadata", 9, 8, 2645863164180224289)
+	model.Property("HeartbeatSeconds", 6, 9, 7523396089368770758)
+	model.Property("Online", 1, 10, 3217953184801102157)
+	model.PropertyFlags(8)
+	model.PropertyIndex(12, 9021593576162934535)
+	model.Property("StatusChangedAt", 10, 11, 77181104586841752)
+	model.EntityLastPropertyId(11, 77181104586841752)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (device_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*Device).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (device_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*Device).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (device_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (device_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*Device)
+	var propFirstSeen int64
+	{
+		var err error
+		propFirstSeen, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.FirstSeen)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Device.FirstSeen: " + err.Error())
+		}
+	}
+
+	var propLastSeen int64
+	{
+		var err error
+		propLastSeen, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.LastSeen)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Device.LastSeen: " + err.Error())
+		}
+	}
+
+	var propStatusChangedAt int64
+	{
+		var err error
+		propStatusChangedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.StatusChangedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Device.StatusChangedAt: " + err.Error())
+		}
+	}
+
+	var offsetName = fbutils.CreateStringOffset(fbb, obj.Name)
+	var offsetGridName = fbutils.CreateStringOffset(fbb, obj.GridName)
+	var offsetLastSourceIP = fbutils.CreateStringOffset(fbb, obj.LastSourceIP)
+	var offsetLabels = fbutils.CreateStringOffset(fbb, obj.Labels)
+	var offsetMetadata = fbutils.CreateStringOffset(fbb, obj.Metadata)
+
+	// build the FlatBuffers object
+	fbb.StartObject(11)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetName)
+	fbutils.SetUOffsetTSlot(fbb, 2, offsetGridName)
+	fbutils.SetInt64Slot(fbb, 3, propFirstSeen)
+	fbutils.SetInt64Slot(fbb, 4, propLastSeen)
+	fbutils.SetUOffsetTSlot(fbb, 5, offsetLastSourceIP)
+	fbutils.SetUOffsetTSlot(fbb, 6, offsetLabels)
+	fbutils.SetUOffsetTSlot(fbb, 7, offsetMetadata)
+	fbutils.SetInt64Slot(fbb, 8, obj.HeartbeatSeconds)
+	fbutils.SetBoolSlot(fbb, 9, obj.Online)
+	fbutils.SetInt64Slot(fbb, 10, propStatusChangedAt)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (device_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'Device' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propFirstSeen, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 10))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Device.FirstSeen: " + err.Error())
+	}
+
+	propLastSeen, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 12))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Device.LastSeen: " + err.Error())
+	}
+
+	propStatusChangedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 24))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Device.StatusChangedAt: " + err.Error())
+	}
+
+	return &Device{
+		Id:               propId,
+		Name:             fbutils.GetStringSlot(table, 6),
+		GridName:         fbutils.GetStringSlot(table, 8),
+		FirstSeen:        propFirstSeen,
+		LastSeen:         propLastSeen,
+		LastSourceIP:     fbutils.GetStringSlot(table, 14),
+		Labels:           fbutils.GetStringSlot(table, 16),
+		Metadata:         fbutils.GetStringSlot(table, 18),
+		HeartbeatSeconds: fbutils.GetInt64Slot(table, 20),
+		Online:           fbutils.GetBoolSlot(table, 22),
+		StatusChangedAt:  propStatusChangedAt,
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (device_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*Device, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (device_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*Device), nil)
+	}
+	return append(slice.([]*Device), object.(*Device))
+}
+
+// Box provides CRUD access to Device objects
+type DeviceBox struct {
+	*objectbox.Box
+}
+
+// BoxForDevice opens a box of Device objects
+func BoxForDevice(ob *objectbox.ObjectBox) *DeviceBox {
+	return &DeviceBox{
+		Box: ob.InternalBox(2),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the Device.Id property on the passed object will be assigned the new ID as well.
+func (box *DeviceBox) Put(object *Device) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the Device.Id property on the passed object will be assigned the new ID as well.
+func (box *DeviceBox) Insert(object *Device) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *DeviceBox) Update(object *Device) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *DeviceBox) PutAsync(object *Device) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the Device.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the Device.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *DeviceBox) PutMany(objects []*Device) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *DeviceBox) Get(id uint64) (*Device, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*Device), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *DeviceBox) GetMany(ids ...uint64) ([]*Device, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Device), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *DeviceBox) GetManyExisting(ids ...uint64) ([]*Device, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Device), nil
+}
+
+// GetAll reads all stored objects
+func (box *DeviceBox) GetAll() ([]*Device, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Device), nil
+}
+
+// Remove deletes a single object
+func (box *DeviceBox) Remove(object *Device) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *DeviceBox) RemoveMany(objects ...*Device) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the Device_ struct to create conditions.
+// Keep the *DeviceQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *DeviceBox) Query(conditions ...objectbox.Condition) *DeviceQuery {
+	return &DeviceQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the Device_ struct to create conditions.
+// Keep the *DeviceQuery if you intend to execute the query multiple times.
+func (box *DeviceBox) QueryOrError(conditions ...objectbox.Condition) (*DeviceQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &DeviceQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See DeviceAsyncBox for more information.
+func (box *DeviceBox) Async() *DeviceAsyncBox {
+	return &DeviceAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// DeviceAsyncBox provides asynchronous operations on Device objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type DeviceAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForDevice creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use DeviceBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForDevice(ob *objectbox.ObjectBox, timeoutMs uint64) *DeviceAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 2, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 2: %s" + err.Error())
+	}
+	return &DeviceAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *DeviceAsyncBox) Put(object *Device) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *DeviceAsyncBox) Insert(object *Device) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *DeviceAsyncBox) Update(object *Device) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *DeviceAsyncBox) Remove(object *Device) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all Device which Id is either 42 or 47:
+//
+// box.Query(Device_.Id.In(42, 47)).Find()
+type DeviceQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *DeviceQuery) Find() ([]*Device, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Device), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *DeviceQuery) Offset(offset uint64) *DeviceQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *DeviceQuery) Limit(limit uint64) *DeviceQuery {
+	query.Query.Limit(limit)
+	return query
+}
@@ -0,0 +1,18 @@
+package models
+
+//go:generate go run github.com/objectbox/objectbox-go/cmd/objectbox-gogen
+
+// Grid is a node in the grid hierarchy, e.g. region -> site -> grid.
+// Activities reference grids by Name through DeviceActivity.GridName. The
+// generator rejects a ToOne link from an entity to itself, so the parent is
+// kept as a plain indexed Id.
+type Grid struct {
+	Id          uint64 `objectbox:"id"`
+	Name        string `objectbox:"unique"`
+	Kind        string // Informational level such as region, site or grid
+	ParentId    uint64 `objectbox:"index"` // Id of the parent Grid, 0 for a root
+	Description string
+	// HeartbeatSeconds is the expected heartbeat interval of devices in this
+	// grid and its descendants, unless set on the device; 0 inherits it.
+	HeartbeatSeconds int64
+}
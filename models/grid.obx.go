@@ -0,0 +1,385 @@
+// Code generated by ObjectBox; DO NOT EDIT.
+// Learn more about defining entities and generating this file - visit https://golang.objectbox.io/entity-annotations
+
+package models
+
+import (
+	"errors"
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+)
+
+type grid_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var GridBinding = grid_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 3,
+	},
+	Uid: 7703368367219709317,
+}
+
+// Grid_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var Grid_ = struct {
+	Id               *objectbox.PropertyUint64
+	Name             *objectbox.PropertyString
+	Kind             *objectbox.PropertyString
+	ParentId         *objectbox.PropertyUint64
+	Description      *objectbox.PropertyString
+	HeartbeatSeconds *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &GridBinding.Entity,
+		},
+	},
+	Name: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &GridBinding.Entity,
+		},
+	},
+	Kind: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &GridBinding.Entity,
+		},
+	},
+	ParentId: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &GridBinding.Entity,
+		},
+	},
+	Description: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &GridBinding.Entity,
+		},
+	},
+	HeartbeatSeconds: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &GridBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (grid_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (grid_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("Grid", 3, 7703368367219709317)
+	model.Property("Id", 6, 1, 3233131452753986948)
+	model.PropertyFlags(1)
+	model.Property("Name", 9, 2, 4526075239713229047)
+	model.PropertyFlags(2080)
+	model.PropertyIndex(10, 1358110638451699189)
+	model.Property("Kind", 9, 3, 6273816808364572631)
+	model.Property("ParentId", 6, 4, 116055547852136272)
+	model.PropertyFlags(8200)
+	model.PropertyIndex(11, 4638521922514140175)
+	model.Property("Description", 9, 5, 5635306735661161034)
+	model.Property("HeartbeatSeconds", 6, 6, 2565547324760082335)
+	model.EntityLastPropertyId(6, 2565547324760082335)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (grid_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*Grid).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (grid_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*Grid).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (grid_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (grid_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*Grid)
+	var offsetName = fbutils.CreateStringOffset(fbb, obj.Name)
+	var offsetKind = fbutils.CreateStringOffset(fbb, obj.Kind)
+	var offsetDescription = fbutils.CreateStringOffset(fbb, obj.Description)
+
+	// build the FlatBuffers object
+	fbb.StartObject(6)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetName)
+	fbutils.SetUOffsetTSlot(fbb, 2, offsetKind)
+	fbutils.SetUint64Slot(fbb, 3, obj.ParentId)
+	fbutils.SetUOffsetTSlot(fbb, 4, offsetDescription)
+	fbutils.SetInt64Slot(fbb, 5, obj.HeartbeatSeconds)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (grid_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'Grid' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	return &Grid{
+		Id:               propId,
+		Name:             fbutils.GetStringSlot(table, 6),
+		Kind:             fbutils.GetStringSlot(table, 8),
+		ParentId:         fbutils.GetUint64Slot(table, 10),
+		Description:      fbutils.GetStringSlot(table, 12),
+		HeartbeatSeconds: fbutils.GetInt64Slot(table, 14),
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (grid_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*Grid, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (grid_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*Grid), nil)
+	}
+	return append(slice.([]*Grid), object.(*Grid))
+}
+
+// Box provides CRUD access to Grid objects
+type GridBox struct {
+	*objectbox.Box
+}
+
+// BoxForGrid opens a box of Grid objects
+func BoxForGrid(ob *objectbox.ObjectBox) *GridBox {
+	return &GridBox{
+		Box: ob.InternalBox(3),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the Grid.Id property on the passed object will be assigned the new ID as well.
+func (box *GridBox) Put(object *Grid) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the Grid.Id property on the passed object will be assigned the new ID as well.
+func (box *GridBox) Insert(object *Grid) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *GridBox) Update(object *Grid) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *GridBox) PutAsync(object *Grid) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the Grid.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the Grid.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *GridBox) PutMany(objects []*Grid) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *GridBox) Get(id uint64) (*Grid, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*Grid), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *GridBox) GetMany(ids ...uint64) ([]*Grid, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Grid), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *GridBox) GetManyExisting(ids ...uint64) ([]*Grid, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Grid), nil
+}
+
+// GetAll reads all stored objects
+func (box *GridBox) GetAll() ([]*Grid, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Grid), nil
+}
+
+// Remove deletes a single object
+func (box *GridBox) Remove(object *Grid) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *GridBox) RemoveMany(objects ...*Grid) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the Grid_ struct to create conditions.
+// Keep the *GridQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *GridBox) Query(conditions ...objectbox.Condition) *GridQuery {
+	return &GridQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the Grid_ struct to create conditions.
+// Keep the *GridQuery if you intend to execute the query multiple times.
+func (box *GridBox) QueryOrError(conditions ...objectbox.Condition) (*GridQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &GridQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See GridAsyncBox for more information.
+func (box *GridBox) Async() *GridAsyncBox {
+	return &GridAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// GridAsyncBox provides asynchronous operations on Grid objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type GridAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForGrid creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use GridBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForGrid(ob *objectbox.ObjectBox, timeoutMs uint64) *GridAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 3, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 3: %s" + err.Error())
+	}
+	return &GridAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *GridAsyncBox) Put(object *Grid) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *GridAsyncBox) Insert(object *Grid) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *GridAsyncBox) Update(object *Grid) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *GridAsyncBox) Remove(object *Grid) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all Grid which Id is either 42 or 47:
+//
+// box.Query(Grid_.Id.In(42, 47)).Find()
+type GridQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *GridQuery) Find() ([]*Grid, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Grid), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *GridQuery) Offset(offset uint64) *GridQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *GridQuery) Limit(limit uint64) *GridQuery {
+	query.Query.Limit(limit)
+	return query
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+//go:generate go run github.com/objectbox/objectbox-go/cmd/objectbox-gogen
+
+// IdempotencyKey remembers the response to a create request so that a retry
+// with the same key gets the same response instead of creating a duplicate.
+type IdempotencyKey struct {
+	Id  uint64 `objectbox:"id"`
+	Key string `objectbox:"unique"`
+	// RequestHash is a hash of the request body; reusing a key for a
+	// different request is rejected.
+	RequestHash string
+	StatusCode  int
+	Response    string // Store as JSON string
+	ActivityId  uint64
+	CreatedAt   time.Time
+	ExpiresAt   time.Time `objectbox:"index"`
+}
@@ -0,0 +1,434 @@
+// Code generated by ObjectBox; DO NOT EDIT.
+// Learn more about defining entities and generating this file - visit https://golang.objectbox.io/entity-annotations
+
+package models
+
+import (
+	"errors"
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+)
+
+type idempotencyKey_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var IdempotencyKeyBinding = idempotencyKey_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 6,
+	},
+	Uid: 7868023213206689140,
+}
+
+// IdempotencyKey_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var IdempotencyKey_ = struct {
+	Id          *objectbox.PropertyUint64
+	Key         *objectbox.PropertyString
+	RequestHash *objectbox.PropertyString
+	StatusCode  *objectbox.PropertyInt
+	Response    *objectbox.PropertyString
+	ActivityId  *objectbox.PropertyUint64
+	CreatedAt   *objectbox.PropertyInt64
+	ExpiresAt   *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &IdempotencyKeyBinding.Entity,
+		},
+	},
+	Key: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &IdempotencyKeyBinding.Entity,
+		},
+	},
+	RequestHash: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &IdempotencyKeyBinding.Entity,
+		},
+	},
+	StatusCode: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &IdempotencyKeyBinding.Entity,
+		},
+	},
+	Response: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &IdempotencyKeyBinding.Entity,
+		},
+	},
+	ActivityId: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &IdempotencyKeyBinding.Entity,
+		},
+	},
+	CreatedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     7,
+			Entity: &IdempotencyKeyBinding.Entity,
+		},
+	},
+	ExpiresAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     8,
+			Entity: &IdempotencyKeyBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (idempotencyKey_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (idempotencyKey_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("IdempotencyKey", 6, 7868023213206689140)
+	model.Property("Id", 6, 1, 5649809462101993568)
+	model.PropertyFlags(1)
+	model.Property("Key", 9, 2, 7887452125833811916)
+	model.PropertyFlags(2080)
+	model.PropertyIndex(19, 777633654290957733)
+	model.Property("RequestHash", 9, 3, 8779578701581790151)
+	model.Property("StatusCode", 6, 4, 5181269370496137082)
+	model.Property("Response", 9, 5, 8296732676294881969)
+	model.Property("ActivityId", 6, 6, 712329498645921353)
+	model.PropertyFlags(8192)
+	model.Property("CreatedAt", 10, 7, 2778208912314636506)
+	model.Property("ExpiresAt", 10, 8, 2071183310609854782)
+	model.PropertyFlags(8)
+	model.PropertyIndex(20, 3938904769548505357)
+	model.EntityLastPropertyId(8, 2071183310609854782)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (idempotencyKey_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*IdempotencyKey).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (idempotencyKey_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*IdempotencyKey).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (idempotencyKey_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (idempotencyKey_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*IdempotencyKey)
+	var propCreatedAt int64
+	{
+		var err error
+		propCreatedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.CreatedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on IdempotencyKey.CreatedAt: " + err.Error())
+		}
+	}
+
+	var propExpiresAt int64
+	{
+		var err error
+		propExpiresAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.ExpiresAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on IdempotencyKey.ExpiresAt: " + err.Error())
+		}
+	}
+
+	var offsetKey = fbutils.CreateStringOffset(fbb, obj.Key)
+	var offsetRequestHash = fbutils.CreateStringOffset(fbb, obj.RequestHash)
+	var offsetResponse = fbutils.CreateStringOffset(fbb, obj.Response)
+
+	// build the FlatBuffers object
+	fbb.StartObject(8)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetKey)
+	fbutils.SetUOffsetTSlot(fbb, 2, offsetRequestHash)
+	fbutils.SetInt64Slot(fbb, 3, int64(obj.StatusCode))
+	fbutils.SetUOffsetTSlot(fbb, 4, offsetResponse)
+	fbutils.SetUint64Slot(fbb, 5, obj.ActivityId)
+	fbutils.SetInt64Slot(fbb, 6, propCreatedAt)
+	fbutils.SetInt64Slot(fbb, 7, propExpiresAt)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (idempotencyKey_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'IdempotencyKey' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propCreatedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 16))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on IdempotencyKey.CreatedAt: " + err.Error())
+	}
+
+	propExpiresAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 18))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on IdempotencyKey.ExpiresAt: " + err.Error())
+	}
+
+	return &IdempotencyKey{
+		Id:          propId,
+		Key:         fbutils.GetStringSlot(table, 6),
+		RequestHash: fbutils.GetStringSlot(table, 8),
+		StatusCode:  fbutils.GetIntSlot(table, 10),
+		Response:    fbutils.GetStringSlot(table, 12),
+		ActivityId:  fbutils.GetUint64Slot(table, 14),
+		CreatedAt:   propCreatedAt,
+		ExpiresAt:   propExpiresAt,
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (idempotencyKey_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*IdempotencyKey, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (idempotencyKey_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*IdempotencyKey), nil)
+	}
+	return append(slice.([]*IdempotencyKey), object.(*IdempotencyKey))
+}
+
+// Box provides CRUD access to IdempotencyKey objects
+type IdempotencyKeyBox struct {
+	*objectbox.Box
+}
+
+// BoxForIdempotencyKey opens a box of IdempotencyKey objects
+func BoxForIdempotencyKey(ob *objectbox.ObjectBox) *IdempotencyKeyBox {
+	return &IdempotencyKeyBox{
+		Box: ob.InternalBox(6),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the IdempotencyKey.Id property on the passed object will be assigned the new ID as well.
+func (box *IdempotencyKeyBox) Put(object *IdempotencyKey) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the IdempotencyKey.Id property on the passed object will be assigned the new ID as well.
+func (box *IdempotencyKeyBox) Insert(object *IdempotencyKey) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *IdempotencyKeyBox) Update(object *IdempotencyKey) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *IdempotencyKeyBox) PutAsync(object *IdempotencyKey) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the IdempotencyKey.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the IdempotencyKey.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *IdempotencyKeyBox) PutMany(objects []*IdempotencyKey) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *IdempotencyKeyBox) Get(id uint64) (*IdempotencyKey, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*IdempotencyKey), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *IdempotencyKeyBox) GetMany(ids ...uint64) ([]*IdempotencyKey, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*IdempotencyKey), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *IdempotencyKeyBox) GetManyExisting(ids ...uint64) ([]*IdempotencyKey, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*IdempotencyKey), nil
+}
+
+// GetAll reads all stored objects
+func (box *IdempotencyKeyBox) GetAll() ([]*IdempotencyKey, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*IdempotencyKey), nil
+}
+
+// Remove deletes a single object
+func (box *IdempotencyKeyBox) Remove(object *IdempotencyKey) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *IdempotencyKeyBox) RemoveMany(objects ...*IdempotencyKey) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the IdempotencyKey_ struct to create conditions.
+// Keep the *IdempotencyKeyQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *IdempotencyKeyBox) Query(conditions ...objectbox.Condition) *IdempotencyKeyQuery {
+	return &IdempotencyKeyQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the IdempotencyKey_ struct to create conditions.
+// Keep the *IdempotencyKeyQuery if you intend to execute the query multiple times.
+func (box *IdempotencyKeyBox) QueryOrError(conditions ...objectbox.Condition) (*IdempotencyKeyQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &IdempotencyKeyQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See IdempotencyKeyAsyncBox for more information.
+func (box *IdempotencyKeyBox) Async() *IdempotencyKeyAsyncBox {
+	return &IdempotencyKeyAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// IdempotencyKeyAsyncBox provides asynchronous operations on IdempotencyKey objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type IdempotencyKeyAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForIdempotencyKey creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use IdempotencyKeyBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForIdempotencyKey(ob *objectbox.ObjectBox, timeoutMs uint64) *IdempotencyKeyAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 6, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 6: %s" + err.Error())
+	}
+	return &IdempotencyKeyAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *IdempotencyKeyAsyncBox) Put(object *IdempotencyKey) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *IdempotencyKeyAsyncBox) Insert(object *IdempotencyKey) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *IdempotencyKeyAsyncBox) Update(object *IdempotencyKey) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *IdempotencyKeyAsyncBox) Remove(object *IdempotencyKey) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all IdempotencyKey which Id is either 42 or 47:
+//
+// box.Query(IdempotencyKey_.Id.In(42, 47)).Find()
+type IdempotencyKeyQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *IdempotencyKeyQuery) Find() ([]*IdempotencyKey, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*IdempotencyKey), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *IdempotencyKeyQuery) Offset(offset uint64) *IdempotencyKeyQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *IdempotencyKeyQuery) Limit(limit uint64) *IdempotencyKeyQuery {
+	query.Query.Limit(limit)
+	return query
+}
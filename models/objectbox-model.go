@@ -13,8 +13,23 @@ func ObjectBoxModel() *objectbox.Model {
 	model.GeneratorVersion(6)
 
 	model.RegisterBinding(DeviceActivityBinding)
-	model.LastEntityId(1, 2906110396233178886)
-	model.LastIndexId(3, 5847086806180283837)
+	model.RegisterBinding(DeviceBinding)
+	model.RegisterBinding(GridBinding)
+	model.RegisterBinding(AlertRuleBinding)
+	model.RegisterBinding(AlertBinding)
+	model.RegisterBinding(IdempotencyKeyBinding)
+	model.RegisterBinding(SessionBinding)
+	model.RegisterBinding(SessionCheckpointBinding)
+	model.RegisterBinding(ActivityChangeBinding)
+	model.RegisterBinding(ChangeCheckpointBinding)
+	model.RegisterBinding(WebhookSubscriptionBinding)
+	model.RegisterBinding(WebhookDeliveryBinding)
+	model.RegisterBinding(WebhookCheckpointBinding)
+	model.RegisterBinding(UsageStatsBinding)
+	model.RegisterBinding(StatsRollupBinding)
+	model.RegisterBinding(SLOBinding)
+	model.LastEntityId(16, 8817471951654328835)
+	model.LastIndexId(44, 4601677247063643125)
 
 	return model
 }
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+//go:generate go run github.com/objectbox/objectbox-go/cmd/objectbox-gogen
+
+// Session statuses
+const (
+	SessionOpen     = "open"
+	SessionClosed   = "closed"    // ended by the pair's end action
+	SessionTimedOut = "timed_out" // no activity within the idle timeout
+	// SessionSuperseded is a session that was never closed before the device
+	// started a new one of the same kind.
+	SessionSuperseded = "superseded"
+)
+
+// Session is a period of device activity derived from a start action such as
+// login and the matching end action such as logout.
+type Session struct {
+	Id         uint64 `objectbox:"id"`
+	DeviceName string `objectbox:"index"`
+	GridName   string `objectbox:"index"`
+	// Kind is the start action of the action pair that opened the session.
+	Kind      string
+	Status    string    `objectbox:"index"`
+	StartedAt time.Time `objectbox:"index"`
+	// EndedAt is the end action's time, or the last activity's time for
+	// sessions that timed out or were superseded. It is unset while open.
+	EndedAt         time.Time `objectbox:"index"`
+	LastActivityAt  time.Time
+	StartActivityId uint64
+	EndActivityId   uint64
+	ActivityCount   int64
+}
+
+// Duration returns how long the session lasted, or has lasted until now if it is still open.
+func (s *Session) Duration(now time.Time) time.Duration {
+	if s.Status == SessionOpen {
+		return now.Sub(s.StartedAt)
+	}
+	return s.EndedAt.Sub(s.StartedAt)
+}
+
+// SessionCheckpoint records the last activity that was folded into sessions,
+// so that sessions are built incrementally. Activities are folded in
+// Timestamp order, then Id order; LastTimestamp and LastActivityId are those
+// of the last one. There is a single row.
+type SessionCheckpoint struct {
+	Id             uint64 `objectbox:"id"`
+	LastActivityId uint64
+	LastTimestamp  time.Time
+	UpdatedAt      time.Time
+}
@@ -0,0 +1,861 @@
+// Code generated by ObjectBox; DO NOT EDIT.
+// Learn more about defining entities and generating this file - visit https://golang.objectbox.io/entity-annotations
+
+package models
+
+import (
+	"errors"
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+)
+
+type session_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var SessionBinding = session_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 7,
+	},
+	Uid: 3581963452237239196,
+}
+
+// Session_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var Session_ = struct {
+	Id              *objectbox.PropertyUint64
+	DeviceName      *objectbox.PropertyString
+	GridName        *objectbox.PropertyString
+	Kind            *objectbox.PropertyString
+	Status          *objectbox.PropertyString
+	StartedAt       *objectbox.PropertyInt64
+	EndedAt         *objectbox.PropertyInt64
+	LastActivityAt  *objectbox.PropertyInt64
+	StartActivityId *objectbox.PropertyUint64
+	EndActivityId   *objectbox.PropertyUint64
+	ActivityCount   *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &SessionBinding.Entity,
+		},
+	},
+	DeviceName: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &SessionBinding.Entity,
+		},
+	},
+	GridName: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &SessionBinding.Entity,
+		},
+	},
+	Kind: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &SessionBinding.Entity,
+		},
+	},
+	Status: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &SessionBinding.Entity,
+		},
+	},
+	StartedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &SessionBinding.Entity,
+		},
+	},
+	EndedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     7,
+			Entity: &SessionBinding.Entity,
+		},
+	},
+	LastActivityAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     8,
+			Entity: &SessionBinding.Entity,
+		},
+	},
+	StartActivityId: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     9,
+			Entity: &SessionBinding.Entity,
+		},
+	},
+	EndActivityId: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     10,
+			Entity: &SessionBinding.Entity,
+		},
+	},
+	ActivityCount: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     11,
+			Entity: &SessionBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (session_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (session_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("Session", 7, 3581963452237239196)
+	model.Property("Id", 6, 1, 3005841485532096087)
+	model.PropertyFlags(1)
+	model.Property("DeviceName", 9, 2, 1890265563884803037)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(22, 6372913162000280760)
+	model.Property("GridName", 9, 3, 1525924898755416973)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(23, 5701120208824260590)
+	model.Property("Kind", 9, 4, 7867951508053797005)
+	model.Property("Status", 9, 5, 5118034514458453571)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(24, 2475084209442955190)
+	model.Property("StartedAt", 10, 6, 2432660689010047206)
+	model.PropertyFlags(8)
+	model.PropertyIndex(25, 4464606254045819678)
+	model.Property("EndedAt", 10, 7, 5672443137185510137)
+	model.PropertyFlags(8)
+	model.PropertyIndex(26, 4252011960016360148)
+	model.Property("LastActivityAt", 10, 8, 4282057038395832260)
+	model.Property("StartActivityId", 6, 9, 522284365773973555)
+	model.PropertyFlags(8192)
+	model.Property("EndActivityId", 6, 10, 7240014731122623945)
+	model.PropertyFlags(8192)
+	model.Property("ActivityCount", 6, 11, 8654340756291074451)
+	model.EntityLastPropertyId(11, 8654340756291074451)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (session_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*Session).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (session_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*Session).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (session_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (session_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*Session)
+	var propStartedAt int64
+	{
+		var err error
+		propStartedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.StartedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Session.StartedAt: " + err.Error())
+		}
+	}
+
+	var propEndedAt int64
+	{
+		var err error
+		propEndedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.EndedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Session.EndedAt: " + err.Error())
+		}
+	}
+
+	var propLastActivityAt int64
+	{
+		var err error
+		propLastActivityAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.LastActivityAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on Session.LastActivityAt: " + err.Error())
+		}
+	}
+
+	var offsetDeviceName = fbutils.CreateStringOffset(fbb, obj.DeviceName)
+	var offsetGridName = fbutils.CreateStringOffset(fbb, obj.GridName)
+	var offsetKind = fbutils.CreateStringOffset(fbb, obj.Kind)
+	var offsetStatus = fbutils.CreateStringOffset(fbb, obj.Status)
+
+	// build the FlatBuffers object
+	fbb.StartObject(11)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetDeviceName)
+	fbutils.SetUOffsetTSlot(fbb, 2, offsetGridName)
+	fbutils.SetUOffsetTSlot(fbb, 3, offsetKind)
+	fbutils.SetUOffsetTSlot(fbb, 4, offsetStatus)
+	fbutils.SetInt64Slot(fbb, 5, propStartedAt)
+	fbutils.SetInt64Slot(fbb, 6, propEndedAt)
+	fbutils.SetInt64Slot(fbb, 7, propLastActivityAt)
+	fbutils.SetUint64Slot(fbb, 8, obj.StartActivityId)
+	fbutils.SetUint64Slot(fbb, 9, obj.EndActivityId)
+	fbutils.SetInt64Slot(fbb, 10, obj.ActivityCount)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (session_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'Session' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propStartedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 14))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Session.StartedAt: " + err.Error())
+	}
+
+	propEndedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 16))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Session.EndedAt: " + err.Error())
+	}
+
+	propLastActivityAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 18))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on Session.LastActivityAt: " + err.Error())
+	}
+
+	return &Session{
+		Id:              propId,
+		DeviceName:      fbutils.GetStringSlot(table, 6),
+		GridName:        fbutils.GetStringSlot(table, 8),
+		Kind:            fbutils.GetStringSlot(table, 10),
+		Status:          fbutils.GetStringSlot(table, 12),
+		StartedAt:       propStartedAt,
+		EndedAt:         propEndedAt,
+		LastActivityAt:  propLastActivityAt,
+		StartActivityId: fbutils.GetUint64Slot(table, 20),
+		EndActivityId:   fbutils.GetUint64Slot(table, 22),
+		ActivityCount:   fbutils.GetInt64Slot(table, 24),
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (session_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*Session, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (session_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*Session), nil)
+	}
+	return append(slice.([]*Session), object.(*Session))
+}
+
+// Box provides CRUD access to Session objects
+type SessionBox struct {
+	*objectbox.Box
+}
+
+// BoxForSession opens a box of Session objects
+func BoxForSession(ob *objectbox.ObjectBox) *SessionBox {
+	return &SessionBox{
+		Box: ob.InternalBox(7),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the Session.Id property on the passed object will be assigned the new ID as well.
+func (box *SessionBox) Put(object *Session) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the Session.Id property on the passed object will be assigned the new ID as well.
+func (box *SessionBox) Insert(object *Session) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *SessionBox) Update(object *Session) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *SessionBox) PutAsync(object *Session) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the Session.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the Session.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *SessionBox) PutMany(objects []*Session) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *SessionBox) Get(id uint64) (*Session, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*Session), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *SessionBox) GetMany(ids ...uint64) ([]*Session, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Session), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *SessionBox) GetManyExisting(ids ...uint64) ([]*Session, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Session), nil
+}
+
+// GetAll reads all stored objects
+func (box *SessionBox) GetAll() ([]*Session, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Session), nil
+}
+
+// Remove deletes a single object
+func (box *SessionBox) Remove(object *Session) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *SessionBox) RemoveMany(objects ...*Session) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the Session_ struct to create conditions.
+// Keep the *SessionQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *SessionBox) Query(conditions ...objectbox.Condition) *SessionQuery {
+	return &SessionQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the Session_ struct to create conditions.
+// Keep the *SessionQuery if you intend to execute the query multiple times.
+func (box *SessionBox) QueryOrError(conditions ...objectbox.Condition) (*SessionQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &SessionQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See SessionAsyncBox for more information.
+func (box *SessionBox) Async() *SessionAsyncBox {
+	return &SessionAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// SessionAsyncBox provides asynchronous operations on Session objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type SessionAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForSession creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use SessionBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForSession(ob *objectbox.ObjectBox, timeoutMs uint64) *SessionAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 7, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 7: %s" + err.Error())
+	}
+	return &SessionAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *SessionAsyncBox) Put(object *Session) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *SessionAsyncBox) Insert(object *Session) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *SessionAsyncBox) Update(object *Session) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *SessionAsyncBox) Remove(object *Session) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all Session which Id is either 42 or 47:
+//
+// box.Query(Session_.Id.In(42, 47)).Find()
+type SessionQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *SessionQuery) Find() ([]*Session, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*Session), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *SessionQuery) Offset(offset uint64) *SessionQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *SessionQuery) Limit(limit uint64) *SessionQuery {
+	query.Query.Limit(limit)
+	return query
+}
+
+type sessionCheckpoint_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var SessionCheckpointBinding = sessionCheckpoint_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 8,
+	},
+	Uid: 5703200808221344010,
+}
+
+// SessionCheckpoint_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var SessionCheckpoint_ = struct {
+	Id             *objectbox.PropertyUint64
+	LastActivityId *objectbox.PropertyUint64
+	UpdatedAt      *objectbox.PropertyInt64
+	LastTimestamp  *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &SessionCheckpointBinding.Entity,
+		},
+	},
+	LastActivityId: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &SessionCheckpointBinding.Entity,
+		},
+	},
+	UpdatedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &SessionCheckpointBinding.Entity,
+		},
+	},
+	LastTimestamp: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &SessionCheckpointBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (sessionCheckpoint_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (sessionCheckpoint_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("SessionCheckpoint", 8, 5703200808221344010)
+	model.Property("Id", 6, 1, 2862002221821139151)
+	model.PropertyFlags(1)
+	model.Property("LastActivityId", 6, 2, 5110436265621159832)
+	model.PropertyFlags(8192)
+	model.Property("UpdatedAt", 10, 3, 8009466223688076729)
+	model.Property("LastTimestamp", 10, 4, 8792860921065979393)
+	model.EntityLastPropertyId(4, 8792860921065979393)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (sessionCheckpoint_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*SessionCheckpoint).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (sessionCheckpoint_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*SessionCheckpoint).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (sessionCheckpoint_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (sessionCheckpoint_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*SessionCheckpoint)
+	var propUpdatedAt int64
+	{
+		var err error
+		propUpdatedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.UpdatedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on SessionCheckpoint.UpdatedAt: " + err.Error())
+		}
+	}
+
+	var propLastTimestamp int64
+	{
+		var err error
+		propLastTimestamp, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.LastTimestamp)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on SessionCheckpoint.LastTimestamp: " + err.Error())
+		}
+	}
+
+	// build the FlatBuffers object
+	fbb.StartObject(4)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUint64Slot(fbb, 1, obj.LastActivityId)
+	fbutils.SetInt64Slot(fbb, 3, propLastTimestamp)
+	fbutils.SetInt64Slot(fbb, 2, propUpdatedAt)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (sessionCheckpoint_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'SessionCheckpoint' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propUpdatedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 8))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on SessionCheckpoint.UpdatedAt: " + err.Error())
+	}
+
+	propLastTimestamp, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 10))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on SessionCheckpoint.LastTimestamp: " + err.Error())
+	}
+
+	return &SessionCheckpoint{
+		Id:             propId,
+		LastActivityId: fbutils.GetUint64Slot(table, 6),
+		LastTimestamp:  propLastTimestamp,
+		UpdatedAt:      propUpdatedAt,
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (sessionCheckpoint_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*SessionCheckpoint, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (sessionCheckpoint_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*SessionCheckpoint), nil)
+	}
+	return append(slice.([]*SessionCheckpoint), object.(*SessionCheckpoint))
+}
+
+// Box provides CRUD access to SessionCheckpoint objects
+type SessionCheckpointBox struct {
+	*objectbox.Box
+}
+
+// BoxForSessionCheckpoint opens a box of SessionCheckpoint objects
+func BoxForSessionCheckpoint(ob *objectbox.ObjectBox) *SessionCheckpointBox {
+	return &SessionCheckpointBox{
+		Box: ob.InternalBox(8),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the SessionCheckpoint.Id property on the passed object will be assigned the new ID as well.
+func (box *SessionCheckpointBox) Put(object *SessionCheckpoint) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the SessionCheckpoint.Id property on the passed object will be assigned the new ID as well.
+func (box *SessionCheckpointBox) Insert(object *SessionCheckpoint) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *SessionCheckpointBox) Update(object *SessionCheckpoint) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *SessionCheckpointBox) PutAsync(object *SessionCheckpoint) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the SessionCheckpoint.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the SessionCheckpoint.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *SessionCheckpointBox) PutMany(objects []*SessionCheckpoint) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *SessionCheckpointBox) Get(id uint64) (*SessionCheckpoint, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*SessionCheckpoint), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *SessionCheckpointBox) GetMany(ids ...uint64) ([]*SessionCheckpoint, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*SessionCheckpoint), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *SessionCheckpointBox) GetManyExisting(ids ...uint64) ([]*SessionCheckpoint, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*SessionCheckpoint), nil
+}
+
+// GetAll reads all stored objects
+func (box *SessionCheckpointBox) GetAll() ([]*SessionCheckpoint, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*SessionCheckpoint), nil
+}
+
+// Remove deletes a single object
+func (box *SessionCheckpointBox) Remove(object *SessionCheckpoint) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *SessionCheckpointBox) RemoveMany(objects ...*SessionCheckpoint) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the SessionCheckpoint_ struct to create conditions.
+// Keep the *SessionCheckpointQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *SessionCheckpointBox) Query(conditions ...objectbox.Condition) *SessionCheckpointQuery {
+	return &SessionCheckpointQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the SessionCheckpoint_ struct to create conditions.
+// Keep the *SessionCheckpointQuery if you intend to execute the query multiple times.
+func (box *SessionCheckpointBox) QueryOrError(conditions ...objectbox.Condition) (*SessionCheckpointQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &SessionCheckpointQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See SessionCheckpointAsyncBox for more information.
+func (box *SessionCheckpointBox) Async() *SessionCheckpointAsyncBox {
+	return &SessionCheckpointAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// SessionCheckpointAsyncBox provides asynchronous operations on SessionCheckpoint objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type SessionCheckpointAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForSessionCheckpoint creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use SessionCheckpointBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForSessionCheckpoint(ob *objectbox.ObjectBox, timeoutMs uint64) *SessionCheckpointAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 8, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 8: %s" + err.Error())
+	}
+	return &SessionCheckpointAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *SessionCheckpointAsyncBox) Put(object *SessionCheckpoint) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *SessionCheckpointAsyncBox) Insert(object *SessionCheckpoint) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *SessionCheckpointAsyncBox) Update(object *SessionCheckpoint) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *SessionCheckpointAsyncBox) Remove(object *SessionCheckpoint) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all SessionCheckpoint which Id is either 42 or 47:
+//
+// box.Query(SessionCheckpoint_.Id.In(42, 47)).Find()
+type SessionCheckpointQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *SessionCheckpointQuery) Find() ([]*SessionCheckpoint, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*SessionCheckpoint), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *SessionCheckpointQuery) Offset(offset uint64) *SessionCheckpointQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *SessionCheckpointQuery) Limit(limit uint64) *SessionCheckpointQuery {
+	query.Query.Limit(limit)
+	return query
+}
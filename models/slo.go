@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+//go:generate go run github.com/objectbox/objectbox-go/cmd/objectbox-gogen
+
+// SLO objectives
+const (
+	SLOAvailability = "availability" // 5xx responses are bad
+	SLOLatency      = "latency"      // responses slower than LatencyThresholdMs are bad
+)
+
+// SLO is a service level objective for one endpoint, and optionally one
+// method: at least Target of its requests within the last WindowDays are good.
+type SLO struct {
+	Id          uint64 `json:"-" objectbox:"id"`
+	Name        string `json:"name" objectbox:"unique"`
+	Description string `json:"description"`
+	Endpoint    string `json:"endpoint" objectbox:"index"`
+	// Method is empty for every method of the endpoint.
+	Method    string  `json:"method"`
+	Objective string  `json:"objective"`
+	Target    float64 `json:"target"` // e.g. 0.999
+	// LatencyThresholdMs is the slowest good response of a latency SLO.
+	LatencyThresholdMs float64   `json:"latency_threshold_ms,omitempty"`
+	WindowDays         int       `json:"window_days"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
@@ -0,0 +1,465 @@
+// Code generated by ObjectBox; DO NOT EDIT.
+// Learn more about defining entities and generating this file - visit https://golang.objectbox.io/entity-annotations
+
+package models
+
+import (
+	"errors"
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+)
+
+type sLO_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var SLOBinding = sLO_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 16,
+	},
+	Uid: 8817471951654328835,
+}
+
+// SLO_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var SLO_ = struct {
+	Id                 *objectbox.PropertyUint64
+	Name               *objectbox.PropertyString
+	Description        *objectbox.PropertyString
+	Endpoint           *objectbox.PropertyString
+	Method             *objectbox.PropertyString
+	Objective          *objectbox.PropertyString
+	Target             *objectbox.PropertyFloat64
+	LatencyThresholdMs *objectbox.PropertyFloat64
+	WindowDays         *objectbox.PropertyInt
+	CreatedAt          *objectbox.PropertyInt64
+	UpdatedAt          *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &SLOBinding.Entity,
+		},
+	},
+	Name: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &SLOBinding.Entity,
+		},
+	},
+	Description: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &SLOBinding.Entity,
+		},
+	},
+	Endpoint: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &SLOBinding.Entity,
+		},
+	},
+	Method: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &SLOBinding.Entity,
+		},
+	},
+	Objective: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &SLOBinding.Entity,
+		},
+	},
+	Target: &objectbox.PropertyFloat64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     7,
+			Entity: &SLOBinding.Entity,
+		},
+	},
+	LatencyThresholdMs: &objectbox.PropertyFloat64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     8,
+			Entity: &SLOBinding.Entity,
+		},
+	},
+	WindowDays: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     9,
+			Entity: &SLOBinding.Entity,
+		},
+	},
+	CreatedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     10,
+			Entity: &SLOBinding.Entity,
+		},
+	},
+	UpdatedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     11,
+			Entity: &SLOBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (sLO_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (sLO_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("SLO", 16, 8817471951654328835)
+	model.Property("Id", 6, 1, 3139600228886002382)
+	model.PropertyFlags(1)
+	model.Property("Name", 9, 2, 6619107375221111111)
+	model.PropertyFlags(2080)
+	model.PropertyIndex(43, 3297499871327697389)
+	model.Property("Description", 9, 3, 4138207529220604478)
+	model.Property("Endpoint", 9, 4, 2028799766255183254)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(44, 4601677247063643125)
+	model.Property("Method", 9, 5, 7154435220754380116)
+	model.Property("Objective", 9, 6, 6979587016982383330)
+	model.Property("Target", 8, 7, 7013499739471807656)
+	model.Property("LatencyThresholdMs", 8, 8, 8052503721509747123)
+	model.Property("WindowDays", 6, 9, 186556594193543255)
+	model.Property("CreatedAt", 10, 10, 8946876565264663402)
+	model.Property("UpdatedAt", 10, 11, 1603484342339579092)
+	model.EntityLastPropertyId(11, 1603484342339579092)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (sLO_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*SLO).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (sLO_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*SLO).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (sLO_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (sLO_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*SLO)
+	var propCreatedAt int64
+	{
+		var err error
+		propCreatedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.CreatedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on SLO.CreatedAt: " + err.Error())
+		}
+	}
+
+	var propUpdatedAt int64
+	{
+		var err error
+		propUpdatedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.UpdatedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on SLO.UpdatedAt: " + err.Error())
+		}
+	}
+
+	var offsetName = fbutils.CreateStringOffset(fbb, obj.Name)
+	var offsetDescription = fbutils.CreateStringOffset(fbb, obj.Description)
+	var offsetEndpoint = fbutils.CreateStringOffset(fbb, obj.Endpoint)
+	var offsetMethod = fbutils.CreateStringOffset(fbb, obj.Method)
+	var offsetObjective = fbutils.CreateStringOffset(fbb, obj.Objective)
+
+	// build the FlatBuffers object
+	fbb.StartObject(11)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetName)
+	fbutils.SetUOffsetTSlot(fbb, 2, offsetDescription)
+	fbutils.SetUOffsetTSlot(fbb, 3, offsetEndpoint)
+	fbutils.SetUOffsetTSlot(fbb, 4, offsetMethod)
+	fbutils.SetUOffsetTSlot(fbb, 5, offsetObjective)
+	fbutils.SetFloat64Slot(fbb, 6, obj.Target)
+	fbutils.SetFloat64Slot(fbb, 7, obj.LatencyThresholdMs)
+	fbutils.SetInt64Slot(fbb, 8, int64(obj.WindowDays))
+	fbutils.SetInt64Slot(fbb, 9, propCreatedAt)
+	fbutils.SetInt64Slot(fbb, 10, propUpdatedAt)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (sLO_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'SLO' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propCreatedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 22))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on SLO.CreatedAt: " + err.Error())
+	}
+
+	propUpdatedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 24))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on SLO.UpdatedAt: " + err.Error())
+	}
+
+	return &SLO{
+		Id:                 propId,
+		Name:               fbutils.GetStringSlot(table, 6),
+		Description:        fbutils.GetStringSlot(table, 8),
+		Endpoint:           fbutils.GetStringSlot(table, 10),
+		Method:             fbutils.GetStringSlot(table, 12),
+		Objective:          fbutils.GetStringSlot(table, 14),
+		Target:             fbutils.GetFloat64Slot(table, 16),
+		LatencyThresholdMs: fbutils.GetFloat64Slot(table, 18),
+		WindowDays:         fbutils.GetIntSlot(table, 20),
+		CreatedAt:          propCreatedAt,
+		UpdatedAt:          propUpdatedAt,
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (sLO_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*SLO, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (sLO_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*SLO), nil)
+	}
+	return append(slice.([]*SLO), object.(*SLO))
+}
+
+// Box provides CRUD access to SLO objects
+type SLOBox struct {
+	*objectbox.Box
+}
+
+// BoxForSLO opens a box of SLO objects
+func BoxForSLO(ob *objectbox.ObjectBox) *SLOBox {
+	return &SLOBox{
+		Box: ob.InternalBox(16),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the SLO.Id property on the passed object will be assigned the new ID as well.
+func (box *SLOBox) Put(object *SLO) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the SLO.Id property on the passed object will be assigned the new ID as well.
+func (box *SLOBox) Insert(object *SLO) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *SLOBox) Update(object *SLO) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *SLOBox) PutAsync(object *SLO) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the SLO.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the SLO.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *SLOBox) PutMany(objects []*SLO) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *SLOBox) Get(id uint64) (*SLO, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*SLO), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *SLOBox) GetMany(ids ...uint64) ([]*SLO, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*SLO), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *SLOBox) GetManyExisting(ids ...uint64) ([]*SLO, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*SLO), nil
+}
+
+// GetAll reads all stored objects
+func (box *SLOBox) GetAll() ([]*SLO, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*SLO), nil
+}
+
+// Remove deletes a single object
+func (box *SLOBox) Remove(object *SLO) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *SLOBox) RemoveMany(objects ...*SLO) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the SLO_ struct to create conditions.
+// Keep the *SLOQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *SLOBox) Query(conditions ...objectbox.Condition) *SLOQuery {
+	return &SLOQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the SLO_ struct to create conditions.
+// Keep the *SLOQuery if you intend to execute the query multiple times.
+func (box *SLOBox) QueryOrError(conditions ...objectbox.Condition) (*SLOQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &SLOQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See SLOAsyncBox for more information.
+func (box *SLOBox) Async() *SLOAsyncBox {
+	return &SLOAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// SLOAsyncBox provides asynchronous operations on SLO objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type SLOAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForSLO creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use SLOBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForSLO(ob *objectbox.ObjectBox, timeoutMs uint64) *SLOAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 16, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 16: %s" + err.Error())
+	}
+	return &SLOAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *SLOAsyncBox) Put(object *SLO) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *SLOAsyncBox) Insert(object *SLO) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *SLOAsyncBox) Update(object *SLO) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *SLOAsyncBox) Remove(object *SLO) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all SLO which Id is either 42 or 47:
+//
+// box.Query(SLO_.Id.In(42, 47)).Find()
+type SLOQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *SLOQuery) Find() ([]*SLO, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*SLO), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *SLOQuery) Offset(offset uint64) *SLOQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *SLOQuery) Limit(limit uint64) *SLOQuery {
+	query.Query.Limit(limit)
+	return query
+}
@@ -2,10 +2,39 @@ package models
 
 import "time"
 
+//go:generate go run github.com/objectbox/objectbox-go/cmd/objectbox-gogen
+
 type UsageStats struct {
-	ID        string    `json:"id"`
-	Endpoint  string    `json:"endpoint"`
-	Method    string    `json:"method"`
-	Status    int       `json:"status"`
-	Timestamp time.Time `json:"timestamp"`
-} 
\ No newline at end of file
+	// ObjectId is the ObjectBox id; clients address stats by ID.
+	ObjectId  uint64    `json:"-" objectbox:"id"`
+	ID        string    `json:"id" objectbox:"unique"`
+	Endpoint  string    `json:"endpoint" objectbox:"index"`
+	Method    string    `json:"method" objectbox:"index"`
+	Status    int       `json:"status" objectbox:"index"`
+	Timestamp time.Time `json:"timestamp" objectbox:"index"`
+	// The fields below are filled in by the request recorder middleware.
+	DurationMs   float64 `json:"duration_ms,omitempty"`
+	RequestSize  int64   `json:"request_size,omitempty"`
+	ResponseSize int64   `json:"response_size,omitempty"`
+	ClientIP     string  `json:"client_ip,omitempty"`
+	UserAgent    string  `json:"user_agent,omitempty"`
+	// Recorded is set for entries written by the middleware rather than posted.
+	Recorded bool `json:"recorded"`
+}
+
+// StatsRollup aggregates the usage stats of one endpoint and method within
+// one time bucket, so summaries over long windows need not read raw rows.
+type StatsRollup struct {
+	Id          uint64    `json:"-" objectbox:"id"`
+	Endpoint    string    `json:"endpoint" objectbox:"index"`
+	Method      string    `json:"method"`
+	BucketStart time.Time `json:"bucket_start" objectbox:"index"`
+	Count       int64     `json:"count"`
+	Status2xx   int64     `json:"status_2xx"`
+	Status3xx   int64     `json:"status_3xx"`
+	Status4xx   int64     `json:"status_4xx"`
+	Status5xx   int64     `json:"status_5xx"`
+	// Latency is a DDSketch of DurationMs as JSON. Entries without a
+	// duration, such as posted ones, are counted but not included.
+	Latency string `json:"-"`
+}
@@ -0,0 +1,895 @@
+// Code generated by ObjectBox; DO NOT EDIT.
+// Learn more about defining entities and generating this file - visit https://golang.objectbox.io/entity-annotations
+
+package models
+
+import (
+	"errors"
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+)
+
+type usageStats_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var UsageStatsBinding = usageStats_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 14,
+	},
+	Uid: 844018040311104212,
+}
+
+// UsageStats_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var UsageStats_ = struct {
+	ObjectId     *objectbox.PropertyUint64
+	ID           *objectbox.PropertyString
+	Endpoint     *objectbox.PropertyString
+	Method       *objectbox.PropertyString
+	Status       *objectbox.PropertyInt
+	Timestamp    *objectbox.PropertyInt64
+	DurationMs   *objectbox.PropertyFloat64
+	RequestSize  *objectbox.PropertyInt64
+	ResponseSize *objectbox.PropertyInt64
+	ClientIP     *objectbox.PropertyString
+	UserAgent    *objectbox.PropertyString
+	Recorded     *objectbox.PropertyBool
+}{
+	ObjectId: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &UsageStatsBinding.Entity,
+		},
+	},
+	ID: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &UsageStatsBinding.Entity,
+		},
+	},
+	Endpoint: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &UsageStatsBinding.Entity,
+		},
+	},
+	Method: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &UsageStatsBinding.Entity,
+		},
+	},
+	Status: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &UsageStatsBinding.Entity,
+		},
+	},
+	Timestamp: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &UsageStatsBinding.Entity,
+		},
+	},
+	DurationMs: &objectbox.PropertyFloat64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     7,
+			Entity: &UsageStatsBinding.Entity,
+		},
+	},
+	RequestSize: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     8,
+			Entity: &UsageStatsBinding.Entity,
+		},
+	},
+	ResponseSize: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     9,
+			Entity: &UsageStatsBinding.Entity,
+		},
+	},
+	ClientIP: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     10,
+			Entity: &UsageStatsBinding.Entity,
+		},
+	},
+	UserAgent: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     11,
+			Entity: &UsageStatsBinding.Entity,
+		},
+	},
+	Recorded: &objectbox.PropertyBool{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     12,
+			Entity: &UsageStatsBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (usageStats_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (usageStats_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("UsageStats", 14, 844018040311104212)
+	model.Property("ObjectId", 6, 1, 5489033826228009436)
+	model.PropertyFlags(1)
+	model.Property("ID", 9, 2, 4932420812088766243)
+	model.PropertyFlags(2080)
+	model.PropertyIndex(36, 234560681221254184)
+	model.Property("Endpoint", 9, 3, 8019170207588944518)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(37, 7286079796153005210)
+	model.Property("Method", 9, 4, 5815997136111229684)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(38, 5944198155364801785)
+	model.Property("Status", 6, 5, 3219868517303872540)
+	model.PropertyFlags(8)
+	model.PropertyIndex(39, 3191950710613740968)
+	model.Property("Timestamp", 10, 6, 7985952949545936267)
+	model.PropertyFlags(8)
+	model.PropertyIndex(40, 8744467642012786433)
+	model.Property("DurationMs", 8, 7, 6396658483953410356)
+	model.Property("RequestSize", 6, 8, 7986018784526247505)
+	model.Property("ResponseSize", 6, 9, 5785408529516116746)
+	model.Property("ClientIP", 9, 10, 5403817199784290083)
+	model.Property("UserAgent", 9, 11, 6909411562521219625)
+	model.Property("Recorded", 1, 12, 8270737609909809535)
+	model.EntityLastPropertyId(12, 8270737609909809535)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (usageStats_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*UsageStats).ObjectId, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (usageStats_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*UsageStats).ObjectId = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (usageStats_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (usageStats_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*UsageStats)
+	var propTimestamp int64
+	{
+		var err error
+		propTimestamp, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.Timestamp)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on UsageStats.Timestamp: " + err.Error())
+		}
+	}
+
+	var offsetID = fbutils.CreateStringOffset(fbb, obj.ID)
+	var offsetEndpoint = fbutils.CreateStringOffset(fbb, obj.Endpoint)
+	var offsetMethod = fbutils.CreateStringOffset(fbb, obj.Method)
+	var offsetClientIP = fbutils.CreateStringOffset(fbb, obj.ClientIP)
+	var offsetUserAgent = fbutils.CreateStringOffset(fbb, obj.UserAgent)
+
+	// build the FlatBuffers object
+	fbb.StartObject(12)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetID)
+	fbutils.SetUOffsetTSlot(fbb, 2, offsetEndpoint)
+	fbutils.SetUOffsetTSlot(fbb, 3, offsetMethod)
+	fbutils.SetInt64Slot(fbb, 4, int64(obj.Status))
+	fbutils.SetInt64Slot(fbb, 5, propTimestamp)
+	fbutils.SetFloat64Slot(fbb, 6, obj.DurationMs)
+	fbutils.SetInt64Slot(fbb, 7, obj.RequestSize)
+	fbutils.SetInt64Slot(fbb, 8, obj.ResponseSize)
+	fbutils.SetUOffsetTSlot(fbb, 9, offsetClientIP)
+	fbutils.SetUOffsetTSlot(fbb, 10, offsetUserAgent)
+	fbutils.SetBoolSlot(fbb, 11, obj.Recorded)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (usageStats_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'UsageStats' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propObjectId = table.GetUint64Slot(4, 0)
+
+	propTimestamp, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 14))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on UsageStats.Timestamp: " + err.Error())
+	}
+
+	return &UsageStats{
+		ObjectId:     propObjectId,
+		ID:           fbutils.GetStringSlot(table, 6),
+		Endpoint:     fbutils.GetStringSlot(table, 8),
+		Method:       fbutils.GetStringSlot(table, 10),
+		Status:       fbutils.GetIntSlot(table, 12),
+		Timestamp:    propTimestamp,
+		DurationMs:   fbutils.GetFloat64Slot(table, 16),
+		RequestSize:  fbutils.GetInt64Slot(table, 18),
+		ResponseSize: fbutils.GetInt64Slot(table, 20),
+		ClientIP:     fbutils.GetStringSlot(table, 22),
+		UserAgent:    fbutils.GetStringSlot(table, 24),
+		Recorded:     fbutils.GetBoolSlot(table, 26),
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (usageStats_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*UsageStats, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (usageStats_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*UsageStats), nil)
+	}
+	return append(slice.([]*UsageStats), object.(*UsageStats))
+}
+
+// Box provides CRUD access to UsageStats objects
+type UsageStatsBox struct {
+	*objectbox.Box
+}
+
+// BoxForUsageStats opens a box of UsageStats objects
+func BoxForUsageStats(ob *objectbox.ObjectBox) *UsageStatsBox {
+	return &UsageStatsBox{
+		Box: ob.InternalBox(14),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the ObjectId is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the UsageStats.ObjectId property on the passed object will be assigned the new ID as well.
+func (box *UsageStatsBox) Put(object *UsageStats) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the ObjectId is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the UsageStats.ObjectId property on the passed object will be assigned the new ID as well.
+func (box *UsageStatsBox) Insert(object *UsageStats) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *UsageStatsBox) Update(object *UsageStats) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *UsageStatsBox) PutAsync(object *UsageStats) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case ObjectIds are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the UsageStats.ObjectId property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the UsageStats.ObjectId assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *UsageStatsBox) PutMany(objects []*UsageStats) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *UsageStatsBox) Get(id uint64) (*UsageStats, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*UsageStats), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *UsageStatsBox) GetMany(ids ...uint64) ([]*UsageStats, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*UsageStats), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *UsageStatsBox) GetManyExisting(ids ...uint64) ([]*UsageStats, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*UsageStats), nil
+}
+
+// GetAll reads all stored objects
+func (box *UsageStatsBox) GetAll() ([]*UsageStats, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*UsageStats), nil
+}
+
+// Remove deletes a single object
+func (box *UsageStatsBox) Remove(object *UsageStats) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *UsageStatsBox) RemoveMany(objects ...*UsageStats) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.ObjectId
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the UsageStats_ struct to create conditions.
+// Keep the *UsageStatsQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *UsageStatsBox) Query(conditions ...objectbox.Condition) *UsageStatsQuery {
+	return &UsageStatsQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the UsageStats_ struct to create conditions.
+// Keep the *UsageStatsQuery if you intend to execute the query multiple times.
+func (box *UsageStatsBox) QueryOrError(conditions ...objectbox.Condition) (*UsageStatsQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &UsageStatsQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See UsageStatsAsyncBox for more information.
+func (box *UsageStatsBox) Async() *UsageStatsAsyncBox {
+	return &UsageStatsAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// UsageStatsAsyncBox provides asynchronous operations on UsageStats objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type UsageStatsAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForUsageStats creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use UsageStatsBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForUsageStats(ob *objectbox.ObjectBox, timeoutMs uint64) *UsageStatsAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 14, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 14: %s" + err.Error())
+	}
+	return &UsageStatsAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the ObjectId property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *UsageStatsAsyncBox) Put(object *UsageStats) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The ObjectId property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *UsageStatsAsyncBox) Insert(object *UsageStats) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *UsageStatsAsyncBox) Update(object *UsageStats) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *UsageStatsAsyncBox) Remove(object *UsageStats) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all UsageStats which ObjectId is either 42 or 47:
+//
+// box.Query(UsageStats_.ObjectId.In(42, 47)).Find()
+type UsageStatsQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *UsageStatsQuery) Find() ([]*UsageStats, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*UsageStats), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *UsageStatsQuery) Offset(offset uint64) *UsageStatsQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *UsageStatsQuery) Limit(limit uint64) *UsageStatsQuery {
+	query.Query.Limit(limit)
+	return query
+}
+
+type statsRollup_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var StatsRollupBinding = statsRollup_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 15,
+	},
+	Uid: 2796895082469028642,
+}
+
+// StatsRollup_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var StatsRollup_ = struct {
+	Id          *objectbox.PropertyUint64
+	Endpoint    *objectbox.PropertyString
+	Method      *objectbox.PropertyString
+	BucketStart *objectbox.PropertyInt64
+	Count       *objectbox.PropertyInt64
+	Status2xx   *objectbox.PropertyInt64
+	Status3xx   *objectbox.PropertyInt64
+	Status4xx   *objectbox.PropertyInt64
+	Status5xx   *objectbox.PropertyInt64
+	Latency     *objectbox.PropertyString
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &StatsRollupBinding.Entity,
+		},
+	},
+	Endpoint: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &StatsRollupBinding.Entity,
+		},
+	},
+	Method: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &StatsRollupBinding.Entity,
+		},
+	},
+	BucketStart: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &StatsRollupBinding.Entity,
+		},
+	},
+	Count: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &StatsRollupBinding.Entity,
+		},
+	},
+	Status2xx: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &StatsRollupBinding.Entity,
+		},
+	},
+	Status3xx: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     7,
+			Entity: &StatsRollupBinding.Entity,
+		},
+	},
+	Status4xx: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     8,
+			Entity: &StatsRollupBinding.Entity,
+		},
+	},
+	Status5xx: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     9,
+			Entity: &StatsRollupBinding.Entity,
+		},
+	},
+	Latency: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     10,
+			Entity: &StatsRollupBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (statsRollup_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (statsRollup_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("StatsRollup", 15, 2796895082469028642)
+	model.Property("Id", 6, 1, 7766877533702826475)
+	model.PropertyFlags(1)
+	model.Property("Endpoint", 9, 2, 468127227315899280)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(41, 2418484314304503873)
+	model.Property("Method", 9, 3, 5087970253628080306)
+	model.Property("BucketStart", 10, 4, 1847790714287101680)
+	model.PropertyFlags(8)
+	model.PropertyIndex(42, 4841138066072521911)
+	model.Property("Count", 6, 5, 5070521493455091847)
+	model.Property("Status2xx", 6, 6, 3230020579582146083)
+	model.Property("Status3xx", 6, 7, 135138015099482890)
+	model.Property("Status4xx", 6, 8, 1834762909030309425)
+	model.Property("Status5xx", 6, 9, 2231816089661785805)
+	model.Property("Latency", 9, 10, 659265970944298108)
+	model.EntityLastPropertyId(10, 659265970944298108)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (statsRollup_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*StatsRollup).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (statsRollup_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*StatsRollup).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (statsRollup_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (statsRollup_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*StatsRollup)
+	var propBucketStart int64
+	{
+		var err error
+		propBucketStart, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.BucketStart)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on StatsRollup.BucketStart: " + err.Error())
+		}
+	}
+
+	var offsetEndpoint = fbutils.CreateStringOffset(fbb, obj.Endpoint)
+	var offsetMethod = fbutils.CreateStringOffset(fbb, obj.Method)
+	var offsetLatency = fbutils.CreateStringOffset(fbb, obj.Latency)
+
+	// build the FlatBuffers object
+	fbb.StartObject(10)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetEndpoint)
+	fbutils.SetUOffsetTSlot(fbb, 2, offsetMethod)
+	fbutils.SetInt64Slot(fbb, 3, propBucketStart)
+	fbutils.SetInt64Slot(fbb, 4, obj.Count)
+	fbutils.SetInt64Slot(fbb, 5, obj.Status2xx)
+	fbutils.SetInt64Slot(fbb, 6, obj.Status3xx)
+	fbutils.SetInt64Slot(fbb, 7, obj.Status4xx)
+	fbutils.SetInt64Slot(fbb, 8, obj.Status5xx)
+	fbutils.SetUOffsetTSlot(fbb, 9, offsetLatency)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (statsRollup_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'StatsRollup' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propBucketStart, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 10))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on StatsRollup.BucketStart: " + err.Error())
+	}
+
+	return &StatsRollup{
+		Id:          propId,
+		Endpoint:    fbutils.GetStringSlot(table, 6),
+		Method:      fbutils.GetStringSlot(table, 8),
+		BucketStart: propBucketStart,
+		Count:       fbutils.GetInt64Slot(table, 12),
+		Status2xx:   fbutils.GetInt64Slot(table, 14),
+		Status3xx:   fbutils.GetInt64Slot(table, 16),
+		Status4xx:   fbutils.GetInt64Slot(table, 18),
+		Status5xx:   fbutils.GetInt64Slot(table, 20),
+		Latency:     fbutils.GetStringSlot(table, 22),
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (statsRollup_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*StatsRollup, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (statsRollup_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*StatsRollup), nil)
+	}
+	return append(slice.([]*StatsRollup), object.(*StatsRollup))
+}
+
+// Box provides CRUD access to StatsRollup objects
+type StatsRollupBox struct {
+	*objectbox.Box
+}
+
+// BoxForStatsRollup opens a box of StatsRollup objects
+func BoxForStatsRollup(ob *objectbox.ObjectBox) *StatsRollupBox {
+	return &StatsRollupBox{
+		Box: ob.InternalBox(15),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the StatsRollup.Id property on the passed object will be assigned the new ID as well.
+func (box *StatsRollupBox) Put(object *StatsRollup) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the StatsRollup.Id property on the passed object will be assigned the new ID as well.
+func (box *StatsRollupBox) Insert(object *StatsRollup) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *StatsRollupBox) Update(object *StatsRollup) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *StatsRollupBox) PutAsync(object *StatsRollup) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the StatsRollup.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the StatsRollup.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *StatsRollupBox) PutMany(objects []*StatsRollup) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *StatsRollupBox) Get(id uint64) (*StatsRollup, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*StatsRollup), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *StatsRollupBox) GetMany(ids ...uint64) ([]*StatsRollup, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*StatsRollup), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *StatsRollupBox) GetManyExisting(ids ...uint64) ([]*StatsRollup, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*StatsRollup), nil
+}
+
+// GetAll reads all stored objects
+func (box *StatsRollupBox) GetAll() ([]*StatsRollup, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*StatsRollup), nil
+}
+
+// Remove deletes a single object
+func (box *StatsRollupBox) Remove(object *StatsRollup) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *StatsRollupBox) RemoveMany(objects ...*StatsRollup) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the StatsRollup_ struct to create conditions.
+// Keep the *StatsRollupQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *StatsRollupBox) Query(conditions ...objectbox.Condition) *StatsRollupQuery {
+	return &StatsRollupQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the StatsRollup_ struct to create conditions.
+// Keep the *StatsRollupQuery if you intend to execute the query multiple times.
+func (box *StatsRollupBox) QueryOrError(conditions ...objectbox.Condition) (*StatsRollupQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &StatsRollupQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See StatsRollupAsyncBox for more information.
+func (box *StatsRollupBox) Async() *StatsRollupAsyncBox {
+	return &StatsRollupAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// StatsRollupAsyncBox provides asynchronous operations on StatsRollup objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type StatsRollupAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForStatsRollup creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use StatsRollupBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForStatsRollup(ob *objectbox.ObjectBox, timeoutMs uint64) *StatsRollupAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 15, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 15: %s" + err.Error())
+	}
+	return &StatsRollupAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *StatsRollupAsyncBox) Put(object *StatsRollup) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *StatsRollupAsyncBox) Insert(object *StatsRollup) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *StatsRollupAsyncBox) Update(object *StatsRollup) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *StatsRollupAsyncBox) Remove(object *StatsRollup) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all StatsRollup which Id is either 42 or 47:
+//
+// box.Query(StatsRollup_.Id.In(42, 47)).Find()
+type StatsRollupQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *StatsRollupQuery) Find() ([]*StatsRollup, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*StatsRollup), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *StatsRollupQuery) Offset(offset uint64) *StatsRollupQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *StatsRollupQuery) Limit(limit uint64) *StatsRollupQuery {
+	query.Query.Limit(limit)
+	return query
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+//go:generate go run github.com/objectbox/objectbox-go/cmd/objectbox-gogen
+
+// Webhook events
+const (
+	WebhookActivityCreated = "activity.created"
+	WebhookActivityDeleted = "activity.deleted"
+)
+
+// WebhookSubscription pushes activity events matching Filter to URL, signed
+// with Secret.
+type WebhookSubscription struct {
+	Id      uint64 `objectbox:"id"`
+	Name    string `objectbox:"unique"`
+	URL     string
+	Filter  string // Store as JSON string
+	Events  string // Comma separated webhook events
+	Secret  string `json:"-"`
+	Enabled bool
+	// StartSequence is the newest change when the subscription was created.
+	// Only later changes are delivered to it.
+	StartSequence uint64
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead" // gave up after the maximum number of attempts
+)
+
+// WebhookDelivery is one event for one subscription in the outbox. It stays
+// pending until the receiver accepts it or it is dead-lettered, and is kept
+// afterwards as the delivery log.
+type WebhookDelivery struct {
+	Id               uint64 `objectbox:"id"`
+	SubscriptionId   uint64 `objectbox:"link:WebhookSubscription"`
+	SubscriptionName string `objectbox:"index"`
+	Event            string
+	// Sequence is the change feed sequence of the change the event is about.
+	Sequence      uint64
+	ActivityId    uint64
+	Payload       string // JSON body, signed when sent
+	Status        string `objectbox:"index"`
+	Attempts      int
+	NextAttemptAt time.Time `objectbox:"index"`
+	LastAttemptAt time.Time
+	// LastStatusCode is the HTTP status of the last attempt, 0 if it got no response.
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	FinishedAt     time.Time `objectbox:"index"` // when it was delivered or dead-lettered
+}
+
+// WebhookCheckpoint is the last change sequence fanned out into the outbox.
+type WebhookCheckpoint struct {
+	Id           uint64 `objectbox:"id"`
+	LastSequence uint64
+	UpdatedAt    time.Time
+}
+
+// Helper methods for events
+func (s *WebhookSubscription) SetEvents(events []string) {
+	s.Events = strings.Join(events, ",")
+}
+
+func (s *WebhookSubscription) GetEvents() []string {
+	if s.Events == "" {
+		return []string{}
+	}
+	return strings.Split(s.Events, ",")
+}
+
+// Subscribes reports whether the subscription receives event.
+func (s *WebhookSubscription) Subscribes(event string) bool {
+	for _, subscribed := range s.GetEvents() {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
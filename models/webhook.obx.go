@@ -0,0 +1,1338 @@
+// Code generated by ObjectBox; DO NOT EDIT.
+// Learn more about defining entities and generating this file - visit https://golang.objectbox.io/entity-annotations
+
+package models
+
+import (
+	"errors"
+	"github.com/google/flatbuffers/go"
+	"github.com/objectbox/objectbox-go/objectbox"
+	"github.com/objectbox/objectbox-go/objectbox/fbutils"
+)
+
+type webhookSubscription_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var WebhookSubscriptionBinding = webhookSubscription_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 11,
+	},
+	Uid: 6614296851789313066,
+}
+
+// WebhookSubscription_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var WebhookSubscription_ = struct {
+	Id            *objectbox.PropertyUint64
+	Name          *objectbox.PropertyString
+	URL           *objectbox.PropertyString
+	Filter        *objectbox.PropertyString
+	Events        *objectbox.PropertyString
+	Secret        *objectbox.PropertyString
+	Enabled       *objectbox.PropertyBool
+	StartSequence *objectbox.PropertyUint64
+	CreatedAt     *objectbox.PropertyInt64
+	UpdatedAt     *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &WebhookSubscriptionBinding.Entity,
+		},
+	},
+	Name: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &WebhookSubscriptionBinding.Entity,
+		},
+	},
+	URL: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &WebhookSubscriptionBinding.Entity,
+		},
+	},
+	Filter: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &WebhookSubscriptionBinding.Entity,
+		},
+	},
+	Events: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &WebhookSubscriptionBinding.Entity,
+		},
+	},
+	Secret: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &WebhookSubscriptionBinding.Entity,
+		},
+	},
+	Enabled: &objectbox.PropertyBool{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     7,
+			Entity: &WebhookSubscriptionBinding.Entity,
+		},
+	},
+	StartSequence: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     8,
+			Entity: &WebhookSubscriptionBinding.Entity,
+		},
+	},
+	CreatedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     9,
+			Entity: &WebhookSubscriptionBinding.Entity,
+		},
+	},
+	UpdatedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     10,
+			Entity: &WebhookSubscriptionBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (webhookSubscription_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (webhookSubscription_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("WebhookSubscription", 11, 6614296851789313066)
+	model.Property("Id", 6, 1, 634137904113439658)
+	model.PropertyFlags(1)
+	model.Property("Name", 9, 2, 6427240815904375007)
+	model.PropertyFlags(2080)
+	model.PropertyIndex(30, 4042247414827285038)
+	model.Property("URL", 9, 3, 6049177359182412394)
+	model.Property("Filter", 9, 4, 8192254005340039094)
+	model.Property("Events", 9, 5, 7431188583229979838)
+	model.Property("Secret", 9, 6, 9057274281401009381)
+	model.Property("Enabled", 1, 7, 6305619385345117578)
+	model.Property("StartSequence", 6, 8, 6320731870102545649)
+	model.PropertyFlags(8192)
+	model.Property("CreatedAt", 10, 9, 7522232048490070518)
+	model.Property("UpdatedAt", 10, 10, 6758895729965679505)
+	model.EntityLastPropertyId(10, 6758895729965679505)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (webhookSubscription_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*WebhookSubscription).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (webhookSubscription_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*WebhookSubscription).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (webhookSubscription_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (webhookSubscription_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*WebhookSubscription)
+	var propCreatedAt int64
+	{
+		var err error
+		propCreatedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.CreatedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on WebhookSubscription.CreatedAt: " + err.Error())
+		}
+	}
+
+	var propUpdatedAt int64
+	{
+		var err error
+		propUpdatedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.UpdatedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on WebhookSubscription.UpdatedAt: " + err.Error())
+		}
+	}
+
+	var offsetName = fbutils.CreateStringOffset(fbb, obj.Name)
+	var offsetURL = fbutils.CreateStringOffset(fbb, obj.URL)
+	var offsetFilter = fbutils.CreateStringOffset(fbb, obj.Filter)
+	var offsetEvents = fbutils.CreateStringOffset(fbb, obj.Events)
+	var offsetSecret = fbutils.CreateStringOffset(fbb, obj.Secret)
+
+	// build the FlatBuffers object
+	fbb.StartObject(10)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUOffsetTSlot(fbb, 1, offsetName)
+	fbutils.SetUOffsetTSlot(fbb, 2, offsetURL)
+	fbutils.SetUOffsetTSlot(fbb, 3, offsetFilter)
+	fbutils.SetUOffsetTSlot(fbb, 4, offsetEvents)
+	fbutils.SetUOffsetTSlot(fbb, 5, offsetSecret)
+	fbutils.SetBoolSlot(fbb, 6, obj.Enabled)
+	fbutils.SetUint64Slot(fbb, 7, obj.StartSequence)
+	fbutils.SetInt64Slot(fbb, 8, propCreatedAt)
+	fbutils.SetInt64Slot(fbb, 9, propUpdatedAt)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (webhookSubscription_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'WebhookSubscription' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propCreatedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 20))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on WebhookSubscription.CreatedAt: " + err.Error())
+	}
+
+	propUpdatedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 22))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on WebhookSubscription.UpdatedAt: " + err.Error())
+	}
+
+	return &WebhookSubscription{
+		Id:            propId,
+		Name:          fbutils.GetStringSlot(table, 6),
+		URL:           fbutils.GetStringSlot(table, 8),
+		Filter:        fbutils.GetStringSlot(table, 10),
+		Events:        fbutils.GetStringSlot(table, 12),
+		Secret:        fbutils.GetStringSlot(table, 14),
+		Enabled:       fbutils.GetBoolSlot(table, 16),
+		StartSequence: fbutils.GetUint64Slot(table, 18),
+		CreatedAt:     propCreatedAt,
+		UpdatedAt:     propUpdatedAt,
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (webhookSubscription_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*WebhookSubscription, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (webhookSubscription_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*WebhookSubscription), nil)
+	}
+	return append(slice.([]*WebhookSubscription), object.(*WebhookSubscription))
+}
+
+// Box provides CRUD access to WebhookSubscription objects
+type WebhookSubscriptionBox struct {
+	*objectbox.Box
+}
+
+// BoxForWebhookSubscription opens a box of WebhookSubscription objects
+func BoxForWebhookSubscription(ob *objectbox.ObjectBox) *WebhookSubscriptionBox {
+	return &WebhookSubscriptionBox{
+		Box: ob.InternalBox(11),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the WebhookSubscription.Id property on the passed object will be assigned the new ID as well.
+func (box *WebhookSubscriptionBox) Put(object *WebhookSubscription) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the WebhookSubscription.Id property on the passed object will be assigned the new ID as well.
+func (box *WebhookSubscriptionBox) Insert(object *WebhookSubscription) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *WebhookSubscriptionBox) Update(object *WebhookSubscription) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *WebhookSubscriptionBox) PutAsync(object *WebhookSubscription) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the WebhookSubscription.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the WebhookSubscription.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *WebhookSubscriptionBox) PutMany(objects []*WebhookSubscription) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *WebhookSubscriptionBox) Get(id uint64) (*WebhookSubscription, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*WebhookSubscription), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *WebhookSubscriptionBox) GetMany(ids ...uint64) ([]*WebhookSubscription, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WebhookSubscription), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *WebhookSubscriptionBox) GetManyExisting(ids ...uint64) ([]*WebhookSubscription, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WebhookSubscription), nil
+}
+
+// GetAll reads all stored objects
+func (box *WebhookSubscriptionBox) GetAll() ([]*WebhookSubscription, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WebhookSubscription), nil
+}
+
+// Remove deletes a single object
+func (box *WebhookSubscriptionBox) Remove(object *WebhookSubscription) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *WebhookSubscriptionBox) RemoveMany(objects ...*WebhookSubscription) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the WebhookSubscription_ struct to create conditions.
+// Keep the *WebhookSubscriptionQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *WebhookSubscriptionBox) Query(conditions ...objectbox.Condition) *WebhookSubscriptionQuery {
+	return &WebhookSubscriptionQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the WebhookSubscription_ struct to create conditions.
+// Keep the *WebhookSubscriptionQuery if you intend to execute the query multiple times.
+func (box *WebhookSubscriptionBox) QueryOrError(conditions ...objectbox.Condition) (*WebhookSubscriptionQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &WebhookSubscriptionQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See WebhookSubscriptionAsyncBox for more information.
+func (box *WebhookSubscriptionBox) Async() *WebhookSubscriptionAsyncBox {
+	return &WebhookSubscriptionAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// WebhookSubscriptionAsyncBox provides asynchronous operations on WebhookSubscription objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type WebhookSubscriptionAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForWebhookSubscription creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use WebhookSubscriptionBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForWebhookSubscription(ob *objectbox.ObjectBox, timeoutMs uint64) *WebhookSubscriptionAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 11, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 11: %s" + err.Error())
+	}
+	return &WebhookSubscriptionAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *WebhookSubscriptionAsyncBox) Put(object *WebhookSubscription) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *WebhookSubscriptionAsyncBox) Insert(object *WebhookSubscription) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *WebhookSubscriptionAsyncBox) Update(object *WebhookSubscription) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *WebhookSubscriptionAsyncBox) Remove(object *WebhookSubscription) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all WebhookSubscription which Id is either 42 or 47:
+//
+// box.Query(WebhookSubscription_.Id.In(42, 47)).Find()
+type WebhookSubscriptionQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *WebhookSubscriptionQuery) Find() ([]*WebhookSubscription, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WebhookSubscription), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *WebhookSubscriptionQuery) Offset(offset uint64) *WebhookSubscriptionQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *WebhookSubscriptionQuery) Limit(limit uint64) *WebhookSubscriptionQuery {
+	query.Query.Limit(limit)
+	return query
+}
+
+type webhookDelivery_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var WebhookDeliveryBinding = webhookDelivery_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 12,
+	},
+	Uid: 8889949507908978921,
+}
+
+// WebhookDelivery_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var WebhookDelivery_ = struct {
+	Id               *objectbox.PropertyUint64
+	SubscriptionId   *objectbox.RelationToOne
+	SubscriptionName *objectbox.PropertyString
+	Event            *objectbox.PropertyString
+	Sequence         *objectbox.PropertyUint64
+	ActivityId       *objectbox.PropertyUint64
+	Payload          *objectbox.PropertyString
+	Status           *objectbox.PropertyString
+	Attempts         *objectbox.PropertyInt
+	NextAttemptAt    *objectbox.PropertyInt64
+	LastAttemptAt    *objectbox.PropertyInt64
+	LastStatusCode   *objectbox.PropertyInt
+	LastError        *objectbox.PropertyString
+	CreatedAt        *objectbox.PropertyInt64
+	FinishedAt       *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+	},
+	SubscriptionId: &objectbox.RelationToOne{
+		Property: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+		Target: &WebhookSubscriptionBinding.Entity,
+	},
+	SubscriptionName: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+	},
+	Event: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     4,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+	},
+	Sequence: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     5,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+	},
+	ActivityId: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     6,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+	},
+	Payload: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     7,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+	},
+	Status: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     8,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+	},
+	Attempts: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     9,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+	},
+	NextAttemptAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     10,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+	},
+	LastAttemptAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     11,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+	},
+	LastStatusCode: &objectbox.PropertyInt{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     12,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+	},
+	LastError: &objectbox.PropertyString{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     13,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+	},
+	CreatedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     14,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+	},
+	FinishedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     15,
+			Entity: &WebhookDeliveryBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (webhookDelivery_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (webhookDelivery_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("WebhookDelivery", 12, 8889949507908978921)
+	model.Property("Id", 6, 1, 6941446453348204099)
+	model.PropertyFlags(1)
+	model.Property("SubscriptionId", 11, 2, 5761930764135297503)
+	model.PropertyFlags(520)
+	model.PropertyRelation("WebhookSubscription", 31, 6606277351443867104)
+	model.Property("SubscriptionName", 9, 3, 5349214680545954420)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(32, 5744854849062454902)
+	model.Property("Event", 9, 4, 5855602634157099051)
+	model.Property("Sequence", 6, 5, 6548098097655839050)
+	model.PropertyFlags(8192)
+	model.Property("ActivityId", 6, 6, 7323319173639112413)
+	model.PropertyFlags(8192)
+	model.Property("Payload", 9, 7, 4461105040108340281)
+	model.Property("Status", 9, 8, 7801755133237966413)
+	model.PropertyFlags(2048)
+	model.PropertyIndex(33, 3583199973136928868)
+	model.Property("Attempts", 6, 9, 3057468893383918931)
+	model.Property("NextAttemptAt", 10, 10, 2391814433032685943)
+	model.PropertyFlags(8)
+	model.PropertyIndex(34, 157615913202678906)
+	model.Property("LastAttemptAt", 10, 11, 4777419233896332113)
+	model.Property("LastStatusCode", 6, 12, 2251955151349959509)
+	model.Property("LastError", 9, 13, 8745741697971495976)
+	model.Property("CreatedAt", 10, 14, 8002920431705441543)
+	model.Property("FinishedAt", 10, 15, 9188435248683470107)
+	model.PropertyFlags(8)
+	model.PropertyIndex(35, 3284761850145708296)
+	model.EntityLastPropertyId(15, 9188435248683470107)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (webhookDelivery_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*WebhookDelivery).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (webhookDelivery_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*WebhookDelivery).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (webhookDelivery_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (webhookDelivery_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*WebhookDelivery)
+	var propNextAttemptAt int64
+	{
+		var err error
+		propNextAttemptAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.NextAttemptAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on WebhookDelivery.NextAttemptAt: " + err.Error())
+		}
+	}
+
+	var propLastAttemptAt int64
+	{
+		var err error
+		propLastAttemptAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.LastAttemptAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on WebhookDelivery.LastAttemptAt: " + err.Error())
+		}
+	}
+
+	var propCreatedAt int64
+	{
+		var err error
+		propCreatedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.CreatedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on WebhookDelivery.CreatedAt: " + err.Error())
+		}
+	}
+
+	var propFinishedAt int64
+	{
+		var err error
+		propFinishedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.FinishedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on WebhookDelivery.FinishedAt: " + err.Error())
+		}
+	}
+
+	var offsetSubscriptionName = fbutils.CreateStringOffset(fbb, obj.SubscriptionName)
+	var offsetEvent = fbutils.CreateStringOffset(fbb, obj.Event)
+	var offsetPayload = fbutils.CreateStringOffset(fbb, obj.Payload)
+	var offsetStatus = fbutils.CreateStringOffset(fbb, obj.Status)
+	var offsetLastError = fbutils.CreateStringOffset(fbb, obj.LastError)
+
+	var rIdSubscriptionId = obj.SubscriptionId
+
+	// build the FlatBuffers object
+	fbb.StartObject(15)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUint64Slot(fbb, 1, rIdSubscriptionId)
+	fbutils.SetUOffsetTSlot(fbb, 2, offsetSubscriptionName)
+	fbutils.SetUOffsetTSlot(fbb, 3, offsetEvent)
+	fbutils.SetUint64Slot(fbb, 4, obj.Sequence)
+	fbutils.SetUint64Slot(fbb, 5, obj.ActivityId)
+	fbutils.SetUOffsetTSlot(fbb, 6, offsetPayload)
+	fbutils.SetUOffsetTSlot(fbb, 7, offsetStatus)
+	fbutils.SetInt64Slot(fbb, 8, int64(obj.Attempts))
+	fbutils.SetInt64Slot(fbb, 9, propNextAttemptAt)
+	fbutils.SetInt64Slot(fbb, 10, propLastAttemptAt)
+	fbutils.SetInt64Slot(fbb, 11, int64(obj.LastStatusCode))
+	fbutils.SetUOffsetTSlot(fbb, 12, offsetLastError)
+	fbutils.SetInt64Slot(fbb, 13, propCreatedAt)
+	fbutils.SetInt64Slot(fbb, 14, propFinishedAt)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (webhookDelivery_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'WebhookDelivery' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propNextAttemptAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 22))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on WebhookDelivery.NextAttemptAt: " + err.Error())
+	}
+
+	propLastAttemptAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 24))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on WebhookDelivery.LastAttemptAt: " + err.Error())
+	}
+
+	propCreatedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 30))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on WebhookDelivery.CreatedAt: " + err.Error())
+	}
+
+	propFinishedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 32))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on WebhookDelivery.FinishedAt: " + err.Error())
+	}
+
+	return &WebhookDelivery{
+		Id:               propId,
+		SubscriptionId:   fbutils.GetUint64Slot(table, 6),
+		SubscriptionName: fbutils.GetStringSlot(table, 8),
+		Event:            fbutils.GetStringSlot(table, 10),
+		Sequence:         fbutils.GetUint64Slot(table, 12),
+		ActivityId:       fbutils.GetUint64Slot(table, 14),
+		Payload:          fbutils.GetStringSlot(table, 16),
+		Status:           fbutils.GetStringSlot(table, 18),
+		Attempts:         fbutils.GetIntSlot(table, 20),
+		NextAttemptAt:    propNextAttemptAt,
+		LastAttemptAt:    propLastAttemptAt,
+		LastStatusCode:   fbutils.GetIntSlot(table, 26),
+		LastError:        fbutils.GetStringSlot(table, 28),
+		CreatedAt:        propCreatedAt,
+		FinishedAt:       propFinishedAt,
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (webhookDelivery_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*WebhookDelivery, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (webhookDelivery_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*WebhookDelivery), nil)
+	}
+	return append(slice.([]*WebhookDelivery), object.(*WebhookDelivery))
+}
+
+// Box provides CRUD access to WebhookDelivery objects
+type WebhookDeliveryBox struct {
+	*objectbox.Box
+}
+
+// BoxForWebhookDelivery opens a box of WebhookDelivery objects
+func BoxForWebhookDelivery(ob *objectbox.ObjectBox) *WebhookDeliveryBox {
+	return &WebhookDeliveryBox{
+		Box: ob.InternalBox(12),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the WebhookDelivery.Id property on the passed object will be assigned the new ID as well.
+func (box *WebhookDeliveryBox) Put(object *WebhookDelivery) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the WebhookDelivery.Id property on the passed object will be assigned the new ID as well.
+func (box *WebhookDeliveryBox) Insert(object *WebhookDelivery) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *WebhookDeliveryBox) Update(object *WebhookDelivery) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *WebhookDeliveryBox) PutAsync(object *WebhookDelivery) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the WebhookDelivery.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the WebhookDelivery.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *WebhookDeliveryBox) PutMany(objects []*WebhookDelivery) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *WebhookDeliveryBox) Get(id uint64) (*WebhookDelivery, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*WebhookDelivery), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *WebhookDeliveryBox) GetMany(ids ...uint64) ([]*WebhookDelivery, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WebhookDelivery), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *WebhookDeliveryBox) GetManyExisting(ids ...uint64) ([]*WebhookDelivery, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WebhookDelivery), nil
+}
+
+// GetAll reads all stored objects
+func (box *WebhookDeliveryBox) GetAll() ([]*WebhookDelivery, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WebhookDelivery), nil
+}
+
+// Remove deletes a single object
+func (box *WebhookDeliveryBox) Remove(object *WebhookDelivery) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *WebhookDeliveryBox) RemoveMany(objects ...*WebhookDelivery) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the WebhookDelivery_ struct to create conditions.
+// Keep the *WebhookDeliveryQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *WebhookDeliveryBox) Query(conditions ...objectbox.Condition) *WebhookDeliveryQuery {
+	return &WebhookDeliveryQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the WebhookDelivery_ struct to create conditions.
+// Keep the *WebhookDeliveryQuery if you intend to execute the query multiple times.
+func (box *WebhookDeliveryBox) QueryOrError(conditions ...objectbox.Condition) (*WebhookDeliveryQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &WebhookDeliveryQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See WebhookDeliveryAsyncBox for more information.
+func (box *WebhookDeliveryBox) Async() *WebhookDeliveryAsyncBox {
+	return &WebhookDeliveryAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// WebhookDeliveryAsyncBox provides asynchronous operations on WebhookDelivery objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type WebhookDeliveryAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForWebhookDelivery creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use WebhookDeliveryBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForWebhookDelivery(ob *objectbox.ObjectBox, timeoutMs uint64) *WebhookDeliveryAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 12, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 12: %s" + err.Error())
+	}
+	return &WebhookDeliveryAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *WebhookDeliveryAsyncBox) Put(object *WebhookDelivery) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *WebhookDeliveryAsyncBox) Insert(object *WebhookDelivery) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *WebhookDeliveryAsyncBox) Update(object *WebhookDelivery) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *WebhookDeliveryAsyncBox) Remove(object *WebhookDelivery) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all WebhookDelivery which Id is either 42 or 47:
+//
+// box.Query(WebhookDelivery_.Id.In(42, 47)).Find()
+type WebhookDeliveryQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *WebhookDeliveryQuery) Find() ([]*WebhookDelivery, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WebhookDelivery), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *WebhookDeliveryQuery) Offset(offset uint64) *WebhookDeliveryQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *WebhookDeliveryQuery) Limit(limit uint64) *WebhookDeliveryQuery {
+	query.Query.Limit(limit)
+	return query
+}
+
+type webhookCheckpoint_EntityInfo struct {
+	objectbox.Entity
+	Uid uint64
+}
+
+var WebhookCheckpointBinding = webhookCheckpoint_EntityInfo{
+	Entity: objectbox.Entity{
+		Id: 13,
+	},
+	Uid: 3301308587348913490,
+}
+
+// WebhookCheckpoint_ contains type-based Property helpers to facilitate some common operations such as Queries.
+var WebhookCheckpoint_ = struct {
+	Id           *objectbox.PropertyUint64
+	LastSequence *objectbox.PropertyUint64
+	UpdatedAt    *objectbox.PropertyInt64
+}{
+	Id: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     1,
+			Entity: &WebhookCheckpointBinding.Entity,
+		},
+	},
+	LastSequence: &objectbox.PropertyUint64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     2,
+			Entity: &WebhookCheckpointBinding.Entity,
+		},
+	},
+	UpdatedAt: &objectbox.PropertyInt64{
+		BaseProperty: &objectbox.BaseProperty{
+			Id:     3,
+			Entity: &WebhookCheckpointBinding.Entity,
+		},
+	},
+}
+
+// GeneratorVersion is called by ObjectBox to verify the compatibility of the generator used to generate this code
+func (webhookCheckpoint_EntityInfo) GeneratorVersion() int {
+	return 6
+}
+
+// AddToModel is called by ObjectBox during model build
+func (webhookCheckpoint_EntityInfo) AddToModel(model *objectbox.Model) {
+	model.Entity("WebhookCheckpoint", 13, 3301308587348913490)
+	model.Property("Id", 6, 1, 799485904396358796)
+	model.PropertyFlags(1)
+	model.Property("LastSequence", 6, 2, 6537797958013157704)
+	model.PropertyFlags(8192)
+	model.Property("UpdatedAt", 10, 3, 6860755687516920435)
+	model.EntityLastPropertyId(3, 6860755687516920435)
+}
+
+// GetId is called by ObjectBox during Put operations to check for existing ID on an object
+func (webhookCheckpoint_EntityInfo) GetId(object interface{}) (uint64, error) {
+	return object.(*WebhookCheckpoint).Id, nil
+}
+
+// SetId is called by ObjectBox during Put to update an ID on an object that has just been inserted
+func (webhookCheckpoint_EntityInfo) SetId(object interface{}, id uint64) error {
+	object.(*WebhookCheckpoint).Id = id
+	return nil
+}
+
+// PutRelated is called by ObjectBox to put related entities before the object itself is flattened and put
+func (webhookCheckpoint_EntityInfo) PutRelated(ob *objectbox.ObjectBox, object interface{}, id uint64) error {
+	return nil
+}
+
+// Flatten is called by ObjectBox to transform an object to a FlatBuffer
+func (webhookCheckpoint_EntityInfo) Flatten(object interface{}, fbb *flatbuffers.Builder, id uint64) error {
+	obj := object.(*WebhookCheckpoint)
+	var propUpdatedAt int64
+	{
+		var err error
+		propUpdatedAt, err = objectbox.TimeInt64ConvertToDatabaseValue(obj.UpdatedAt)
+		if err != nil {
+			return errors.New("converter objectbox.TimeInt64ConvertToDatabaseValue() failed on WebhookCheckpoint.UpdatedAt: " + err.Error())
+		}
+	}
+
+	// build the FlatBuffers object
+	fbb.StartObject(3)
+	fbutils.SetUint64Slot(fbb, 0, id)
+	fbutils.SetUint64Slot(fbb, 1, obj.LastSequence)
+	fbutils.SetInt64Slot(fbb, 2, propUpdatedAt)
+	return nil
+}
+
+// Load is called by ObjectBox to load an object from a FlatBuffer
+func (webhookCheckpoint_EntityInfo) Load(ob *objectbox.ObjectBox, bytes []byte) (interface{}, error) {
+	if len(bytes) == 0 { // sanity check, should "never" happen
+		return nil, errors.New("can't deserialize an object of type 'WebhookCheckpoint' - no data received")
+	}
+
+	var table = &flatbuffers.Table{
+		Bytes: bytes,
+		Pos:   flatbuffers.GetUOffsetT(bytes),
+	}
+
+	var propId = table.GetUint64Slot(4, 0)
+
+	propUpdatedAt, err := objectbox.TimeInt64ConvertToEntityProperty(fbutils.GetInt64Slot(table, 8))
+	if err != nil {
+		return nil, errors.New("converter objectbox.TimeInt64ConvertToEntityProperty() failed on WebhookCheckpoint.UpdatedAt: " + err.Error())
+	}
+
+	return &WebhookCheckpoint{
+		Id:           propId,
+		LastSequence: fbutils.GetUint64Slot(table, 6),
+		UpdatedAt:    propUpdatedAt,
+	}, nil
+}
+
+// MakeSlice is called by ObjectBox to construct a new slice to hold the read objects
+func (webhookCheckpoint_EntityInfo) MakeSlice(capacity int) interface{} {
+	return make([]*WebhookCheckpoint, 0, capacity)
+}
+
+// AppendToSlice is called by ObjectBox to fill the slice of the read objects
+func (webhookCheckpoint_EntityInfo) AppendToSlice(slice interface{}, object interface{}) interface{} {
+	if object == nil {
+		return append(slice.([]*WebhookCheckpoint), nil)
+	}
+	return append(slice.([]*WebhookCheckpoint), object.(*WebhookCheckpoint))
+}
+
+// Box provides CRUD access to WebhookCheckpoint objects
+type WebhookCheckpointBox struct {
+	*objectbox.Box
+}
+
+// BoxForWebhookCheckpoint opens a box of WebhookCheckpoint objects
+func BoxForWebhookCheckpoint(ob *objectbox.ObjectBox) *WebhookCheckpointBox {
+	return &WebhookCheckpointBox{
+		Box: ob.InternalBox(13),
+	}
+}
+
+// Put synchronously inserts/updates a single object.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the WebhookCheckpoint.Id property on the passed object will be assigned the new ID as well.
+func (box *WebhookCheckpointBox) Put(object *WebhookCheckpoint) (uint64, error) {
+	return box.Box.Put(object)
+}
+
+// Insert synchronously inserts a single object. As opposed to Put, Insert will fail if given an ID that already exists.
+// In case the Id is not specified, it would be assigned automatically (auto-increment).
+// When inserting, the WebhookCheckpoint.Id property on the passed object will be assigned the new ID as well.
+func (box *WebhookCheckpointBox) Insert(object *WebhookCheckpoint) (uint64, error) {
+	return box.Box.Insert(object)
+}
+
+// Update synchronously updates a single object.
+// As opposed to Put, Update will fail if an object with the same ID is not found in the database.
+func (box *WebhookCheckpointBox) Update(object *WebhookCheckpoint) error {
+	return box.Box.Update(object)
+}
+
+// PutAsync asynchronously inserts/updates a single object.
+// Deprecated: use box.Async().Put() instead
+func (box *WebhookCheckpointBox) PutAsync(object *WebhookCheckpoint) (uint64, error) {
+	return box.Box.PutAsync(object)
+}
+
+// PutMany inserts multiple objects in single transaction.
+// In case Ids are not set on the objects, they would be assigned automatically (auto-increment).
+//
+// Returns: IDs of the put objects (in the same order).
+// When inserting, the WebhookCheckpoint.Id property on the objects in the slice will be assigned the new IDs as well.
+//
+// Note: In case an error occurs during the transaction, some of the objects may already have the WebhookCheckpoint.Id assigned
+// even though the transaction has been rolled back and the objects are not stored under those IDs.
+//
+// Note: The slice may be empty or even nil; in both cases, an empty IDs slice and no error is returned.
+func (box *WebhookCheckpointBox) PutMany(objects []*WebhookCheckpoint) ([]uint64, error) {
+	return box.Box.PutMany(objects)
+}
+
+// Get reads a single object.
+//
+// Returns nil (and no error) in case the object with the given ID doesn't exist.
+func (box *WebhookCheckpointBox) Get(id uint64) (*WebhookCheckpoint, error) {
+	object, err := box.Box.Get(id)
+	if err != nil {
+		return nil, err
+	} else if object == nil {
+		return nil, nil
+	}
+	return object.(*WebhookCheckpoint), nil
+}
+
+// GetMany reads multiple objects at once.
+// If any of the objects doesn't exist, its position in the return slice is nil
+func (box *WebhookCheckpointBox) GetMany(ids ...uint64) ([]*WebhookCheckpoint, error) {
+	objects, err := box.Box.GetMany(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WebhookCheckpoint), nil
+}
+
+// GetManyExisting reads multiple objects at once, skipping those that do not exist.
+func (box *WebhookCheckpointBox) GetManyExisting(ids ...uint64) ([]*WebhookCheckpoint, error) {
+	objects, err := box.Box.GetManyExisting(ids...)
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WebhookCheckpoint), nil
+}
+
+// GetAll reads all stored objects
+func (box *WebhookCheckpointBox) GetAll() ([]*WebhookCheckpoint, error) {
+	objects, err := box.Box.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WebhookCheckpoint), nil
+}
+
+// Remove deletes a single object
+func (box *WebhookCheckpointBox) Remove(object *WebhookCheckpoint) error {
+	return box.Box.Remove(object)
+}
+
+// RemoveMany deletes multiple objects at once.
+// Returns the number of deleted object or error on failure.
+// Note that this method will not fail if an object is not found (e.g. already removed).
+// In case you need to strictly check whether all of the objects exist before removing them,
+// you can execute multiple box.Contains() and box.Remove() inside a single write transaction.
+func (box *WebhookCheckpointBox) RemoveMany(objects ...*WebhookCheckpoint) (uint64, error) {
+	var ids = make([]uint64, len(objects))
+	for k, object := range objects {
+		ids[k] = object.Id
+	}
+	return box.Box.RemoveIds(ids...)
+}
+
+// Creates a query with the given conditions. Use the fields of the WebhookCheckpoint_ struct to create conditions.
+// Keep the *WebhookCheckpointQuery if you intend to execute the query multiple times.
+// Note: this function panics if you try to create illegal queries; e.g. use properties of an alien type.
+// This is typically a programming error. Use QueryOrError instead if you want the explicit error check.
+func (box *WebhookCheckpointBox) Query(conditions ...objectbox.Condition) *WebhookCheckpointQuery {
+	return &WebhookCheckpointQuery{
+		box.Box.Query(conditions...),
+	}
+}
+
+// Creates a query with the given conditions. Use the fields of the WebhookCheckpoint_ struct to create conditions.
+// Keep the *WebhookCheckpointQuery if you intend to execute the query multiple times.
+func (box *WebhookCheckpointBox) QueryOrError(conditions ...objectbox.Condition) (*WebhookCheckpointQuery, error) {
+	if query, err := box.Box.QueryOrError(conditions...); err != nil {
+		return nil, err
+	} else {
+		return &WebhookCheckpointQuery{query}, nil
+	}
+}
+
+// Async provides access to the default Async Box for asynchronous operations. See WebhookCheckpointAsyncBox for more information.
+func (box *WebhookCheckpointBox) Async() *WebhookCheckpointAsyncBox {
+	return &WebhookCheckpointAsyncBox{AsyncBox: box.Box.Async()}
+}
+
+// WebhookCheckpointAsyncBox provides asynchronous operations on WebhookCheckpoint objects.
+//
+// Asynchronous operations are executed on a separate internal thread for better performance.
+//
+// There are two main use cases:
+//
+// 1) "execute & forget:" you gain faster put/remove operations as you don't have to wait for the transaction to finish.
+//
+// 2) Many small transactions: if your write load is typically a lot of individual puts that happen in parallel,
+// this will merge small transactions into bigger ones. This results in a significant gain in overall throughput.
+//
+// In situations with (extremely) high async load, an async method may be throttled (~1ms) or delayed up to 1 second.
+// In the unlikely event that the object could still not be enqueued (full queue), an error will be returned.
+//
+// Note that async methods do not give you hard durability guarantees like the synchronous Box provides.
+// There is a small time window in which the data may not have been committed durably yet.
+type WebhookCheckpointAsyncBox struct {
+	*objectbox.AsyncBox
+}
+
+// AsyncBoxForWebhookCheckpoint creates a new async box with the given operation timeout in case an async queue is full.
+// The returned struct must be freed explicitly using the Close() method.
+// It's usually preferable to use WebhookCheckpointBox::Async() which takes care of resource management and doesn't require closing.
+func AsyncBoxForWebhookCheckpoint(ob *objectbox.ObjectBox, timeoutMs uint64) *WebhookCheckpointAsyncBox {
+	var async, err = objectbox.NewAsyncBox(ob, 13, timeoutMs)
+	if err != nil {
+		panic("Could not create async box for entity ID 13: %s" + err.Error())
+	}
+	return &WebhookCheckpointAsyncBox{AsyncBox: async}
+}
+
+// Put inserts/updates a single object asynchronously.
+// When inserting a new object, the Id property on the passed object will be assigned the new ID the entity would hold
+// if the insert is ultimately successful. The newly assigned ID may not become valid if the insert fails.
+func (asyncBox *WebhookCheckpointAsyncBox) Put(object *WebhookCheckpoint) (uint64, error) {
+	return asyncBox.AsyncBox.Put(object)
+}
+
+// Insert a single object asynchronously.
+// The Id property on the passed object will be assigned the new ID the entity would hold if the insert is ultimately
+// successful. The newly assigned ID may not become valid if the insert fails.
+// Fails silently if an object with the same ID already exists (this error is not returned).
+func (asyncBox *WebhookCheckpointAsyncBox) Insert(object *WebhookCheckpoint) (id uint64, err error) {
+	return asyncBox.AsyncBox.Insert(object)
+}
+
+// Update a single object asynchronously.
+// The object must already exists or the update fails silently (without an error returned).
+func (asyncBox *WebhookCheckpointAsyncBox) Update(object *WebhookCheckpoint) error {
+	return asyncBox.AsyncBox.Update(object)
+}
+
+// Remove deletes a single object asynchronously.
+func (asyncBox *WebhookCheckpointAsyncBox) Remove(object *WebhookCheckpoint) error {
+	return asyncBox.AsyncBox.Remove(object)
+}
+
+// Query provides a way to search stored objects
+//
+// For example, you can find all WebhookCheckpoint which Id is either 42 or 47:
+//
+// box.Query(WebhookCheckpoint_.Id.In(42, 47)).Find()
+type WebhookCheckpointQuery struct {
+	*objectbox.Query
+}
+
+// Find returns all objects matching the query
+func (query *WebhookCheckpointQuery) Find() ([]*WebhookCheckpoint, error) {
+	objects, err := query.Query.Find()
+	if err != nil {
+		return nil, err
+	}
+	return objects.([]*WebhookCheckpoint), nil
+}
+
+// Offset defines the index of the first object to process (how many objects to skip)
+func (query *WebhookCheckpointQuery) Offset(offset uint64) *WebhookCheckpointQuery {
+	query.Query.Offset(offset)
+	return query
+}
+
+// Limit sets the number of elements to process by the query
+func (query *WebhookCheckpointQuery) Limit(limit uint64) *WebhookCheckpointQuery {
+	query.Query.Limit(limit)
+	return query
+}
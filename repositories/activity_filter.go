@@ -0,0 +1,251 @@
+package repositories
+
+import (
+	"fmt"
+	"go-rest-api/models"
+	"strings"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+// ActivityFilter is one clause of an activity search. Exactly one field must be
+// set: either a leaf condition or an "and"/"or" group of nested clauses.
+type ActivityFilter struct {
+	And       []ActivityFilter `json:"and,omitempty"`
+	Or        []ActivityFilter `json:"or,omitempty"`
+	Timestamp *TimeRange       `json:"timestamp,omitempty"`
+	Action    *SetMatch        `json:"action,omitempty"`
+	SourceIP  *PrefixMatch     `json:"source_ip,omitempty"`
+	Device    *GlobMatch       `json:"device,omitempty"`
+	Grid      *GlobMatch       `json:"grid,omitempty"`
+	// SourceIPMismatch matches activities whose claimed and derived source IPs
+	// do (true) or do not (false) disagree.
+	SourceIPMismatch *bool `json:"source_ip_mismatch,omitempty"`
+}
+
+// TimeRange matches timestamps in [From, To). Either bound may be omitted.
+type TimeRange struct {
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+}
+
+type SetMatch struct {
+	In []string `json:"in"`
+}
+
+type PrefixMatch struct {
+	Prefix string `json:"prefix"`
+}
+
+// GlobMatch supports '*' wildcards at the start and/or end of the pattern so
+// that it maps onto ObjectBox equals/prefix/suffix/contains conditions.
+type GlobMatch struct {
+	Glob string `json:"glob"`
+}
+
+// FilterError points at the clause of a filter that could not be translated.
+type FilterError struct {
+	Clause  string `json:"clause"`
+	Message string `json:"error"`
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Clause, e.Message)
+}
+
+// Condition translates the filter into an ObjectBox condition. A nil condition
+// with a nil error means the filter matches everything.
+func (f ActivityFilter) Condition() (objectbox.Condition, error) {
+	if f.IsEmpty() {
+		return nil, nil
+	}
+	return f.condition("filter")
+}
+
+// IsEmpty reports whether the filter has no clauses and so matches everything.
+func (f ActivityFilter) IsEmpty() bool {
+	return f.And == nil && f.Or == nil && f.Timestamp == nil && f.Action == nil &&
+		f.SourceIP == nil && f.Device == nil && f.Grid == nil && f.SourceIPMismatch == nil
+}
+
+func (f ActivityFilter) condition(path string) (objectbox.Condition, error) {
+	var set []string
+	if f.And != nil {
+		set = append(set, "and")
+	}
+	if f.Or != nil {
+		set = append(set, "or")
+	}
+	if f.Timestamp != nil {
+		set = append(set, "timestamp")
+	}
+	if f.Action != nil {
+		set = append(set, "action")
+	}
+	if f.SourceIP != nil {
+		set = append(set, "source_ip")
+	}
+	if f.Device != nil {
+		set = append(set, "device")
+	}
+	if f.Grid != nil {
+		set = append(set, "grid")
+	}
+	if f.SourceIPMismatch != nil {
+		set = append(set, "source_ip_mismatch")
+	}
+	switch len(set) {
+	case 0:
+		return nil, &FilterError{Clause: path, Message: "empty clause"}
+	case 1:
+	default:
+		return nil, &FilterError{Clause: path, Message: "clause must contain exactly one of " + strings.Join(set, ", ")}
+	}
+
+	switch {
+	case f.And != nil:
+		conditions, err := groupConditions(path+".and", f.And)
+		if err != nil {
+			return nil, err
+		}
+		return objectbox.All(conditions...), nil
+	case f.Or != nil:
+		conditions, err := groupConditions(path+".or", f.Or)
+		if err != nil {
+			return nil, err
+		}
+		return objectbox.Any(conditions...), nil
+	case f.Timestamp != nil:
+		return f.Timestamp.condition(path + ".timestamp")
+	case f.Action != nil:
+		if len(f.Action.In) == 0 {
+			return nil, &FilterError{Clause: path + ".action.in", Message: "must list at least one action"}
+		}
+		return models.DeviceActivity_.Action.In(true, f.Action.In...), nil
+	case f.SourceIP != nil:
+		if f.SourceIP.Prefix == "" {
+			return nil, &FilterError{Clause: path + ".source_ip.prefix", Message: "must not be empty"}
+		}
+		return models.DeviceActivity_.SourceIP.HasPrefix(f.SourceIP.Prefix, true), nil
+	case f.Device != nil:
+		return globCondition(path+".device.glob", models.DeviceActivity_.DeviceName, f.Device.Glob)
+	case f.SourceIPMismatch != nil:
+		return models.DeviceActivity_.SourceIPMismatch.Equals(*f.SourceIPMismatch), nil
+	default:
+		return globCondition(path+".grid.glob", models.DeviceActivity_.GridName, f.Grid.Glob)
+	}
+}
+
+func groupConditions(path string, clauses []ActivityFilter) ([]objectbox.Condition, error) {
+	if len(clauses) == 0 {
+		return nil, &FilterError{Clause: path, Message: "group must contain at least one clause"}
+	}
+	conditions := make([]objectbox.Condition, len(clauses))
+	for i, clause := range clauses {
+		condition, err := clause.condition(fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			return nil, err
+		}
+		conditions[i] = condition
+	}
+	return conditions, nil
+}
+
+func (r TimeRange) condition(path string) (objectbox.Condition, error) {
+	ts := models.DeviceActivity_.Timestamp
+	switch {
+	case r.From == nil && r.To == nil:
+		return nil, &FilterError{Clause: path, Message: "must set from and/or to"}
+	case r.From == nil:
+		return ts.LessThan(r.To.UnixMilli()), nil
+	case r.To == nil:
+		return ts.GreaterOrEqual(r.From.UnixMilli()), nil
+	case !r.From.Before(*r.To):
+		return nil, &FilterError{Clause: path, Message: "from must be before to"}
+	}
+	return objectbox.All(ts.GreaterOrEqual(r.From.UnixMilli()), ts.LessThan(r.To.UnixMilli())), nil
+}
+
+func globCondition(path string, property *objectbox.PropertyString, glob string) (objectbox.Condition, error) {
+	leading, trailing, literal := splitGlob(glob)
+	if literal == "" {
+		return nil, &FilterError{Clause: path, Message: "pattern must contain a literal part"}
+	}
+	if strings.ContainsAny(literal, "*?[") {
+		return nil, &FilterError{Clause: path, Message: "only leading or trailing '*' wildcards are supported"}
+	}
+
+	switch {
+	case leading && trailing:
+		return property.Contains(literal, true), nil
+	case leading:
+		return property.HasSuffix(literal, true), nil
+	case trailing:
+		return property.HasPrefix(literal, true), nil
+	}
+	return property.Equals(literal, true), nil
+}
+
+// Matches evaluates the filter against a single activity in memory, using the
+// same semantics as the ObjectBox condition. The filter must be valid.
+func (f ActivityFilter) Matches(activity models.DeviceActivity) bool {
+	switch {
+	case f.And != nil:
+		for _, clause := range f.And {
+			if !clause.Matches(activity) {
+				return false
+			}
+		}
+		return true
+	case f.Or != nil:
+		for _, clause := range f.Or {
+			if clause.Matches(activity) {
+				return true
+			}
+		}
+		return false
+	case f.Timestamp != nil:
+		ts := activity.Timestamp.UnixMilli()
+		if f.Timestamp.From != nil && ts < f.Timestamp.From.UnixMilli() {
+			return false
+		}
+		return f.Timestamp.To == nil || ts < f.Timestamp.To.UnixMilli()
+	case f.Action != nil:
+		for _, action := range f.Action.In {
+			if activity.Action == action {
+				return true
+			}
+		}
+		return false
+	case f.SourceIP != nil:
+		return strings.HasPrefix(activity.SourceIP, f.SourceIP.Prefix)
+	case f.Device != nil:
+		return globMatches(f.Device.Glob, activity.DeviceName)
+	case f.Grid != nil:
+		return globMatches(f.Grid.Glob, activity.GridName)
+	case f.SourceIPMismatch != nil:
+		return activity.SourceIPMismatch == *f.SourceIPMismatch
+	}
+	return true
+}
+
+func globMatches(glob, value string) bool {
+	leading, trailing, literal := splitGlob(glob)
+	switch {
+	case leading && trailing:
+		return strings.Contains(value, literal)
+	case leading:
+		return strings.HasSuffix(value, literal)
+	case trailing:
+		return strings.HasPrefix(value, literal)
+	}
+	return value == literal
+}
+
+func splitGlob(glob string) (leading, trailing bool, literal string) {
+	leading = strings.HasPrefix(glob, "*")
+	trailing = strings.HasSuffix(glob, "*")
+	literal = strings.TrimSuffix(strings.TrimPrefix(glob, "*"), "*")
+	return leading, trailing, literal
+}
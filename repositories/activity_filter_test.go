@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"go-rest-api/models"
+	"testing"
+	"time"
+)
+
+func parseFilter(t *testing.T, raw string) ActivityFilter {
+	t.Helper()
+	var filter ActivityFilter
+	if err := json.Unmarshal([]byte(raw), &filter); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", raw, err)
+	}
+	return filter
+}
+
+func TestFilterCondition(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		clause string
+	}{
+		{"empty matches everything", `{}`, ""},
+		{"action", `{"action":{"in":["login","logout"]}}`, ""},
+		{"source ip prefix", `{"source_ip":{"prefix":"10."}}`, ""},
+		{"exact device", `{"device":{"glob":"sensor-1"}}`, ""},
+		{"device prefix", `{"device":{"glob":"sensor-*"}}`, ""},
+		{"grid suffix", `{"grid":{"glob":"*-north"}}`, ""},
+		{"grid contains", `{"grid":{"glob":"*north*"}}`, ""},
+		{"mismatch", `{"source_ip_mismatch":false}`, ""},
+		{"open ended range", `{"timestamp":{"from":"2026-01-01T00:00:00Z"}}`, ""},
+		{"range", `{"timestamp":{"from":"2026-01-01T00:00:00Z","to":"2026-01-02T00:00:00Z"}}`, ""},
+		{"nested groups", `{"and":[{"or":[{"device":{"glob":"a"}},{"device":{"glob":"b*"}}]},{"action":{"in":["x"]}}]}`, ""},
+		{"two fields", `{"action":{"in":["x"]},"device":{"glob":"a"}}`, "filter"},
+		{"empty nested clause", `{"and":[{}]}`, "filter.and[0]"},
+		{"empty group", `{"or":[]}`, "filter.or"},
+		{"no actions", `{"and":[{"device":{"glob":"a"}},{"action":{"in":[]}}]}`, "filter.and[1].action.in"},
+		{"empty prefix", `{"source_ip":{"prefix":""}}`, "filter.source_ip.prefix"},
+		{"wildcard only", `{"device":{"glob":"**"}}`, "filter.device.glob"},
+		{"inner wildcard", `{"grid":{"glob":"a*b"}}`, "filter.grid.glob"},
+		{"character class", `{"grid":{"glob":"a[bc]"}}`, "filter.grid.glob"},
+		{"range without bounds", `{"timestamp":{}}`, "filter.timestamp"},
+		{"empty range", `{"timestamp":{"from":"2026-01-02T00:00:00Z","to":"2026-01-02T00:00:00Z"}}`, "filter.timestamp"},
+		{"reversed range", `{"or":[{"timestamp":{"from":"2026-01-03T00:00:00Z","to":"2026-01-02T00:00:00Z"}}]}`, "filter.or[0].timestamp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseFilter(t, tt.filter).Condition()
+			if tt.clause == "" {
+				if err != nil {
+					t.Fatalf("Condition() error = %v", err)
+				}
+				return
+			}
+			var filterErr *FilterError
+			if !errors.As(err, &filterErr) {
+				t.Fatalf("Condition() error = %v, want a FilterError", err)
+			}
+			if filterErr.Clause != tt.clause {
+				t.Errorf("error clause = %q, want %q (%v)", filterErr.Clause, tt.clause, err)
+			}
+		})
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	activity := models.DeviceActivity{
+		DeviceName: "sensor-12",
+		GridName:   "grid-north",
+		Action:     "login",
+		SourceIP:   "10.1.2.3",
+		Timestamp:  time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{`{}`, true},
+		{`{"device":{"glob":"sensor-12"}}`, true},
+		{`{"device":{"glob":"sensor-1"}}`, false},
+		{`{"device":{"glob":"sensor-*"}}`, true},
+		{`{"device":{"glob":"*-12"}}`, true},
+		{`{"grid":{"glob":"*nor*"}}`, true},
+		{`{"grid":{"glob":"north*"}}`, false},
+		{`{"action":{"in":["logout","login"]}}`, true},
+		{`{"action":{"in":["logout"]}}`, false},
+		{`{"source_ip":{"prefix":"10.1."}}`, true},
+		{`{"source_ip":{"prefix":"10.2."}}`, false},
+		{`{"source_ip_mismatch":false}`, true},
+		{`{"source_ip_mismatch":true}`, false},
+		{`{"timestamp":{"from":"2026-01-01T12:00:00Z"}}`, true},
+		{`{"timestamp":{"to":"2026-01-01T12:00:00Z"}}`, false},
+		{`{"timestamp":{"from":"2026-01-01T00:00:00Z","to":"2026-01-01T12:00:00.001Z"}}`, true},
+		{`{"and":[{"device":{"glob":"sensor-*"}},{"action":{"in":["logout"]}}]}`, false},
+		{`{"or":[{"device":{"glob":"other"}},{"action":{"in":["login"]}}]}`, true},
+		{`{"or":[{"device":{"glob":"other"}},{"and":[{"grid":{"glob":"grid-*"}},{"action":{"in":["x"]}}]}]}`, false},
+	}
+	for _, tt := range tests {
+		if got := parseFilter(t, tt.filter).Matches(activity); got != tt.want {
+			t.Errorf("Matches(%s) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"fmt"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+// ActivityField names a string property of DeviceActivity that can be projected.
+type ActivityField string
+
+const (
+	FieldGrid     ActivityField = "grid"
+	FieldDevice   ActivityField = "device"
+	FieldAction   ActivityField = "action"
+	FieldSourceIP ActivityField = "source_ip"
+)
+
+// ParseActivityField validates a field name used for grouping.
+func ParseActivityField(name string) (ActivityField, error) {
+	switch field := ActivityField(name); field {
+	case FieldGrid, FieldDevice, FieldAction, FieldSourceIP:
+		return field, nil
+	}
+	return "", fmt.Errorf("unknown field %q: expected %s, %s, %s or %s", name, FieldGrid, FieldDevice, FieldAction, FieldSourceIP)
+}
+
+func (f ActivityField) property() *objectbox.PropertyString {
+	switch f {
+	case FieldGrid:
+		return models.DeviceActivity_.GridName
+	case FieldDevice:
+		return models.DeviceActivity_.DeviceName
+	case FieldAction:
+		return models.DeviceActivity_.Action
+	}
+	return models.DeviceActivity_.SourceIP
+}
+
+// Value returns the field's value on a single activity.
+func (f ActivityField) Value(activity models.DeviceActivity) string {
+	switch f {
+	case FieldGrid:
+		return activity.GridName
+	case FieldDevice:
+		return activity.DeviceName
+	case FieldAction:
+		return activity.Action
+	}
+	return activity.SourceIP
+}
+
+// ActivityProjection holds the timestamps (in Unix milliseconds) of matching
+// activities and, for every requested field, a column of values aligned with them.
+type ActivityProjection struct {
+	Timestamps []int64
+	Columns    [][]string
+}
+
+// Project reads only the Timestamp and the requested string properties of the
+// activities matching filter, without loading whole DeviceActivity objects.
+// All columns are read in one transaction so they stay aligned.
+func (r *ActivityRepository) Project(filter ActivityFilter, fields []ActivityField) (ActivityProjection, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("project", "activity").Observe(duration)
+	}()
+
+	condition, err := filter.Condition()
+	if err != nil {
+		return ActivityProjection{}, err
+	}
+	conditions := []objectbox.Condition{notDeleted()}
+	if condition != nil {
+		conditions = append(conditions, condition)
+	}
+
+	query, err := r.box.QueryOrError(conditions...)
+	if err != nil {
+		return ActivityProjection{}, err
+	}
+	defer query.Close()
+
+	projection := ActivityProjection{Columns: make([][]string, len(fields))}
+	err = r.ob.RunInReadTx(func() error {
+		timestampQuery, err := query.PropertyOrError(models.DeviceActivity_.Timestamp)
+		if err != nil {
+			return err
+		}
+		defer timestampQuery.Close()
+
+		timestamps, err := timestampQuery.FindInt64s(new(int64))
+		if err != nil {
+			return err
+		}
+		projection.Timestamps = timestamps
+
+		for i, field := range fields {
+			column, err := projectStrings(query, field.property())
+			if err != nil {
+				return err
+			}
+			if len(column) != len(timestamps) {
+				return fmt.Errorf("projection of %s returned %d values for %d activities", field, len(column), len(timestamps))
+			}
+			projection.Columns[i] = column
+		}
+		return nil
+	})
+	if err != nil {
+		return ActivityProjection{}, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("project", "activity").Inc()
+	return projection, nil
+}
+
+func projectStrings(query *models.DeviceActivityQuery, property *objectbox.PropertyString) ([]string, error) {
+	propertyQuery, err := query.PropertyOrError(property)
+	if err != nil {
+		return nil, err
+	}
+	defer propertyQuery.Close()
+
+	empty := ""
+	return propertyQuery.FindStrings(&empty)
+}
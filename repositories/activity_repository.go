@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"encoding/json"
+	"errors"
 	"go-rest-api/metrics"
 	"go-rest-api/models"
 	"time"
@@ -8,17 +10,68 @@ import (
 	"github.com/objectbox/objectbox-go/objectbox"
 )
 
+// ErrActivityNotFound is returned when an activity does not exist or is not
+// in the state an operation requires, e.g. restoring one that is not in the trash.
+var ErrActivityNotFound = errors.New("activity not found")
+
+// notDeleted matches activities that are not in the trash. Live activities
+// store either the zero time or 0 as DeletedAt, both of which are <= 0.
+func notDeleted() objectbox.Condition {
+	return models.DeviceActivity_.DeletedAt.LessOrEqual(0)
+}
+
+// inTrash matches soft-deleted activities.
+func inTrash() objectbox.Condition {
+	return models.DeviceActivity_.DeletedAt.GreaterThan(0)
+}
+
+// ActivityPage is a single page of activities and the cursor for the page after it.
+// NextCursor is empty when there are no more results.
+type ActivityPage struct {
+	Activities []models.DeviceActivity
+	NextCursor string
+}
+
 type ActivityRepository struct {
-	box *models.DeviceActivityBox
+	ob          *objectbox.ObjectBox
+	box         *models.DeviceActivityBox
+	devices     *DeviceRepository
+	keys        *IdempotencyRepository
+	changes     *ChangelogRepository
+	createHooks []func(models.DeviceActivity)
 }
 
 func NewActivityRepository(ob *objectbox.ObjectBox) *ActivityRepository {
 	box := models.BoxForDeviceActivity(ob)
-	repo := &ActivityRepository{box: box}
+	repo := &ActivityRepository{
+		ob:      ob,
+		box:     box,
+		devices: NewDeviceRepository(ob),
+		keys:    NewIdempotencyRepository(ob),
+		changes: NewChangelogRepository(ob),
+	}
 	repo.updateMetrics()
 	return repo
 }
 
+// OnCreate registers a hook that is called with every activity after it has been stored.
+// Hooks run synchronously on the write path and must not block.
+func (r *ActivityRepository) OnCreate(hook func(models.DeviceActivity)) {
+	r.createHooks = append(r.createHooks, hook)
+}
+
+func (r *ActivityRepository) publishCreated(activity models.DeviceActivity) {
+	for _, hook := range r.createHooks {
+		hook(activity)
+	}
+}
+
+// Changelog returns the changelog every mutation of this repository is recorded in.
+func (r *ActivityRepository) Changelog() *ChangelogRepository {
+	return r.changes
+}
+
+// updateMetrics exports the number of stored activities, trash included.
 func (r *ActivityRepository) updateMetrics() {
 	count, err := r.box.Count()
 	if err == nil {
@@ -33,16 +86,268 @@ func (r *ActivityRepository) Create(activity models.DeviceActivity) error {
 		metrics.ObjectBoxOperationDuration.WithLabelValues("create", "activity").Observe(duration)
 	}()
 
-	_, err := r.box.Put(&activity)
+	// The device is registered in the same transaction as the activity.
+	err := r.ob.RunInWriteTx(func() error {
+		if err := r.devices.touch([]*models.DeviceActivity{&activity}); err != nil {
+			return err
+		}
+		if _, err := r.box.Put(&activity); err != nil {
+			return err
+		}
+		return r.changes.record(models.ChangeCreated, start, &activity)
+	})
 	if err != nil {
 		return err
 	}
 
 	metrics.ObjectBoxOperationsTotal.WithLabelValues("create", "activity").Inc()
 	r.updateMetrics()
+	r.changes.committed()
+	r.publishCreated(activity)
 	return nil
 }
 
+// CreateIdempotent stores activity like Create unless req.Key was already used
+// within its TTL. In that case nothing is created and the remembered response
+// is returned instead. Otherwise activity receives its new Id, the activity is
+// remembered as the response under req.Key and nil is returned.
+func (r *ActivityRepository) CreateIdempotent(activity *models.DeviceActivity, req IdempotencyRequest) (*models.IdempotencyKey, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("create_idempotent", "activity").Observe(duration)
+	}()
+
+	var replay *models.IdempotencyKey
+	err := r.ob.RunInWriteTx(func() error {
+		var err error
+		if replay, err = r.keys.lookup(req, start); err != nil || replay != nil {
+			return err
+		}
+		if err := r.devices.touch([]*models.DeviceActivity{activity}); err != nil {
+			return err
+		}
+		if _, err := r.box.Put(activity); err != nil {
+			return err
+		}
+		if err := r.changes.record(models.ChangeCreated, start, activity); err != nil {
+			return err
+		}
+		response, err := json.Marshal(activity)
+		if err != nil {
+			return err
+		}
+		return r.keys.remember(req, activity.Id, string(response), start)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if replay != nil {
+		metrics.ObjectBoxOperationsTotal.WithLabelValues("replay", "activity").Inc()
+		return replay, nil
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("create", "activity").Inc()
+	r.updateMetrics()
+	r.keys.updateMetrics()
+	r.changes.committed()
+	r.publishCreated(*activity)
+	return nil, nil
+}
+
+// CreateResult is the outcome of one activity of CreateMany.
+type CreateResult struct {
+	Id       uint64
+	UniqueId string
+	// Duplicate is set when the activity repeats an idempotent create, from
+	// an earlier request or earlier in the same batch. Id and UniqueId are
+	// those of the original, and nothing is stored.
+	Duplicate bool
+	// Err is ErrIdempotencyKeyMismatch when the key was used for a different activity.
+	Err error
+}
+
+// CreateMany stores activities in a single transaction. Activities whose
+// request has a Key are deduplicated like CreateIdempotent; requests is either
+// nil or holds one request per activity. With allOrNothing a key mismatch rolls back the whole
+// batch and returns ErrIdempotencyKeyMismatch together with the results that
+// tell which activities caused it.
+func (r *ActivityRepository) CreateMany(activities []*models.DeviceActivity, requests []IdempotencyRequest, allOrNothing bool) ([]CreateResult, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("create_many", "activity").Observe(duration)
+	}()
+
+	var results []CreateResult
+	var created []*models.DeviceActivity
+	err := r.ob.RunInWriteTx(func() error {
+		results = make([]CreateResult, len(activities))
+		created = make([]*models.DeviceActivity, 0, len(activities))
+		createdIndex := make([]int, 0, len(activities))
+		// firstByKey is the batch index of the first activity with each key.
+		firstByKey := make(map[string]int)
+		var duplicates []int
+		mismatch := false
+
+		for i, activity := range activities {
+			var req IdempotencyRequest
+			if requests != nil {
+				req = requests[i]
+			}
+			if req.Key != "" {
+				if first, ok := firstByKey[req.Key]; ok {
+					if requests[first].RequestHash != req.RequestHash {
+						results[i].Err = ErrIdempotencyKeyMismatch
+						mismatch = true
+					} else {
+						results[i].Duplicate = true
+						duplicates = append(duplicates, i)
+					}
+					continue
+				}
+				replay, err := r.keys.lookup(req, start)
+				if errors.Is(err, ErrIdempotencyKeyMismatch) {
+					results[i].Err = err
+					mismatch = true
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				if replay != nil {
+					var original models.DeviceActivity
+					if err := json.Unmarshal([]byte(replay.Response), &original); err != nil {
+						return err
+					}
+					results[i] = CreateResult{Id: replay.ActivityId, UniqueId: original.UniqueId, Duplicate: true}
+					continue
+				}
+				firstByKey[req.Key] = i
+			}
+			created = append(created, activity)
+			createdIndex = append(createdIndex, i)
+		}
+		if mismatch && allOrNothing {
+			return ErrIdempotencyKeyMismatch
+		}
+
+		if err := r.devices.touch(created); err != nil {
+			return err
+		}
+		ids, err := r.box.PutMany(created)
+		if err != nil {
+			return err
+		}
+		if err := r.changes.record(models.ChangeCreated, start, created...); err != nil {
+			return err
+		}
+		for n, i := range createdIndex {
+			results[i].Id, results[i].UniqueId = ids[n], created[n].UniqueId
+			if requests == nil || requests[i].Key == "" {
+				continue
+			}
+			response, err := json.Marshal(created[n])
+			if err != nil {
+				return err
+			}
+			if err := r.keys.remember(requests[i], ids[n], string(response), start); err != nil {
+				return err
+			}
+		}
+		for _, i := range duplicates {
+			first := results[firstByKey[requests[i].Key]]
+			results[i].Id, results[i].UniqueId = first.Id, first.UniqueId
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrIdempotencyKeyMismatch) {
+			return results, err
+		}
+		return nil, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("create_many", "activity").Inc()
+	r.updateMetrics()
+	if requests != nil {
+		r.keys.updateMetrics()
+	}
+	r.changes.committed()
+	for _, activity := range created {
+		r.publishCreated(*activity)
+	}
+	return results, nil
+}
+
+// UpdateMany rewrites existing activities in a single transaction. Unlike
+// CreateMany it does not notify create hooks.
+func (r *ActivityRepository) UpdateMany(activities []*models.DeviceActivity) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("update_many", "activity").Observe(duration)
+	}()
+
+	err := r.ob.RunInWriteTx(func() error {
+		if _, err := r.box.PutMany(activities); err != nil {
+			return err
+		}
+		return r.changes.record(models.ChangeUpdated, start, activities...)
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("update_many", "activity").Inc()
+	r.changes.committed()
+	return nil
+}
+
+// LinkDevices registers devices for up to limit activities stored before the
+// device registry existed and links them. It returns how many were linked.
+func (r *ActivityRepository) LinkDevices(limit int) (int, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("link_devices", "activity").Observe(duration)
+	}()
+
+	var linked int
+	err := r.ob.RunInWriteTx(func() error {
+		query, err := r.box.QueryOrError(
+			models.DeviceActivity_.DeviceId.Equals(0),
+			models.DeviceActivity_.DeviceName.NotEquals("", true),
+		)
+		if err != nil {
+			return err
+		}
+		defer query.Close()
+
+		activities, err := query.Limit(uint64(limit)).Find()
+		if err != nil || len(activities) == 0 {
+			return err
+		}
+		if err := r.devices.touch(activities); err != nil {
+			return err
+		}
+		if _, err := r.box.PutMany(activities); err != nil {
+			return err
+		}
+		linked = len(activities)
+		return r.changes.record(models.ChangeUpdated, start, activities...)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("link_devices", "activity").Inc()
+	if linked > 0 {
+		r.changes.committed()
+	}
+	return linked, nil
+}
+
 func (r *ActivityRepository) GetAll() ([]models.DeviceActivity, error) {
 	start := time.Now()
 	defer func() {
@@ -50,7 +355,13 @@ func (r *ActivityRepository) GetAll() ([]models.DeviceActivity, error) {
 		metrics.ObjectBoxOperationDuration.WithLabelValues("get_all", "activity").Observe(duration)
 	}()
 
-	results, err := r.box.GetAll()
+	query, err := r.box.QueryOrError(notDeleted())
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Find()
 	if err != nil {
 		return nil, err
 	}
@@ -64,37 +375,148 @@ func (r *ActivityRepository) GetAll() ([]models.DeviceActivity, error) {
 	return activities, nil
 }
 
-func (r *ActivityRepository) GetByGrid(gridName string) ([]models.DeviceActivity, error) {
+func (r *ActivityRepository) List(page PageRequest) (ActivityPage, error) {
 	start := time.Now()
 	defer func() {
 		duration := time.Since(start).Seconds()
-		metrics.ObjectBoxOperationDuration.WithLabelValues("get_by_grid", "activity").Observe(duration)
+		metrics.ObjectBoxOperationDuration.WithLabelValues("list", "activity").Observe(duration)
 	}()
 
-	query := r.box.Query(models.DeviceActivity_.GridName.Equals(gridName, true))
-	results, err := query.Find()
+	result, err := r.findPage(page, notDeleted())
 	if err != nil {
-		return nil, err
+		return ActivityPage{}, err
 	}
 
-	activities := make([]models.DeviceActivity, len(results))
-	for i, result := range results {
-		activities[i] = *result
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("list", "activity").Inc()
+	return result, nil
+}
+
+func (r *ActivityRepository) GetByGrid(gridName string, page PageRequest) (ActivityPage, error) {
+	return r.GetByGrids([]string{gridName}, page)
+}
+
+// GetByGrids returns activities in any of the grids, e.g. a grid and its descendants.
+func (r *ActivityRepository) GetByGrids(gridNames []string, page PageRequest) (ActivityPage, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_by_grid", "activity").Observe(duration)
+	}()
+
+	result, err := r.findPage(page, notDeleted(), models.DeviceActivity_.GridName.In(true, gridNames...))
+	if err != nil {
+		return ActivityPage{}, err
 	}
 
 	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_by_grid", "activity").Inc()
-	return activities, nil
+	return result, nil
 }
 
-func (r *ActivityRepository) GetByDevice(deviceName string) ([]models.DeviceActivity, error) {
+func (r *ActivityRepository) GetByDevice(deviceName string, page PageRequest) (ActivityPage, error) {
 	start := time.Now()
 	defer func() {
 		duration := time.Since(start).Seconds()
 		metrics.ObjectBoxOperationDuration.WithLabelValues("get_by_device", "activity").Observe(duration)
 	}()
 
-	query := r.box.Query(models.DeviceActivity_.DeviceName.Equals(deviceName, true))
-	results, err := query.Find()
+	result, err := r.findPage(page, notDeleted(), models.DeviceActivity_.DeviceName.Equals(deviceName, true))
+	if err != nil {
+		return ActivityPage{}, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_by_device", "activity").Inc()
+	return result, nil
+}
+
+// Search returns a page of activities matching the filter. The filter is run
+// inside ObjectBox; a *FilterError is returned if it cannot be translated.
+func (r *ActivityRepository) Search(filter ActivityFilter, page PageRequest) (ActivityPage, error) {
+	return r.search(filter, page, false)
+}
+
+// SearchWithDeleted is Search including activities in the trash.
+func (r *ActivityRepository) SearchWithDeleted(filter ActivityFilter, page PageRequest) (ActivityPage, error) {
+	return r.search(filter, page, true)
+}
+
+func (r *ActivityRepository) search(filter ActivityFilter, page PageRequest, withDeleted bool) (ActivityPage, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("search", "activity").Observe(duration)
+	}()
+
+	conditions, err := matchingConditions(filter, withDeleted)
+	if err != nil {
+		return ActivityPage{}, err
+	}
+	result, err := r.findPage(page, conditions...)
+	if err != nil {
+		return ActivityPage{}, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("search", "activity").Inc()
+	return result, nil
+}
+
+// matchingConditions translates filter, restricted to activities outside the
+// trash unless withDeleted is set.
+func matchingConditions(filter ActivityFilter, withDeleted bool) ([]objectbox.Condition, error) {
+	condition, err := filter.Condition()
+	if err != nil {
+		return nil, err
+	}
+	var conditions []objectbox.Condition
+	if !withDeleted {
+		conditions = append(conditions, notDeleted())
+	}
+	if condition != nil {
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// GetAfterId returns up to limit activities with an Id greater than afterId in
+// Id order, optionally restricted by a filter. It is used to replay missed events.
+func (r *ActivityRepository) GetAfterId(afterId uint64, filter ActivityFilter, limit int) ([]models.DeviceActivity, error) {
+	return r.getAfterId(afterId, filter, limit, false)
+}
+
+// GetAfterIdWithDeleted is GetAfterId including activities in the trash, for
+// maintenance jobs that must rewrite every stored row.
+func (r *ActivityRepository) GetAfterIdWithDeleted(afterId uint64, filter ActivityFilter, limit int) ([]models.DeviceActivity, error) {
+	return r.getAfterId(afterId, filter, limit, true)
+}
+
+func (r *ActivityRepository) getAfterId(afterId uint64, filter ActivityFilter, limit int, withDeleted bool) ([]models.DeviceActivity, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_after_id", "activity").Observe(duration)
+	}()
+
+	condition, err := filter.Condition()
+	if err != nil {
+		return nil, err
+	}
+	conditions := []objectbox.Condition{
+		models.DeviceActivity_.Id.GreaterThan(afterId),
+		models.DeviceActivity_.Id.OrderAsc(),
+	}
+	if !withDeleted {
+		conditions = append(conditions, notDeleted())
+	}
+	if condition != nil {
+		conditions = append(conditions, condition)
+	}
+
+	query, err := r.box.QueryOrError(conditions...)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(uint64(limit)).Find()
 	if err != nil {
 		return nil, err
 	}
@@ -104,10 +526,229 @@ func (r *ActivityRepository) GetByDevice(deviceName string) ([]models.DeviceActi
 		activities[i] = *result
 	}
 
-	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_by_device", "activity").Inc()
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_after_id", "activity").Inc()
 	return activities, nil
 }
 
+// PruneScope restricts which grids RemoveOlderThan looks at. With Grid set only
+// that grid is pruned; otherwise every grid except ExcludeGrids is.
+type PruneScope struct {
+	Grid         string
+	ExcludeGrids []string
+}
+
+// RemoveOlderThan deletes up to limit of the oldest activities with a Timestamp
+// before cutoff and returns how many were removed. Each call is its own
+// transaction so callers can prune in bounded batches.
+func (r *ActivityRepository) RemoveOlderThan(cutoff time.Time, scope PruneScope, limit int) (uint64, error) {
+	conditions := []objectbox.Condition{
+		models.DeviceActivity_.Timestamp.LessThan(cutoff.UnixMilli()),
+		models.DeviceActivity_.Timestamp.OrderAsc(),
+	}
+	if scope.Grid != "" {
+		conditions = append(conditions, models.DeviceActivity_.GridName.Equals(scope.Grid, true))
+	}
+	for _, grid := range scope.ExcludeGrids {
+		conditions = append(conditions, models.DeviceActivity_.GridName.NotEquals(grid, true))
+	}
+	return r.removeBatch("prune", conditions, limit)
+}
+
+// RemoveOldest deletes up to limit of the oldest activities by Timestamp.
+func (r *ActivityRepository) RemoveOldest(limit int) (uint64, error) {
+	return r.removeBatch("prune", []objectbox.Condition{models.DeviceActivity_.Timestamp.OrderAsc()}, limit)
+}
+
+func (r *ActivityRepository) removeBatch(operation string, conditions []objectbox.Condition, limit int) (uint64, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues(operation, "activity").Observe(duration)
+	}()
+
+	var removed uint64
+	err := r.ob.RunInWriteTx(func() error {
+		query, err := r.box.QueryOrError(conditions...)
+		if err != nil {
+			return err
+		}
+		defer query.Close()
+
+		activities, err := query.Limit(uint64(limit)).Find()
+		if err != nil || len(activities) == 0 {
+			return err
+		}
+		ids := make([]uint64, len(activities))
+		for i, activity := range activities {
+			ids[i] = activity.Id
+		}
+		if removed, err = r.box.RemoveIds(ids...); err != nil {
+			return err
+		}
+		return r.changes.record(models.ChangePurged, start, activities...)
+	})
+	if err != nil || removed == 0 {
+		return 0, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues(operation, "activity").Inc()
+	r.updateMetrics()
+	r.changes.committed()
+	return removed, nil
+}
+
+// RemoveExpiredKeys deletes up to limit idempotency keys that expired before now.
+func (r *ActivityRepository) RemoveExpiredKeys(now time.Time, limit int) (uint64, error) {
+	return r.keys.RemoveExpired(now, limit)
+}
+
+// CountWithDeleted returns the number of stored activities, including those
+// in the trash, which take up storage until they are purged.
+func (r *ActivityRepository) CountWithDeleted() (uint64, error) {
+	return r.box.Count()
+}
+
+// CountByGrids returns the number of activities in any of the grids.
+func (r *ActivityRepository) CountByGrids(gridNames []string) (uint64, error) {
+	query, err := r.box.QueryOrError(notDeleted(), models.DeviceActivity_.GridName.In(true, gridNames...))
+	if err != nil {
+		return 0, err
+	}
+	defer query.Close()
+	return query.Count()
+}
+
+// CountByGridAndDevice returns the number of activities per grid and device.
+// It reads the distinct grids and their distinct devices with property
+// queries and counts every pair on the indexes, without loading activities.
+func (r *ActivityRepository) CountByGridAndDevice() (map[string]map[string]uint64, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("count_by_grid", "activity").Observe(duration)
+	}()
+
+	grids, err := r.box.QueryOrError(notDeleted())
+	if err != nil {
+		return nil, err
+	}
+	defer grids.Close()
+	devices, err := r.box.QueryOrError(notDeleted(), models.DeviceActivity_.GridName.Equals("", true))
+	if err != nil {
+		return nil, err
+	}
+	defer devices.Close()
+	pairs, err := r.box.QueryOrError(notDeleted(), models.DeviceActivity_.GridName.Equals("", true),
+		models.DeviceActivity_.DeviceName.Equals("", true))
+	if err != nil {
+		return nil, err
+	}
+	defer pairs.Close()
+
+	counts := make(map[string]map[string]uint64)
+	err = r.ob.RunInReadTx(func() error {
+		gridNames, err := distinctStrings(grids, models.DeviceActivity_.GridName)
+		if err != nil {
+			return err
+		}
+		for _, grid := range gridNames {
+			if err := devices.SetStringParams(models.DeviceActivity_.GridName, grid); err != nil {
+				return err
+			}
+			deviceNames, err := distinctStrings(devices, models.DeviceActivity_.DeviceName)
+			if err != nil {
+				return err
+			}
+			counts[grid] = make(map[string]uint64, len(deviceNames))
+			for _, device := range deviceNames {
+				if err := pairs.SetStringParams(models.DeviceActivity_.GridName, grid); err != nil {
+					return err
+				}
+				if err := pairs.SetStringParams(models.DeviceActivity_.DeviceName, device); err != nil {
+					return err
+				}
+				count, err := pairs.Count()
+				if err != nil {
+					return err
+				}
+				counts[grid][device] = count
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("count_by_grid", "activity").Inc()
+	return counts, nil
+}
+
+// distinctStrings returns the distinct, case-sensitive values of property
+// among the activities matching query.
+func distinctStrings(query *models.DeviceActivityQuery, property *objectbox.PropertyString) ([]string, error) {
+	propertyQuery, err := query.PropertyOrError(property)
+	if err != nil {
+		return nil, err
+	}
+	defer propertyQuery.Close()
+
+	if err := propertyQuery.DistinctString(true, true); err != nil {
+		return nil, err
+	}
+	empty := ""
+	return propertyQuery.FindStrings(&empty)
+}
+
+// OldestTimestampWithDeleted returns the smallest stored Timestamp, including
+// activities in the trash, or false if the box is empty.
+func (r *ActivityRepository) OldestTimestampWithDeleted() (time.Time, bool, error) {
+	query, err := r.box.QueryOrError(models.DeviceActivity_.Timestamp.OrderAsc())
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(1).Find()
+	if err != nil || len(results) == 0 {
+		return time.Time{}, false, err
+	}
+	return results[0].Timestamp, true, nil
+}
+
+// LowestIdWithDeleted returns the smallest stored Id, including activities in
+// the trash, or false if the box is empty.
+func (r *ActivityRepository) LowestIdWithDeleted() (uint64, bool, error) {
+	query, err := r.box.QueryOrError(models.DeviceActivity_.Id.OrderAsc())
+	if err != nil {
+		return 0, false, err
+	}
+	defer query.Close()
+
+	ids, err := query.Limit(1).FindIds()
+	if err != nil || len(ids) == 0 {
+		return 0, false, err
+	}
+	return ids[0], true, nil
+}
+
+// findByUniqueId returns the activity with the given UniqueId, or nil.
+func (r *ActivityRepository) findByUniqueId(uniqueId string) (*models.DeviceActivity, error) {
+	query, err := r.box.QueryOrError(models.DeviceActivity_.UniqueId.Equals(uniqueId, true))
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(1).Find()
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// Delete moves an activity to the trash by setting DeletedAt. It stays
+// restorable until the trash is purged.
 func (r *ActivityRepository) Delete(uniqueId string) error {
 	start := time.Now()
 	defer func() {
@@ -115,18 +756,254 @@ func (r *ActivityRepository) Delete(uniqueId string) error {
 		metrics.ObjectBoxOperationDuration.WithLabelValues("delete", "activity").Observe(duration)
 	}()
 
-	query := r.box.Query(models.DeviceActivity_.UniqueId.Equals(uniqueId, true))
-	results, err := query.Find()
-	if err != nil || len(results) == 0 {
+	err := r.ob.RunInWriteTx(func() error {
+		activity, err := r.findByUniqueId(uniqueId)
+		if err != nil {
+			return err
+		}
+		if activity == nil || activity.IsDeleted() {
+			return ErrActivityNotFound
+		}
+		activity.DeletedAt = start
+		if _, err := r.box.Put(activity); err != nil {
+			return err
+		}
+		return r.changes.record(models.ChangeDeleted, start, activity)
+	})
+	if err != nil {
 		return err
 	}
 
-	err = r.box.Remove(results[0])
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("delete", "activity").Inc()
+	r.changes.committed()
+	return nil
+}
+
+// Restore takes an activity out of the trash and returns it.
+func (r *ActivityRepository) Restore(uniqueId string) (*models.DeviceActivity, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("restore", "activity").Observe(duration)
+	}()
+
+	var activity *models.DeviceActivity
+	err := r.ob.RunInWriteTx(func() error {
+		var err error
+		if activity, err = r.findByUniqueId(uniqueId); err != nil {
+			return err
+		}
+		if activity == nil || !activity.IsDeleted() {
+			return ErrActivityNotFound
+		}
+		activity.DeletedAt = time.Time{}
+		if _, err := r.box.Put(activity); err != nil {
+			return err
+		}
+		return r.changes.record(models.ChangeRestored, start, activity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("restore", "activity").Inc()
+	r.changes.committed()
+	return activity, nil
+}
+
+// HardDelete permanently removes an activity, whether or not it is in the trash.
+func (r *ActivityRepository) HardDelete(uniqueId string) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("hard_delete", "activity").Observe(duration)
+	}()
+
+	err := r.ob.RunInWriteTx(func() error {
+		activity, err := r.findByUniqueId(uniqueId)
+		if err != nil {
+			return err
+		}
+		if activity == nil {
+			return ErrActivityNotFound
+		}
+		if err := r.box.Remove(activity); err != nil {
+			return err
+		}
+		return r.changes.record(models.ChangePurged, start, activity)
+	})
 	if err != nil {
 		return err
 	}
 
-	metrics.ObjectBoxOperationsTotal.WithLabelValues("delete", "activity").Inc()
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("hard_delete", "activity").Inc()
 	r.updateMetrics()
+	r.changes.committed()
 	return nil
-} 
\ No newline at end of file
+}
+
+// DeleteDevice removes a device from the registry and returns how many
+// stored activities, including those in the trash, link to it. Unless force
+// is set it refuses with ErrDeviceHasActivities while there are any; with
+// force they are purged in the same transaction, so no activity is left with
+// a dangling DeviceId. Unlinking them instead would not last: LinkDevices
+// registers the device again for activities without one. The next activity
+// from the device registers it again too.
+func (r *ActivityRepository) DeleteDevice(name string, force bool) (uint64, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("delete", "device").Observe(duration)
+	}()
+
+	var linked uint64
+	err := r.ob.RunInWriteTx(func() error {
+		device, err := r.devices.findByName(name)
+		if err != nil {
+			return err
+		}
+		if device == nil {
+			return ErrDeviceNotFound
+		}
+
+		query, err := r.box.QueryOrError(models.DeviceActivity_.DeviceId.Equals(device.Id))
+		if err != nil {
+			return err
+		}
+		defer query.Close()
+
+		if !force {
+			if linked, err = query.Count(); err != nil {
+				return err
+			}
+			if linked > 0 {
+				return ErrDeviceHasActivities
+			}
+			return r.devices.box.Remove(device)
+		}
+
+		activities, err := query.Find()
+		if err != nil {
+			return err
+		}
+		if len(activities) > 0 {
+			ids := make([]uint64, len(activities))
+			for i, activity := range activities {
+				ids[i] = activity.Id
+			}
+			if linked, err = r.box.RemoveIds(ids...); err != nil {
+				return err
+			}
+			if err := r.changes.record(models.ChangePurged, start, activities...); err != nil {
+				return err
+			}
+		}
+		return r.devices.box.Remove(device)
+	})
+	if err != nil {
+		return linked, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("delete", "device").Inc()
+	r.devices.updateMetrics()
+	if linked > 0 {
+		r.updateMetrics()
+		r.changes.committed()
+	}
+	return linked, nil
+}
+
+// GetTrash returns a page of activities in the trash.
+func (r *ActivityRepository) GetTrash(page PageRequest) (ActivityPage, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_trash", "activity").Observe(duration)
+	}()
+
+	result, err := r.findPage(page, inTrash())
+	if err != nil {
+		return ActivityPage{}, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_trash", "activity").Inc()
+	return result, nil
+}
+
+// CountMatching returns the number of activities that match filter and that
+// DeleteMatching with the same hard would delete: those outside the trash, or
+// with hard set those in the trash too.
+func (r *ActivityRepository) CountMatching(filter ActivityFilter, hard bool) (uint64, error) {
+	conditions, err := matchingConditions(filter, hard)
+	if err != nil {
+		return 0, err
+	}
+
+	query, err := r.box.QueryOrError(conditions...)
+	if err != nil {
+		return 0, err
+	}
+	defer query.Close()
+	return query.Count()
+}
+
+// DeleteMatching deletes up to limit activities that match filter in one
+// transaction and returns how many were deleted. Activities outside the trash
+// are moved to it; when hard is set matching activities are removed
+// permanently, including those already in the trash. Callers repeat it until
+// it returns fewer than limit.
+func (r *ActivityRepository) DeleteMatching(filter ActivityFilter, limit int, hard bool) (uint64, error) {
+	conditions, err := matchingConditions(filter, hard)
+	if err != nil {
+		return 0, err
+	}
+	if hard {
+		return r.removeBatch("bulk_hard_delete", conditions, limit)
+	}
+
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("bulk_delete", "activity").Observe(duration)
+	}()
+
+	var deleted int
+	err = r.ob.RunInWriteTx(func() error {
+		query, err := r.box.QueryOrError(conditions...)
+		if err != nil {
+			return err
+		}
+		defer query.Close()
+
+		activities, err := query.Limit(uint64(limit)).Find()
+		if err != nil || len(activities) == 0 {
+			return err
+		}
+		for _, activity := range activities {
+			activity.DeletedAt = start
+		}
+		if _, err := r.box.PutMany(activities); err != nil {
+			return err
+		}
+		deleted = len(activities)
+		return r.changes.record(models.ChangeDeleted, start, activities...)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("bulk_delete", "activity").Inc()
+	if deleted > 0 {
+		r.changes.committed()
+	}
+	return uint64(deleted), nil
+}
+
+// RemoveDeletedBefore permanently deletes up to limit activities that were
+// moved to the trash before cutoff and returns how many were removed.
+func (r *ActivityRepository) RemoveDeletedBefore(cutoff time.Time, limit int) (uint64, error) {
+	return r.removeBatch("purge", []objectbox.Condition{
+		inTrash(),
+		models.DeviceActivity_.DeletedAt.LessThan(cutoff.UnixMilli()),
+	}, limit)
+}
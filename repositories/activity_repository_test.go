@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"errors"
+	"go-rest-api/models"
+	"testing"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+func openTestObjectBox(t *testing.T) *objectbox.ObjectBox {
+	t.Helper()
+	ob, err := objectbox.NewBuilder().Model(models.ObjectBoxModel()).Directory(t.TempDir()).Build()
+	if err != nil {
+		t.Fatalf("opening ObjectBox: %v", err)
+	}
+	t.Cleanup(ob.Close)
+	return ob
+}
+
+func eventRequest(eventId, hash string) IdempotencyRequest {
+	return IdempotencyRequest{Key: "event:dev-1:" + eventId, RequestHash: hash, StatusCode: 201, TTL: time.Hour}
+}
+
+func testActivity(uniqueId string) *models.DeviceActivity {
+	return &models.DeviceActivity{UniqueId: uniqueId, DeviceName: "dev-1", GridName: "eu", Action: "login", Timestamp: time.Now()}
+}
+
+func TestActivityCreateManyDeduplicates(t *testing.T) {
+	repo := NewActivityRepository(openTestObjectBox(t))
+
+	results, err := repo.CreateMany(
+		[]*models.DeviceActivity{testActivity("a1"), testActivity("a2"), testActivity("a3")},
+		[]IdempotencyRequest{eventRequest("e1", "h1"), {}, eventRequest("e1", "h1")},
+		true,
+	)
+	if err != nil {
+		t.Fatalf("CreateMany: %v", err)
+	}
+	if results[0].Duplicate || results[1].Duplicate || !results[2].Duplicate {
+		t.Fatalf("results = %+v, want only the repeated event duplicate", results)
+	}
+	if results[2].Id != results[0].Id || results[2].UniqueId != "a1" {
+		t.Errorf("duplicate refers to %d %q, want %d a1", results[2].Id, results[2].UniqueId, results[0].Id)
+	}
+
+	// Replaying the batch stores nothing new.
+	results, err = repo.CreateMany(
+		[]*models.DeviceActivity{testActivity("b1"), testActivity("b2")},
+		[]IdempotencyRequest{eventRequest("e1", "h1"), eventRequest("e2", "h2")},
+		true,
+	)
+	if err != nil {
+		t.Fatalf("replaying: %v", err)
+	}
+	if !results[0].Duplicate || results[0].UniqueId != "a1" || results[1].Duplicate {
+		t.Errorf("replay results = %+v", results)
+	}
+	if count, err := repo.CountWithDeleted(); err != nil || count != 3 {
+		t.Errorf("stored %d activities, %v, want 3", count, err)
+	}
+}
+
+func TestActivityCreateManyMismatch(t *testing.T) {
+	repo := NewActivityRepository(openTestObjectBox(t))
+	if _, err := repo.CreateMany([]*models.DeviceActivity{testActivity("a1")},
+		[]IdempotencyRequest{eventRequest("e1", "h1")}, true); err != nil {
+		t.Fatalf("CreateMany: %v", err)
+	}
+
+	batch := func() []*models.DeviceActivity {
+		return []*models.DeviceActivity{testActivity("b1"), testActivity("b2")}
+	}
+	requests := []IdempotencyRequest{{}, eventRequest("e1", "other")}
+
+	results, err := repo.CreateMany(batch(), requests, true)
+	if !errors.Is(err, ErrIdempotencyKeyMismatch) || !errors.Is(results[1].Err, ErrIdempotencyKeyMismatch) {
+		t.Fatalf("all or nothing: %v, results %+v", err, results)
+	}
+	if count, _ := repo.CountWithDeleted(); count != 1 {
+		t.Errorf("stored %d activities after the rollback, want 1", count)
+	}
+
+	results, err = repo.CreateMany(batch(), requests, false)
+	if err != nil || results[0].Id == 0 || !errors.Is(results[1].Err, ErrIdempotencyKeyMismatch) {
+		t.Fatalf("partial: %v, results %+v", err, results)
+	}
+	if count, _ := repo.CountWithDeleted(); count != 2 {
+		t.Errorf("stored %d activities, want 2", count)
+	}
+}
+
+func TestActivityDeleteDevice(t *testing.T) {
+	repo := NewActivityRepository(openTestObjectBox(t))
+	for _, uniqueId := range []string{"a1", "a2"} {
+		if err := repo.Create(*testActivity(uniqueId)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	if err := repo.Delete("a2"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	linked, err := repo.DeleteDevice("dev-1", false)
+	if !errors.Is(err, ErrDeviceHasActivities) || linked != 2 {
+		t.Fatalf("DeleteDevice = %d, %v, want 2 and ErrDeviceHasActivities", linked, err)
+	}
+	if _, err := repo.DeleteDevice("dev-2", true); !errors.Is(err, ErrDeviceNotFound) {
+		t.Errorf("deleting an unknown device: %v", err)
+	}
+
+	linked, err = repo.DeleteDevice("dev-1", true)
+	if err != nil || linked != 2 {
+		t.Fatalf("forced DeleteDevice = %d, %v, want 2", linked, err)
+	}
+	if count, err := repo.CountWithDeleted(); err != nil || count != 0 {
+		t.Errorf("%d activities left, %v", count, err)
+	}
+	if _, err := repo.DeleteDevice("dev-1", false); !errors.Is(err, ErrDeviceNotFound) {
+		t.Errorf("device is still registered: %v", err)
+	}
+}
+
+func TestActivityDeleteMatchingHardIncludesTrash(t *testing.T) {
+	repo := NewActivityRepository(openTestObjectBox(t))
+	for _, uniqueId := range []string{"a1", "a2", "a3"} {
+		if err := repo.Create(*testActivity(uniqueId)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	if err := repo.Delete("a1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	filter := ActivityFilter{Action: &SetMatch{In: []string{"login"}}}
+
+	for _, tt := range []struct {
+		hard bool
+		want uint64
+	}{{false, 2}, {true, 3}} {
+		if count, err := repo.CountMatching(filter, tt.hard); err != nil || count != tt.want {
+			t.Errorf("CountMatching(hard=%v) = %d, %v, want %d", tt.hard, count, err, tt.want)
+		}
+	}
+	deleted, err := repo.DeleteMatching(filter, 10, true)
+	if err != nil || deleted != 3 {
+		t.Fatalf("hard DeleteMatching = %d, %v, want 3", deleted, err)
+	}
+	if count, err := repo.CountWithDeleted(); err != nil || count != 0 {
+		t.Errorf("%d activities left, %v", count, err)
+	}
+}
@@ -0,0 +1,299 @@
+package repositories
+
+import (
+	"errors"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+var (
+	ErrAlertRuleNotFound = errors.New("alert rule not found")
+	ErrAlertRuleExists   = errors.New("alert rule already exists")
+	ErrAlertNotFound     = errors.New("alert not found")
+)
+
+// AlertRepository stores alert rules and the alerts they raise.
+type AlertRepository struct {
+	ob     *objectbox.ObjectBox
+	rules  *models.AlertRuleBox
+	alerts *models.AlertBox
+}
+
+func NewAlertRepository(ob *objectbox.ObjectBox) *AlertRepository {
+	repo := &AlertRepository{ob: ob, rules: models.BoxForAlertRule(ob), alerts: models.BoxForAlert(ob)}
+	repo.updateMetrics()
+	return repo
+}
+
+func (r *AlertRepository) updateMetrics() {
+	if count, err := r.rules.Count(); err == nil {
+		metrics.ObjectBoxEntityCount.WithLabelValues("alert_rule").Set(float64(count))
+	}
+	if count, err := r.alerts.Count(); err == nil {
+		metrics.ObjectBoxEntityCount.WithLabelValues("alert").Set(float64(count))
+	}
+}
+
+// GetRules returns every rule ordered by name.
+func (r *AlertRepository) GetRules() ([]models.AlertRule, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_all", "alert_rule").Observe(duration)
+	}()
+
+	query, err := r.rules.QueryOrError(models.AlertRule_.Name.OrderAsc(true))
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Find()
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]models.AlertRule, len(results))
+	for i, rule := range results {
+		rules[i] = *rule
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_all", "alert_rule").Inc()
+	return rules, nil
+}
+
+func (r *AlertRepository) findRule(name string) (*models.AlertRule, error) {
+	query, err := r.rules.QueryOrError(models.AlertRule_.Name.Equals(name, true))
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(1).Find()
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// GetRule returns the named rule or ErrAlertRuleNotFound.
+func (r *AlertRepository) GetRule(name string) (*models.AlertRule, error) {
+	rule, err := r.findRule(name)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, ErrAlertRuleNotFound
+	}
+	return rule, nil
+}
+
+func (r *AlertRepository) CreateRule(rule *models.AlertRule) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("create", "alert_rule").Observe(duration)
+	}()
+
+	err := r.ob.RunInWriteTx(func() error {
+		existing, err := r.findRule(rule.Name)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return ErrAlertRuleExists
+		}
+		rule.Id = 0
+		rule.CreatedAt = time.Now()
+		rule.UpdatedAt = rule.CreatedAt
+		_, err = r.rules.Put(rule)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("create", "alert_rule").Inc()
+	r.updateMetrics()
+	return nil
+}
+
+// UpdateRule loads the named rule, applies update to it and stores the result
+// in one transaction.
+func (r *AlertRepository) UpdateRule(name string, update func(rule *models.AlertRule) error) (*models.AlertRule, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("update", "alert_rule").Observe(duration)
+	}()
+
+	var rule *models.AlertRule
+	err := r.ob.RunInWriteTx(func() error {
+		var err error
+		if rule, err = r.findRule(name); err != nil {
+			return err
+		}
+		if rule == nil {
+			return ErrAlertRuleNotFound
+		}
+		if err := update(rule); err != nil {
+			return err
+		}
+		rule.Name = name
+		rule.UpdatedAt = time.Now()
+		_, err = r.rules.Put(rule)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("update", "alert_rule").Inc()
+	return rule, nil
+}
+
+// DeleteRule removes a rule. Alerts it raised are kept for history.
+func (r *AlertRepository) DeleteRule(name string) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("delete", "alert_rule").Observe(duration)
+	}()
+
+	rule, err := r.findRule(name)
+	if err != nil {
+		return err
+	}
+	if rule == nil {
+		return ErrAlertRuleNotFound
+	}
+	if err := r.rules.Remove(rule); err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("delete", "alert_rule").Inc()
+	r.updateMetrics()
+	return nil
+}
+
+// UpdateAlert reloads an alert, applies update to it and stores the result in
+// one transaction, so concurrent updates of different fields do not clobber
+// each other.
+func (r *AlertRepository) UpdateAlert(id uint64, update func(alert *models.Alert)) (*models.Alert, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("update", "alert").Observe(duration)
+	}()
+
+	var alert *models.Alert
+	err := r.ob.RunInWriteTx(func() error {
+		var err error
+		if alert, err = r.alerts.Get(id); err != nil {
+			return err
+		}
+		if alert == nil {
+			return ErrAlertNotFound
+		}
+		update(alert)
+		_, err = r.alerts.Put(alert)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("update", "alert").Inc()
+	return alert, nil
+}
+
+// PutAlert creates or updates an alert.
+func (r *AlertRepository) PutAlert(alert *models.Alert) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("put", "alert").Observe(duration)
+	}()
+
+	created := alert.Id == 0
+	if _, err := r.alerts.Put(alert); err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("put", "alert").Inc()
+	if created {
+		r.updateMetrics()
+	}
+	return nil
+}
+
+// GetAlerts returns the most recent alerts first, optionally only those with
+// the given status.
+func (r *AlertRepository) GetAlerts(status string, limit int) ([]models.Alert, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_alerts", "alert").Observe(duration)
+	}()
+
+	conditions := []objectbox.Condition{models.Alert_.Id.OrderDesc()}
+	if status != "" {
+		conditions = append(conditions, models.Alert_.Status.Equals(status, true))
+	}
+	query, err := r.alerts.QueryOrError(conditions...)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	if limit > 0 {
+		query.Limit(uint64(limit))
+	}
+	results, err := query.Find()
+	if err != nil {
+		return nil, err
+	}
+	alerts := make([]models.Alert, len(results))
+	for i, alert := range results {
+		alerts[i] = *alert
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_alerts", "alert").Inc()
+	return alerts, nil
+}
+
+// GetFiring returns every alert that is currently firing.
+func (r *AlertRepository) GetFiring() ([]models.Alert, error) {
+	return r.GetAlerts(models.AlertFiring, 0)
+}
+
+// GetUndelivered returns alerts whose current status has not been delivered yet.
+func (r *AlertRepository) GetUndelivered() ([]models.Alert, error) {
+	// Only firing alerts and resolved alerts whose resolution is still
+	// undelivered can be behind; the rest are filtered in memory.
+	query, err := r.alerts.QueryOrError(
+		objectbox.Any(
+			models.Alert_.Status.Equals(models.AlertFiring, true),
+			models.Alert_.NotifiedStatus.NotEquals(models.AlertResolved, true),
+		),
+		models.Alert_.Id.OrderAsc(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Find()
+	if err != nil {
+		return nil, err
+	}
+	var alerts []models.Alert
+	for _, alert := range results {
+		if alert.NotifiedStatus != alert.Status {
+			alerts = append(alerts, *alert)
+		}
+	}
+	return alerts, nil
+}
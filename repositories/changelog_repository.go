@@ -0,0 +1,287 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"sync"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+var (
+	ErrChangeCheckpointNotFound = errors.New("change checkpoint not found")
+	// ErrSequenceAhead is returned when a checkpoint is set past the newest change.
+	ErrSequenceAhead = errors.New("sequence is ahead of the newest change")
+)
+
+// ChangelogRepository stores the activity changelog and the checkpoints of
+// its consumers. Changes are recorded by ActivityRepository inside its write
+// transactions; since those are serialized and Ids only grow, changes become
+// visible in sequence order without gaps being filled in later.
+type ChangelogRepository struct {
+	ob          *objectbox.ObjectBox
+	box         *models.ActivityChangeBox
+	checkpoints *models.ChangeCheckpointBox
+
+	mu      sync.Mutex
+	changed chan struct{}
+}
+
+func NewChangelogRepository(ob *objectbox.ObjectBox) *ChangelogRepository {
+	repo := &ChangelogRepository{
+		ob:          ob,
+		box:         models.BoxForActivityChange(ob),
+		checkpoints: models.BoxForChangeCheckpoint(ob),
+		changed:     make(chan struct{}),
+	}
+	repo.updateMetrics()
+	return repo
+}
+
+func (r *ChangelogRepository) updateMetrics() {
+	count, err := r.box.Count()
+	if err == nil {
+		metrics.ObjectBoxEntityCount.WithLabelValues("activity_change").Set(float64(count))
+	}
+}
+
+// record appends one change per activity. It must run inside the write
+// transaction that mutates the activities, after they have their Ids. Purged
+// activities are recorded by Id, UniqueId and DeletedAt only, so no copy of
+// their data outlives them.
+func (r *ChangelogRepository) record(operation string, now time.Time, activities ...*models.DeviceActivity) error {
+	changes := make([]*models.ActivityChange, len(activities))
+	for i, activity := range activities {
+		snapshot := activity
+		if operation == models.ChangePurged {
+			snapshot = &models.DeviceActivity{Id: activity.Id, UniqueId: activity.UniqueId, DeletedAt: activity.DeletedAt}
+		}
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			return err
+		}
+		changes[i] = &models.ActivityChange{
+			Operation:  operation,
+			ActivityId: activity.Id,
+			UniqueId:   activity.UniqueId,
+			ChangedAt:  now,
+			Activity:   string(data),
+		}
+	}
+	_, err := r.box.PutMany(changes)
+	return err
+}
+
+// committed wakes up everyone waiting in Changed. It is called after a
+// transaction that recorded changes has been committed.
+func (r *ChangelogRepository) committed() {
+	r.mu.Lock()
+	close(r.changed)
+	r.changed = make(chan struct{})
+	r.mu.Unlock()
+	r.updateMetrics()
+}
+
+// Changed returns a channel that is closed once further changes are
+// committed. Take it before reading so that no commit is missed.
+func (r *ChangelogRepository) Changed() <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.changed
+}
+
+// GetAfter returns up to limit changes with a sequence greater than after, oldest first.
+func (r *ChangelogRepository) GetAfter(after uint64, limit int) ([]models.ActivityChange, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_after", "activity_change").Observe(duration)
+	}()
+
+	query, err := r.box.QueryOrError(
+		models.ActivityChange_.Id.GreaterThan(after),
+		models.ActivityChange_.Id.OrderAsc(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(uint64(limit)).Find()
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]models.ActivityChange, len(results))
+	for i, change := range results {
+		changes[i] = *change
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_after", "activity_change").Inc()
+	return changes, nil
+}
+
+// LastSequence returns the sequence of the newest change, or 0 if there is none.
+func (r *ChangelogRepository) LastSequence() (uint64, error) {
+	query, err := r.box.QueryOrError(models.ActivityChange_.Id.OrderDesc())
+	if err != nil {
+		return 0, err
+	}
+	defer query.Close()
+
+	ids, err := query.Limit(1).FindIds()
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// LastSnapshot returns the newest change of an activity before the given
+// sequence that holds its data, i.e. is not a purge, or nil if none is left.
+func (r *ChangelogRepository) LastSnapshot(activityId, before uint64) (*models.ActivityChange, error) {
+	query, err := r.box.QueryOrError(
+		models.ActivityChange_.ActivityId.Equals(activityId),
+		models.ActivityChange_.Id.LessThan(before),
+		models.ActivityChange_.Operation.NotEquals(models.ChangePurged, true),
+		models.ActivityChange_.Id.OrderDesc(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(1).Find()
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// RemoveBefore deletes up to limit changes recorded before cutoff with a
+// sequence of at most maxSequence, oldest first, and returns how many were deleted.
+func (r *ChangelogRepository) RemoveBefore(cutoff time.Time, maxSequence uint64, limit int) (uint64, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("prune", "activity_change").Observe(duration)
+	}()
+
+	query, err := r.box.QueryOrError(
+		models.ActivityChange_.ChangedAt.LessThan(cutoff.UnixMilli()),
+		models.ActivityChange_.Id.LessOrEqual(maxSequence),
+		models.ActivityChange_.Id.OrderAsc(),
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer query.Close()
+
+	ids, err := query.Limit(uint64(limit)).FindIds()
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	removed, err := r.box.RemoveIds(ids...)
+	if err != nil {
+		return 0, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("prune", "activity_change").Inc()
+	r.updateMetrics()
+	return removed, nil
+}
+
+func (r *ChangelogRepository) findCheckpoint(name string) (*models.ChangeCheckpoint, error) {
+	query, err := r.checkpoints.QueryOrError(models.ChangeCheckpoint_.Name.Equals(name, true))
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(1).Find()
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// GetCheckpoints returns every consumer checkpoint ordered by name.
+func (r *ChangelogRepository) GetCheckpoints() ([]models.ChangeCheckpoint, error) {
+	query, err := r.checkpoints.QueryOrError(models.ChangeCheckpoint_.Name.OrderAsc(true))
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Find()
+	if err != nil {
+		return nil, err
+	}
+	checkpoints := make([]models.ChangeCheckpoint, len(results))
+	for i, checkpoint := range results {
+		checkpoints[i] = *checkpoint
+	}
+	return checkpoints, nil
+}
+
+// GetCheckpoint returns the named checkpoint or ErrChangeCheckpointNotFound.
+func (r *ChangelogRepository) GetCheckpoint(name string) (*models.ChangeCheckpoint, error) {
+	checkpoint, err := r.findCheckpoint(name)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint == nil {
+		return nil, ErrChangeCheckpointNotFound
+	}
+	return checkpoint, nil
+}
+
+// SetCheckpoint creates or moves the named checkpoint to sequence, which may
+// not be past the newest change.
+func (r *ChangelogRepository) SetCheckpoint(name string, sequence uint64) (*models.ChangeCheckpoint, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("set_checkpoint", "activity_change").Observe(duration)
+	}()
+
+	var checkpoint *models.ChangeCheckpoint
+	err := r.ob.RunInWriteTx(func() error {
+		last, err := r.LastSequence()
+		if err != nil {
+			return err
+		}
+		if sequence > last {
+			return ErrSequenceAhead
+		}
+		if checkpoint, err = r.findCheckpoint(name); err != nil {
+			return err
+		}
+		if checkpoint == nil {
+			checkpoint = &models.ChangeCheckpoint{Name: name}
+		}
+		checkpoint.Sequence = sequence
+		checkpoint.UpdatedAt = start
+		_, err = r.checkpoints.Put(checkpoint)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("set_checkpoint", "activity_change").Inc()
+	return checkpoint, nil
+}
+
+// DeleteCheckpoint removes the named checkpoint.
+func (r *ChangelogRepository) DeleteCheckpoint(name string) error {
+	checkpoint, err := r.findCheckpoint(name)
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil {
+		return ErrChangeCheckpointNotFound
+	}
+	return r.checkpoints.Remove(checkpoint)
+}
@@ -0,0 +1,322 @@
+package repositories
+
+import (
+	"errors"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"strconv"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+var (
+	ErrDeviceNotFound = errors.New("device not found")
+	ErrDeviceExists   = errors.New("device already exists")
+	// ErrDeviceHasActivities is returned when deleting a device, without
+	// force, that stored activities, including those in the trash, still link to.
+	ErrDeviceHasActivities = errors.New("device has activities")
+)
+
+// DevicePage is a single page of devices in Id order and the cursor for the
+// page after it. NextCursor is empty when there are no more results.
+type DevicePage struct {
+	Devices    []models.Device
+	NextCursor string
+}
+
+type DeviceRepository struct {
+	ob  *objectbox.ObjectBox
+	box *models.DeviceBox
+}
+
+func NewDeviceRepository(ob *objectbox.ObjectBox) *DeviceRepository {
+	repo := &DeviceRepository{ob: ob, box: models.BoxForDevice(ob)}
+	repo.updateMetrics()
+	return repo
+}
+
+func (r *DeviceRepository) updateMetrics() {
+	count, err := r.box.Count()
+	if err == nil {
+		metrics.ObjectBoxEntityCount.WithLabelValues("device").Set(float64(count))
+	}
+}
+
+// touch links each activity to its device, creating devices seen for the
+// first time and advancing the presence fields of known ones. Synthetic
+// activities are linked but do not count as presence. It must run
+// inside a write transaction so that concurrent first activities of the same
+// device cannot both create it.
+func (r *DeviceRepository) touch(activities []*models.DeviceActivity) error {
+	devices := make(map[string]*models.Device)
+	created := false
+	for _, activity := range activities {
+		if activity.DeviceName == "" {
+			continue
+		}
+		device, ok := devices[activity.DeviceName]
+		if !ok {
+			existing, err := r.findByName(activity.DeviceName)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				// A device is online from its first activity on.
+				existing = &models.Device{Name: activity.DeviceName, Online: true, StatusChangedAt: time.Now()}
+				created = true
+			}
+			device = existing
+			devices[activity.DeviceName] = device
+		}
+		if !activity.Synthetic {
+			observePresence(device, activity)
+		}
+	}
+
+	for _, device := range devices {
+		if _, err := r.box.Put(device); err != nil {
+			return err
+		}
+	}
+	for _, activity := range activities {
+		if device, ok := devices[activity.DeviceName]; ok {
+			activity.DeviceId = device.Id
+		}
+	}
+	if created {
+		r.updateMetrics()
+	}
+	return nil
+}
+
+// observePresence advances a device's presence fields with one activity. The
+// server receive time is used so device clock skew cannot move LastSeen.
+func observePresence(device *models.Device, activity *models.DeviceActivity) {
+	seen := activity.ReceivedAt
+	if seen.IsZero() {
+		seen = activity.Timestamp
+	}
+	if device.FirstSeen.IsZero() || seen.Before(device.FirstSeen) {
+		device.FirstSeen = seen
+	}
+	if !seen.Before(device.LastSeen) {
+		device.LastSeen = seen
+		if activity.GridName != "" {
+			device.GridName = activity.GridName
+		}
+		if activity.SourceIP != "" {
+			device.LastSourceIP = activity.SourceIP
+		}
+	}
+}
+
+func (r *DeviceRepository) findByName(name string) (*models.Device, error) {
+	query, err := r.box.QueryOrError(models.Device_.Name.Equals(name, true))
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(1).Find()
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// List returns devices in Id order after the cursor, optionally only those
+// whose current grid is grid.
+func (r *DeviceRepository) List(grid string, cursor string, limit int) (DevicePage, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("list", "device").Observe(duration)
+	}()
+
+	var afterId uint64
+	if cursor != "" {
+		id, err := strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return DevicePage{}, ErrInvalidCursor
+		}
+		afterId = id
+	}
+
+	conditions := []objectbox.Condition{
+		models.Device_.Id.GreaterThan(afterId),
+		models.Device_.Id.OrderAsc(),
+	}
+	if grid != "" {
+		conditions = append(conditions, models.Device_.GridName.Equals(grid, true))
+	}
+	query, err := r.box.QueryOrError(conditions...)
+	if err != nil {
+		return DevicePage{}, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(uint64(limit + 1)).Find()
+	if err != nil {
+		return DevicePage{}, err
+	}
+
+	var page DevicePage
+	if len(results) > limit {
+		results = results[:limit]
+		page.NextCursor = strconv.FormatUint(results[limit-1].Id, 10)
+	}
+	page.Devices = make([]models.Device, len(results))
+	for i, device := range results {
+		page.Devices[i] = *device
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("list", "device").Inc()
+	return page, nil
+}
+
+// GetByOnline returns every device whose Online flag equals online.
+func (r *DeviceRepository) GetByOnline(online bool) ([]models.Device, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_by_online", "device").Observe(duration)
+	}()
+
+	query, err := r.box.QueryOrError(models.Device_.Online.Equals(online), models.Device_.Name.OrderAsc(true))
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Find()
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]models.Device, len(results))
+	for i, device := range results {
+		devices[i] = *device
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_by_online", "device").Inc()
+	return devices, nil
+}
+
+// SetOnline re-reads the device in a write transaction and asks evaluate
+// whether it is online, so a concurrent activity is never overwritten by a
+// stale decision. It returns the device and whether its state changed.
+func (r *DeviceRepository) SetOnline(id uint64, evaluate func(device models.Device) bool, at time.Time) (models.Device, bool, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("set_online", "device").Observe(duration)
+	}()
+
+	var device *models.Device
+	changed := false
+	err := r.ob.RunInWriteTx(func() error {
+		var err error
+		if device, err = r.box.Get(id); err != nil {
+			return err
+		}
+		if device == nil {
+			return ErrDeviceNotFound
+		}
+		online := evaluate(*device)
+		if online == device.Online {
+			return nil
+		}
+		device.Online = online
+		device.StatusChangedAt = at
+		changed = true
+		_, err = r.box.Put(device)
+		return err
+	})
+	if err != nil {
+		return models.Device{}, false, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("set_online", "device").Inc()
+	return *device, changed, nil
+}
+
+// GetByName returns the device with the given name or ErrDeviceNotFound.
+func (r *DeviceRepository) GetByName(name string) (*models.Device, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_by_name", "device").Observe(duration)
+	}()
+
+	device, err := r.findByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if device == nil {
+		return nil, ErrDeviceNotFound
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_by_name", "device").Inc()
+	return device, nil
+}
+
+// Create registers a device ahead of its first activity.
+func (r *DeviceRepository) Create(device *models.Device) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("create", "device").Observe(duration)
+	}()
+
+	err := r.ob.RunInWriteTx(func() error {
+		existing, err := r.findByName(device.Name)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return ErrDeviceExists
+		}
+		device.Id = 0
+		_, err = r.box.Put(device)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("create", "device").Inc()
+	r.updateMetrics()
+	return nil
+}
+
+// Update loads the named device, applies update to it and stores the result
+// in one transaction.
+func (r *DeviceRepository) Update(name string, update func(device *models.Device) error) (*models.Device, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("update", "device").Observe(duration)
+	}()
+
+	var device *models.Device
+	err := r.ob.RunInWriteTx(func() error {
+		var err error
+		if device, err = r.findByName(name); err != nil {
+			return err
+		}
+		if device == nil {
+			return ErrDeviceNotFound
+		}
+		if err := update(device); err != nil {
+			return err
+		}
+		_, err = r.box.Put(device)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("update", "device").Inc()
+	return device, nil
+}
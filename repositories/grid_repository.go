@@ -0,0 +1,282 @@
+package repositories
+
+import (
+	"errors"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"sort"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+var (
+	ErrGridNotFound       = errors.New("grid not found")
+	ErrGridExists         = errors.New("grid already exists")
+	ErrGridParentNotFound = errors.New("parent grid not found")
+	ErrGridCycle          = errors.New("a grid cannot be its own ancestor")
+	ErrGridHasChildren    = errors.New("grid has child grids")
+)
+
+// GridTree is a snapshot of the grid hierarchy.
+type GridTree struct {
+	byName   map[string]*models.Grid
+	byId     map[uint64]*models.Grid
+	children map[uint64][]*models.Grid
+}
+
+func newGridTree(grids []*models.Grid) GridTree {
+	tree := GridTree{
+		byName:   make(map[string]*models.Grid, len(grids)),
+		byId:     make(map[uint64]*models.Grid, len(grids)),
+		children: make(map[uint64][]*models.Grid),
+	}
+	for _, grid := range grids {
+		tree.byName[grid.Name] = grid
+		tree.byId[grid.Id] = grid
+		tree.children[grid.ParentId] = append(tree.children[grid.ParentId], grid)
+	}
+	return tree
+}
+
+// Grids returns every grid ordered by name.
+func (t GridTree) Grids() []models.Grid {
+	grids := make([]models.Grid, 0, len(t.byName))
+	for _, grid := range t.byName {
+		grids = append(grids, *grid)
+	}
+	sort.Slice(grids, func(i, j int) bool { return grids[i].Name < grids[j].Name })
+	return grids
+}
+
+// Get returns the named grid.
+func (t GridTree) Get(name string) (models.Grid, bool) {
+	grid, ok := t.byName[name]
+	if !ok {
+		return models.Grid{}, false
+	}
+	return *grid, true
+}
+
+// ParentName returns the name of the grid's parent, or "" for a root.
+func (t GridTree) ParentName(grid models.Grid) string {
+	if parent, ok := t.byId[grid.ParentId]; ok {
+		return parent.Name
+	}
+	return ""
+}
+
+// Children returns the names of the grid's direct children.
+func (t GridTree) Children(name string) []string {
+	grid, ok := t.byName[name]
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, child := range t.children[grid.Id] {
+		names = append(names, child.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Subtree returns name followed by the names of all its descendants. Grid
+// names that are not registered are returned on their own, so flat grids
+// used only as GridName keep working.
+func (t GridTree) Subtree(name string) []string {
+	names := []string{name}
+	grid, ok := t.byName[name]
+	if !ok {
+		return names
+	}
+	seen := map[uint64]bool{grid.Id: true}
+	queue := []uint64{grid.Id}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, child := range t.children[id] {
+			if seen[child.Id] {
+				continue
+			}
+			seen[child.Id] = true
+			names = append(names, child.Name)
+			queue = append(queue, child.Id)
+		}
+	}
+	return names
+}
+
+// Ancestors returns the named grid followed by its parent, grandparent and so
+// on up to the root. An unregistered name returns nothing.
+func (t GridTree) Ancestors(name string) []models.Grid {
+	var grids []models.Grid
+	grid := t.byName[name]
+	// Bounded by the number of grids in case stored data contains a cycle.
+	for steps := 0; grid != nil && steps <= len(t.byId); steps++ {
+		grids = append(grids, *grid)
+		grid = t.byId[grid.ParentId]
+	}
+	return grids
+}
+
+// parentId resolves the parent name for grid and checks it does not create a cycle.
+func (t GridTree) parentId(grid *models.Grid, parent string) (uint64, error) {
+	if parent == "" {
+		return 0, nil
+	}
+	node, ok := t.byName[parent]
+	if !ok {
+		return 0, ErrGridParentNotFound
+	}
+	// Bounded by the number of grids in case stored data already contains a cycle.
+	ancestor := node
+	for steps := 0; ancestor != nil && steps <= len(t.byId); steps++ {
+		if grid.Id != 0 && ancestor.Id == grid.Id {
+			return 0, ErrGridCycle
+		}
+		ancestor = t.byId[ancestor.ParentId]
+	}
+	return node.Id, nil
+}
+
+type GridRepository struct {
+	ob  *objectbox.ObjectBox
+	box *models.GridBox
+}
+
+func NewGridRepository(ob *objectbox.ObjectBox) *GridRepository {
+	repo := &GridRepository{ob: ob, box: models.BoxForGrid(ob)}
+	repo.updateMetrics()
+	return repo
+}
+
+func (r *GridRepository) updateMetrics() {
+	count, err := r.box.Count()
+	if err == nil {
+		metrics.ObjectBoxEntityCount.WithLabelValues("grid").Set(float64(count))
+	}
+}
+
+// Tree loads the whole grid hierarchy.
+func (r *GridRepository) Tree() (GridTree, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_all", "grid").Observe(duration)
+	}()
+
+	grids, err := r.box.GetAll()
+	if err != nil {
+		return GridTree{}, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_all", "grid").Inc()
+	return newGridTree(grids), nil
+}
+
+// Subtree returns name and the names of all its descendant grids.
+func (r *GridRepository) Subtree(name string) ([]string, error) {
+	tree, err := r.Tree()
+	if err != nil {
+		return nil, err
+	}
+	return tree.Subtree(name), nil
+}
+
+// Create stores a new grid under the named parent, or as a root if parent is empty.
+func (r *GridRepository) Create(grid *models.Grid, parent string) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("create", "grid").Observe(duration)
+	}()
+
+	err := r.ob.RunInWriteTx(func() error {
+		tree, err := r.Tree()
+		if err != nil {
+			return err
+		}
+		if _, exists := tree.byName[grid.Name]; exists {
+			return ErrGridExists
+		}
+		grid.Id = 0
+		if grid.ParentId, err = tree.parentId(grid, parent); err != nil {
+			return err
+		}
+		_, err = r.box.Put(grid)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("create", "grid").Inc()
+	r.updateMetrics()
+	return nil
+}
+
+// Update applies update to the named grid and moves it under parent,
+// rejecting moves that would make the grid its own ancestor.
+func (r *GridRepository) Update(name, parent string, update func(grid *models.Grid)) (*models.Grid, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("update", "grid").Observe(duration)
+	}()
+
+	var grid *models.Grid
+	err := r.ob.RunInWriteTx(func() error {
+		tree, err := r.Tree()
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if grid, ok = tree.byName[name]; !ok {
+			return ErrGridNotFound
+		}
+		update(grid)
+		grid.Name = name
+		if grid.ParentId, err = tree.parentId(grid, parent); err != nil {
+			return err
+		}
+		_, err = r.box.Put(grid)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("update", "grid").Inc()
+	return grid, nil
+}
+
+// Delete removes a grid that has no child grids. Activities keep their GridName.
+func (r *GridRepository) Delete(name string) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("delete", "grid").Observe(duration)
+	}()
+
+	err := r.ob.RunInWriteTx(func() error {
+		tree, err := r.Tree()
+		if err != nil {
+			return err
+		}
+		grid, ok := tree.byName[name]
+		if !ok {
+			return ErrGridNotFound
+		}
+		if len(tree.children[grid.Id]) > 0 {
+			return ErrGridHasChildren
+		}
+		return r.box.Remove(grid)
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("delete", "grid").Inc()
+	r.updateMetrics()
+	return nil
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"errors"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+// ErrIdempotencyKeyMismatch is returned when a key is reused for a request
+// with a different body.
+var ErrIdempotencyKeyMismatch = errors.New("idempotency key was already used for a different request")
+
+// IdempotencyRequest identifies a create request that may be a retry.
+type IdempotencyRequest struct {
+	Key         string
+	RequestHash string
+	StatusCode  int
+	TTL         time.Duration
+}
+
+type IdempotencyRepository struct {
+	ob  *objectbox.ObjectBox
+	box *models.IdempotencyKeyBox
+}
+
+func NewIdempotencyRepository(ob *objectbox.ObjectBox) *IdempotencyRepository {
+	repo := &IdempotencyRepository{ob: ob, box: models.BoxForIdempotencyKey(ob)}
+	repo.updateMetrics()
+	return repo
+}
+
+func (r *IdempotencyRepository) updateMetrics() {
+	count, err := r.box.Count()
+	if err == nil {
+		metrics.ObjectBoxEntityCount.WithLabelValues("idempotency_key").Set(float64(count))
+	}
+}
+
+// find returns the stored key, including an expired one.
+func (r *IdempotencyRepository) find(key string) (*models.IdempotencyKey, error) {
+	query, err := r.box.QueryOrError(models.IdempotencyKey_.Key.Equals(key, true))
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(1).Find()
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// lookup returns the live record for req, nil if there is none, or
+// ErrIdempotencyKeyMismatch. It must run inside a write transaction together
+// with remember so that concurrent retries cannot both miss.
+func (r *IdempotencyRepository) lookup(req IdempotencyRequest, now time.Time) (*models.IdempotencyKey, error) {
+	record, err := r.find(req.Key)
+	if err != nil || record == nil || !record.ExpiresAt.After(now) {
+		return nil, err
+	}
+	if record.RequestHash != req.RequestHash {
+		return nil, ErrIdempotencyKeyMismatch
+	}
+	return record, nil
+}
+
+// remember stores the response for req, replacing an expired record of the same key.
+func (r *IdempotencyRepository) remember(req IdempotencyRequest, activityId uint64, response string, now time.Time) error {
+	record, err := r.find(req.Key)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		record = &models.IdempotencyKey{Key: req.Key}
+	}
+	record.RequestHash = req.RequestHash
+	record.StatusCode = req.StatusCode
+	record.Response = response
+	record.ActivityId = activityId
+	record.CreatedAt = now
+	record.ExpiresAt = now.Add(req.TTL)
+	_, err = r.box.Put(record)
+	return err
+}
+
+// RemoveExpired deletes up to limit keys that expired before now and returns
+// how many were deleted.
+func (r *IdempotencyRepository) RemoveExpired(now time.Time, limit int) (uint64, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("prune", "idempotency_key").Observe(duration)
+	}()
+
+	query, err := r.box.QueryOrError(models.IdempotencyKey_.ExpiresAt.LessOrEqual(now.UnixMilli()))
+	if err != nil {
+		return 0, err
+	}
+	defer query.Close()
+
+	ids, err := query.Limit(uint64(limit)).FindIds()
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	removed, err := r.box.RemoveIds(ids...)
+	if err != nil {
+		return 0, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("prune", "idempotency_key").Inc()
+	r.updateMetrics()
+	return removed, nil
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"go-rest-api/models"
+	"strconv"
+	"strings"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+const (
+	DefaultPageLimit = 100
+	MaxPageLimit     = 1000
+)
+
+// SortOrder selects the direction activities are returned in.
+type SortOrder string
+
+const (
+	SortTimestampAsc  SortOrder = "timestamp"
+	SortTimestampDesc SortOrder = "-timestamp"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ParseSortOrder validates a sort query parameter, defaulting to ascending timestamps.
+func ParseSortOrder(value string) (SortOrder, error) {
+	switch SortOrder(value) {
+	case "", SortTimestampAsc:
+		return SortTimestampAsc, nil
+	case SortTimestampDesc:
+		return SortTimestampDesc, nil
+	}
+	return "", fmt.Errorf("invalid sort %q: expected %q or %q", value, SortTimestampAsc, SortTimestampDesc)
+}
+
+// Cursor marks the last activity returned on a page. It holds the ObjectBox Id
+// together with the timestamp so the next page can resume with a keyset query.
+type Cursor struct {
+	Id        uint64
+	Timestamp int64
+}
+
+// Encode returns the opaque string form handed out to clients.
+func (c Cursor) Encode() string {
+	raw := strconv.FormatUint(c.Id, 10) + ":" + strconv.FormatInt(c.Timestamp, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor previously produced by Cursor.Encode.
+func DecodeCursor(value string) (*Cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	idPart, tsPart, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	id, err := strconv.ParseUint(idPart, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	ts, err := strconv.ParseInt(tsPart, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{Id: id, Timestamp: ts}, nil
+}
+
+// PageRequest describes which slice of a result set to load.
+type PageRequest struct {
+	Limit  int
+	Cursor *Cursor
+	Sort   SortOrder
+}
+
+func (p PageRequest) limit() int {
+	if p.Limit <= 0 {
+		return DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+	return p.Limit
+}
+
+// findPage runs a keyset-paginated query ordered by Timestamp and Id. Only
+// limit+1 rows are read from ObjectBox; the extra row tells us whether another
+// page exists.
+func (r *ActivityRepository) findPage(page PageRequest, conditions ...objectbox.Condition) (ActivityPage, error) {
+	limit := page.limit()
+	desc := page.Sort == SortTimestampDesc
+
+	if page.Cursor != nil {
+		conditions = append(conditions, afterCursor(*page.Cursor, desc))
+	}
+
+	// Id breaks ties between equal timestamps, matching the cursor condition.
+	orders := []objectbox.Condition{models.DeviceActivity_.Timestamp.OrderAsc(), models.DeviceActivity_.Id.OrderAsc()}
+	if desc {
+		orders = []objectbox.Condition{models.DeviceActivity_.Timestamp.OrderDesc(), models.DeviceActivity_.Id.OrderDesc()}
+	}
+
+	query, err := r.box.QueryOrError(append(conditions, orders...)...)
+	if err != nil {
+		return ActivityPage{}, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(uint64(limit + 1)).Find()
+	if err != nil {
+		return ActivityPage{}, err
+	}
+
+	hasMore := len(results) > limit
+	if hasMore {
+		results = results[:limit]
+	}
+
+	activities := make([]models.DeviceActivity, len(results))
+	for i, result := range results {
+		activities[i] = *result
+	}
+
+	var next string
+	if hasMore && len(activities) > 0 {
+		last := activities[len(activities)-1]
+		next = Cursor{Id: last.Id, Timestamp: last.Timestamp.UnixMilli()}.Encode()
+	}
+	return ActivityPage{Activities: activities, NextCursor: next}, nil
+}
+
+// afterCursor matches the rows that come after the cursor in (Timestamp, Id) order.
+func afterCursor(cursor Cursor, desc bool) objectbox.Condition {
+	ts := models.DeviceActivity_.Timestamp
+	id := models.DeviceActivity_.Id
+	if desc {
+		return objectbox.Any(
+			ts.LessThan(cursor.Timestamp),
+			objectbox.All(ts.Equals(cursor.Timestamp), id.LessThan(cursor.Id)),
+		)
+	}
+	return objectbox.Any(
+		ts.GreaterThan(cursor.Timestamp),
+		objectbox.All(ts.Equals(cursor.Timestamp), id.GreaterThan(cursor.Id)),
+	)
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	for _, cursor := range []Cursor{
+		{Id: 1, Timestamp: 0},
+		{Id: 42, Timestamp: 1767225600000},
+		{Id: math.MaxUint64, Timestamp: math.MaxInt64},
+		{Id: 7, Timestamp: -62135596800000},
+	} {
+		got, err := DecodeCursor(cursor.Encode())
+		if err != nil || got == nil || *got != cursor {
+			t.Errorf("DecodeCursor(Encode(%+v)) = %+v, %v", cursor, got, err)
+		}
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+	tests := []struct {
+		name  string
+		value string
+		want  *Cursor
+		err   error
+	}{
+		{"empty", "", nil, nil},
+		{"valid", encode("5:1000"), &Cursor{Id: 5, Timestamp: 1000}, nil},
+		{"not base64", "!!!", nil, ErrInvalidCursor},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte("5:10")), nil, ErrInvalidCursor},
+		{"no separator", encode("5"), nil, ErrInvalidCursor},
+		{"negative id", encode("-5:1000"), nil, ErrInvalidCursor},
+		{"id not a number", encode("x:1000"), nil, ErrInvalidCursor},
+		{"timestamp not a number", encode("5:x"), nil, ErrInvalidCursor},
+		{"extra part", encode("5:1000:1"), nil, ErrInvalidCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(tt.value)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("DecodeCursor(%q) error = %v, want %v", tt.value, err, tt.err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("DecodeCursor(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSortOrder(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    SortOrder
+		wantErr bool
+	}{
+		{"", SortTimestampAsc, false},
+		{"timestamp", SortTimestampAsc, false},
+		{"-timestamp", SortTimestampDesc, false},
+		{"Timestamp", "", true},
+		{"id", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSortOrder(tt.value)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseSortOrder(%q) = %q, %v", tt.value, got, err)
+		}
+	}
+}
+
+func TestPageRequestLimit(t *testing.T) {
+	tests := []struct{ limit, want int }{
+		{0, DefaultPageLimit},
+		{-1, DefaultPageLimit},
+		{1, 1},
+		{MaxPageLimit, MaxPageLimit},
+		{MaxPageLimit + 1, MaxPageLimit},
+	}
+	for _, tt := range tests {
+		if got := (PageRequest{Limit: tt.limit}).limit(); got != tt.want {
+			t.Errorf("limit(%d) = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestActivityPagesBreakTimestampTies(t *testing.T) {
+	repo := NewActivityRepository(openTestObjectBox(t))
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Three activities share a timestamp, so pages of two split the tie.
+	for i, minute := range []int{0, 1, 1, 1, 2} {
+		activity := testActivity(string(rune('a' + i)))
+		activity.Timestamp = base.Add(time.Duration(minute) * time.Minute)
+		if err := repo.Create(*activity); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	for _, tt := range []struct {
+		sort SortOrder
+		want string
+	}{
+		{SortTimestampAsc, "abcde"},
+		{SortTimestampDesc, "edcba"},
+	} {
+		page := PageRequest{Limit: 2, Sort: tt.sort}
+		var got string
+		for pages := 0; ; pages++ {
+			if pages > 5 {
+				t.Fatalf("%s: pagination does not end", tt.sort)
+			}
+			result, err := repo.List(page)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			for _, activity := range result.Activities {
+				got += activity.UniqueId
+			}
+			if result.NextCursor == "" {
+				break
+			}
+			if page.Cursor, err = DecodeCursor(result.NextCursor); err != nil {
+				t.Fatalf("DecodeCursor: %v", err)
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%s: pages return %q, want %q", tt.sort, got, tt.want)
+		}
+	}
+}
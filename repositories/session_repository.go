@@ -0,0 +1,423 @@
+package repositories
+
+import (
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"strconv"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+// SessionRules describe how sessions are derived from activities.
+type SessionRules struct {
+	// Pairs maps each start action to the action that ends its session.
+	Pairs map[string]string
+	// IdleTimeout ends an open session once no activity of its device
+	// arrived for this long. Zero keeps sessions open until the end action.
+	IdleTimeout time.Duration
+	// Lateness is how old an activity's Timestamp must be before it is
+	// folded. Activities are folded in Timestamp order, so those that arrive
+	// out of order but within Lateness still count; an activity arriving
+	// later than that with a Timestamp before the last one folded is dropped.
+	Lateness time.Duration
+}
+
+// SessionPage is a single page of sessions, newest first, and the cursor for
+// the page after it. NextCursor is empty when there are no more results.
+type SessionPage struct {
+	Sessions   []models.Session
+	NextCursor string
+}
+
+type SessionRepository struct {
+	ob          *objectbox.ObjectBox
+	box         *models.SessionBox
+	checkpoints *models.SessionCheckpointBox
+	activities  *models.DeviceActivityBox
+}
+
+func NewSessionRepository(ob *objectbox.ObjectBox) *SessionRepository {
+	repo := &SessionRepository{
+		ob:          ob,
+		box:         models.BoxForSession(ob),
+		checkpoints: models.BoxForSessionCheckpoint(ob),
+		activities:  models.BoxForDeviceActivity(ob),
+	}
+	repo.updateMetrics()
+	return repo
+}
+
+func (r *SessionRepository) updateMetrics() {
+	if count, err := r.box.Count(); err == nil {
+		metrics.ObjectBoxEntityCount.WithLabelValues("session").Set(float64(count))
+	}
+	query, err := r.box.QueryOrError(models.Session_.Status.Equals(models.SessionOpen, true))
+	if err != nil {
+		return
+	}
+	defer query.Close()
+	if count, err := query.Count(); err == nil {
+		metrics.SessionsOpen.Set(float64(count))
+	}
+}
+
+func (r *SessionRepository) checkpoint() (*models.SessionCheckpoint, error) {
+	checkpoints, err := r.checkpoints.GetAll()
+	if err != nil || len(checkpoints) == 0 {
+		return &models.SessionCheckpoint{}, err
+	}
+	return checkpoints[0], nil
+}
+
+// sessionKey identifies the open session of one kind on one device.
+type sessionKey struct {
+	device string
+	kind   string
+}
+
+// sessionBatch holds the sessions touched while folding one batch of activities.
+type sessionBatch struct {
+	rules SessionRules
+	// load returns the open session for a key, or nil.
+	load    func(key sessionKey) (*models.Session, error)
+	open    map[sessionKey]*models.Session
+	touched []*models.Session
+}
+
+// find returns the open session for key, loading it on first use.
+func (b *sessionBatch) find(key sessionKey) (*models.Session, error) {
+	if session, ok := b.open[key]; ok {
+		return session, nil
+	}
+	session, err := b.load(key)
+	if err != nil {
+		return nil, err
+	}
+	b.open[key] = session
+	return session, nil
+}
+
+// findOpen returns the open session for key, or nil.
+func (r *SessionRepository) findOpen(key sessionKey) (*models.Session, error) {
+	query, err := r.box.QueryOrError(
+		models.Session_.DeviceName.Equals(key.device, true),
+		models.Session_.Status.Equals(models.SessionOpen, true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	sessions, err := query.Find()
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		if session.Kind == key.kind {
+			return session, nil
+		}
+	}
+	return nil, nil
+}
+
+func (b *sessionBatch) end(key sessionKey, session *models.Session, status string, at time.Time) {
+	session.Status = status
+	session.EndedAt = at
+	b.open[key] = nil
+}
+
+// observe folds one activity into the sessions of its device.
+func (b *sessionBatch) observe(activity *models.DeviceActivity) error {
+	for start, end := range b.rules.Pairs {
+		key := sessionKey{device: activity.DeviceName, kind: start}
+		session, err := b.find(key)
+		if err != nil {
+			return err
+		}
+		ts := activity.Timestamp
+
+		if session != nil && b.rules.IdleTimeout > 0 && ts.Sub(session.LastActivityAt) > b.rules.IdleTimeout {
+			b.end(key, session, models.SessionTimedOut, session.LastActivityAt)
+			session = nil
+		}
+
+		switch {
+		case activity.Action == start:
+			if session != nil {
+				b.end(key, session, models.SessionSuperseded, session.LastActivityAt)
+			}
+			session = &models.Session{
+				DeviceName:      activity.DeviceName,
+				GridName:        activity.GridName,
+				Kind:            start,
+				Status:          models.SessionOpen,
+				StartedAt:       ts,
+				LastActivityAt:  ts,
+				StartActivityId: activity.Id,
+				ActivityCount:   1,
+			}
+			b.open[key] = session
+			b.touched = append(b.touched, session)
+		case session == nil:
+			// Activities outside a session, including unmatched end actions, are ignored.
+			continue
+		case activity.Action == end:
+			session.ActivityCount++
+			session.LastActivityAt = ts
+			session.EndActivityId = activity.Id
+			b.end(key, session, models.SessionClosed, ts)
+			b.touched = append(b.touched, session)
+		default:
+			session.ActivityCount++
+			if ts.After(session.LastActivityAt) {
+				session.LastActivityAt = ts
+			}
+			b.touched = append(b.touched, session)
+		}
+	}
+	return nil
+}
+
+// Build folds up to limit activities into sessions and advances the
+// checkpoint in the same transaction. Activities are folded in Timestamp
+// order, then Id order, from the checkpoint up to those whose Timestamp is
+// rules.Lateness before now. It returns how many activities were processed;
+// callers repeat it until that is fewer than limit. Synthetic and deleted
+// activities are skipped.
+func (r *SessionRepository) Build(rules SessionRules, now time.Time, limit int) (int, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("build", "session").Observe(duration)
+	}()
+
+	processed := 0
+	err := r.ob.RunInWriteTx(func() error {
+		checkpoint, err := r.checkpoint()
+		if err != nil {
+			return err
+		}
+		if err := r.migrateCheckpoint(checkpoint); err != nil {
+			return err
+		}
+		last := checkpoint.LastTimestamp.UnixMilli()
+		query, err := r.activities.QueryOrError(
+			objectbox.Any(
+				models.DeviceActivity_.Timestamp.GreaterThan(last),
+				objectbox.All(
+					models.DeviceActivity_.Timestamp.Equals(last),
+					models.DeviceActivity_.Id.GreaterThan(checkpoint.LastActivityId),
+				),
+			),
+			models.DeviceActivity_.Timestamp.LessOrEqual(now.Add(-rules.Lateness).UnixMilli()),
+			models.DeviceActivity_.Timestamp.OrderAsc(),
+			models.DeviceActivity_.Id.OrderAsc(),
+		)
+		if err != nil {
+			return err
+		}
+		defer query.Close()
+
+		activities, err := query.Limit(uint64(limit)).Find()
+		if err != nil || len(activities) == 0 {
+			return err
+		}
+
+		batch := &sessionBatch{rules: rules, load: r.findOpen, open: make(map[sessionKey]*models.Session)}
+		for _, activity := range activities {
+			if activity.DeviceName != "" && !activity.Synthetic && !activity.IsDeleted() {
+				if err := batch.observe(activity); err != nil {
+					return err
+				}
+			}
+			checkpoint.LastTimestamp = activity.Timestamp
+			checkpoint.LastActivityId = activity.Id
+		}
+
+		seen := make(map[*models.Session]bool, len(batch.touched))
+		for _, session := range batch.touched {
+			if seen[session] {
+				continue
+			}
+			seen[session] = true
+			if _, err := r.box.Put(session); err != nil {
+				return err
+			}
+		}
+		checkpoint.UpdatedAt = start
+		if _, err := r.checkpoints.Put(checkpoint); err != nil {
+			return err
+		}
+		processed = len(activities)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("build", "session").Inc()
+	if processed > 0 {
+		r.updateMetrics()
+	}
+	return processed, nil
+}
+
+// migrateCheckpoint sets LastTimestamp on a checkpoint written when
+// activities were folded in Id order, to the latest Timestamp folded then.
+func (r *SessionRepository) migrateCheckpoint(checkpoint *models.SessionCheckpoint) error {
+	if checkpoint.LastActivityId == 0 || checkpoint.LastTimestamp.UnixMilli() > 0 {
+		return nil
+	}
+	query, err := r.activities.QueryOrError(models.DeviceActivity_.Id.LessOrEqual(checkpoint.LastActivityId))
+	if err != nil {
+		return err
+	}
+	defer query.Close()
+
+	latest, err := query.PropertyOrError(models.DeviceActivity_.Timestamp)
+	if err != nil {
+		return err
+	}
+	millis, err := latest.Max()
+	if err != nil {
+		return err
+	}
+	checkpoint.LastTimestamp = time.UnixMilli(millis)
+	return nil
+}
+
+// ExpireIdle times out open sessions whose last activity is older than the
+// idle timeout and the lateness at now and returns how many were ended.
+func (r *SessionRepository) ExpireIdle(rules SessionRules, now time.Time) (int, error) {
+	if rules.IdleTimeout <= 0 {
+		return 0, nil
+	}
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("expire", "session").Observe(duration)
+	}()
+
+	// Activities within the lateness may still extend a session.
+	cutoff := now.Add(-rules.IdleTimeout - rules.Lateness)
+	expired := 0
+	err := r.ob.RunInWriteTx(func() error {
+		query, err := r.box.QueryOrError(models.Session_.Status.Equals(models.SessionOpen, true))
+		if err != nil {
+			return err
+		}
+		defer query.Close()
+
+		sessions, err := query.Find()
+		if err != nil {
+			return err
+		}
+		var ended []*models.Session
+		for _, session := range sessions {
+			if session.LastActivityAt.Before(cutoff) {
+				session.Status = models.SessionTimedOut
+				session.EndedAt = session.LastActivityAt
+				ended = append(ended, session)
+			}
+		}
+		if len(ended) == 0 {
+			return nil
+		}
+		_, err = r.box.PutMany(ended)
+		expired = len(ended)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("expire", "session").Inc()
+	if expired > 0 {
+		r.updateMetrics()
+	}
+	return expired, nil
+}
+
+// GetByDevice returns a page of the device's sessions, newest first,
+// optionally only those with the given status.
+func (r *SessionRepository) GetByDevice(device, status, cursor string, limit int) (SessionPage, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_by_device", "session").Observe(duration)
+	}()
+
+	conditions := []objectbox.Condition{
+		models.Session_.DeviceName.Equals(device, true),
+		models.Session_.Id.OrderDesc(),
+	}
+	if status != "" {
+		conditions = append(conditions, models.Session_.Status.Equals(status, true))
+	}
+	if cursor != "" {
+		beforeId, err := strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return SessionPage{}, ErrInvalidCursor
+		}
+		conditions = append(conditions, models.Session_.Id.LessThan(beforeId))
+	}
+
+	query, err := r.box.QueryOrError(conditions...)
+	if err != nil {
+		return SessionPage{}, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(uint64(limit + 1)).Find()
+	if err != nil {
+		return SessionPage{}, err
+	}
+	var page SessionPage
+	if len(results) > limit {
+		results = results[:limit]
+		page.NextCursor = strconv.FormatUint(results[limit-1].Id, 10)
+	}
+	page.Sessions = make([]models.Session, len(results))
+	for i, session := range results {
+		page.Sessions[i] = *session
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_by_device", "session").Inc()
+	return page, nil
+}
+
+// GetByGridsBetween returns the sessions in any of the grids that overlap
+// [from, to): started before to and still open or ended at or after from.
+func (r *SessionRepository) GetByGridsBetween(gridNames []string, from, to time.Time) ([]models.Session, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_by_grid", "session").Observe(duration)
+	}()
+
+	query, err := r.box.QueryOrError(
+		models.Session_.GridName.In(true, gridNames...),
+		models.Session_.StartedAt.LessThan(to.UnixMilli()),
+		objectbox.Any(
+			models.Session_.Status.Equals(models.SessionOpen, true),
+			models.Session_.EndedAt.GreaterOrEqual(from.UnixMilli()),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Find()
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]models.Session, len(results))
+	for i, session := range results {
+		sessions[i] = *session
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_by_grid", "session").Inc()
+	return sessions, nil
+}
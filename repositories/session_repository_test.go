@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"go-rest-api/models"
+	"testing"
+	"time"
+)
+
+var sessionBase = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+func sessionActivity(id uint64, action string, minute int) *models.DeviceActivity {
+	return &models.DeviceActivity{Id: id, DeviceName: "dev-1", GridName: "eu", Action: action,
+		Timestamp: sessionBase.Add(time.Duration(minute) * time.Minute)}
+}
+
+// foldSessions folds activities into sessions, starting without any open
+// session, and returns every session touched in the order first touched.
+func foldSessions(t *testing.T, rules SessionRules, activities ...*models.DeviceActivity) []*models.Session {
+	t.Helper()
+	batch := &sessionBatch{
+		rules: rules,
+		load:  func(sessionKey) (*models.Session, error) { return nil, nil },
+		open:  make(map[sessionKey]*models.Session),
+	}
+	for _, activity := range activities {
+		if err := batch.observe(activity); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var sessions []*models.Session
+	seen := make(map[*models.Session]bool)
+	for _, session := range batch.touched {
+		if !seen[session] {
+			seen[session] = true
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+func TestSessionBatchObserve(t *testing.T) {
+	rules := SessionRules{Pairs: map[string]string{"login": "logout"}, IdleTimeout: 30 * time.Minute}
+	type want struct {
+		status        string
+		startMinute   int
+		endMinute     int
+		activityCount int64
+		endActivityId uint64
+	}
+	tests := []struct {
+		name       string
+		rules      SessionRules
+		activities []*models.DeviceActivity
+		want       []want
+	}{
+		{"pair", rules, []*models.DeviceActivity{
+			sessionActivity(1, "login", 0), sessionActivity(2, "view", 5), sessionActivity(3, "logout", 10),
+		}, []want{{models.SessionClosed, 0, 10, 3, 3}}},
+		{"open", rules, []*models.DeviceActivity{
+			sessionActivity(1, "login", 0), sessionActivity(2, "view", 5),
+		}, []want{{models.SessionOpen, 0, 0, 2, 0}}},
+		{"unmatched end", rules, []*models.DeviceActivity{
+			sessionActivity(1, "logout", 0), sessionActivity(2, "view", 5),
+		}, nil},
+		{"idle timeout", rules, []*models.DeviceActivity{
+			sessionActivity(1, "login", 0), sessionActivity(2, "view", 10), sessionActivity(3, "view", 41),
+			sessionActivity(4, "logout", 45),
+		}, []want{{models.SessionTimedOut, 0, 10, 2, 0}}},
+		{"activity at the idle timeout", rules, []*models.DeviceActivity{
+			sessionActivity(1, "login", 0), sessionActivity(2, "logout", 30),
+		}, []want{{models.SessionClosed, 0, 30, 2, 2}}},
+		{"no idle timeout", SessionRules{Pairs: rules.Pairs}, []*models.DeviceActivity{
+			sessionActivity(1, "login", 0), sessionActivity(2, "logout", 600),
+		}, []want{{models.SessionClosed, 0, 600, 2, 2}}},
+		{"start after timeout", rules, []*models.DeviceActivity{
+			sessionActivity(1, "login", 0), sessionActivity(2, "login", 40),
+		}, []want{{models.SessionTimedOut, 0, 0, 1, 0}, {models.SessionOpen, 40, 0, 1, 0}}},
+		{"superseded", rules, []*models.DeviceActivity{
+			sessionActivity(1, "login", 0), sessionActivity(2, "view", 5), sessionActivity(3, "login", 8),
+			sessionActivity(4, "logout", 9),
+		}, []want{{models.SessionSuperseded, 0, 5, 2, 0}, {models.SessionClosed, 8, 9, 2, 4}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions := foldSessions(t, tt.rules, tt.activities...)
+			if len(sessions) != len(tt.want) {
+				t.Fatalf("got %d sessions, want %d", len(sessions), len(tt.want))
+			}
+			for i, w := range tt.want {
+				session := sessions[i]
+				wantEnd := time.Time{}
+				if w.status != models.SessionOpen {
+					wantEnd = sessionBase.Add(time.Duration(w.endMinute) * time.Minute)
+				}
+				if session.Status != w.status || !session.StartedAt.Equal(sessionBase.Add(time.Duration(w.startMinute)*time.Minute)) ||
+					!session.EndedAt.Equal(wantEnd) || session.ActivityCount != w.activityCount || session.EndActivityId != w.endActivityId {
+					t.Errorf("session %d = %s from %s to %s, %d activities, end %d; want %+v", i, session.Status,
+						session.StartedAt.Format(time.Kitchen), session.EndedAt.Format(time.Kitchen), session.ActivityCount,
+						session.EndActivityId, w)
+				}
+			}
+		})
+	}
+}
+
+func TestSessionBatchObserveKinds(t *testing.T) {
+	rules := SessionRules{Pairs: map[string]string{"login": "logout", "door_open": "door_close"}}
+	sessions := foldSessions(t, rules,
+		sessionActivity(1, "login", 0), sessionActivity(2, "door_open", 1), sessionActivity(3, "door_close", 2),
+		sessionActivity(4, "logout", 3))
+	kinds := make(map[string]*models.Session)
+	for _, session := range sessions {
+		kinds[session.Kind] = session
+	}
+	if login := kinds["login"]; login == nil || login.Status != models.SessionClosed || login.ActivityCount != 4 {
+		t.Errorf("login session = %+v", login)
+	}
+	if door := kinds["door_open"]; door == nil || door.Status != models.SessionClosed || door.ActivityCount != 2 {
+		t.Errorf("door session = %+v", door)
+	}
+}
+
+func TestSessionRepositoryBuild(t *testing.T) {
+	ob := openTestObjectBox(t)
+	repo := NewSessionRepository(ob)
+	activities := models.BoxForDeviceActivity(ob)
+	rules := SessionRules{Pairs: map[string]string{"login": "logout"}, IdleTimeout: time.Hour, Lateness: 5 * time.Minute}
+	put := func(action string, minute int) {
+		t.Helper()
+		if _, err := activities.Put(sessionActivity(0, action, minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	build := func(minute int) {
+		t.Helper()
+		if _, err := repo.Build(rules, sessionBase.Add(time.Duration(minute)*time.Minute), 1000); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sessions := func() []models.Session {
+		t.Helper()
+		page, err := repo.GetByDevice("dev-1", "", "", 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return page.Sessions
+	}
+
+	// The logout is stored before the view it follows, within the lateness.
+	put("login", 0)
+	put("logout", 10)
+	put("view", 8)
+	build(12)
+	if got := sessions(); len(got) != 1 || got[0].Status != models.SessionOpen || got[0].ActivityCount != 1 {
+		t.Fatalf("activities within the lateness were folded: %+v", got)
+	}
+	build(15)
+	got := sessions()
+	if len(got) != 1 || got[0].Status != models.SessionClosed || got[0].ActivityCount != 3 {
+		t.Fatalf("sessions = %+v, want one closed with 3 activities", got)
+	}
+
+	// A login older than the last activity folded arrives too late.
+	put("login", 9)
+	build(30)
+	if got := sessions(); len(got) != 1 {
+		t.Errorf("a late start action opened a session: %+v", got)
+	}
+	put("login", 20)
+	build(30)
+	if got := sessions(); len(got) != 2 || got[0].Status != models.SessionOpen {
+		t.Errorf("sessions = %+v, want a new open one", got)
+	}
+}
@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"errors"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+var (
+	ErrSLONotFound = errors.New("SLO not found")
+	ErrSLOExists   = errors.New("SLO already exists")
+)
+
+// SLORepository stores SLO definitions.
+type SLORepository struct {
+	ob  *objectbox.ObjectBox
+	box *models.SLOBox
+}
+
+func NewSLORepository(ob *objectbox.ObjectBox) *SLORepository {
+	repo := &SLORepository{ob: ob, box: models.BoxForSLO(ob)}
+	repo.updateMetrics()
+	return repo
+}
+
+func (r *SLORepository) updateMetrics() {
+	if count, err := r.box.Count(); err == nil {
+		metrics.ObjectBoxEntityCount.WithLabelValues("slo").Set(float64(count))
+	}
+}
+
+// GetAll returns every SLO ordered by name.
+func (r *SLORepository) GetAll() ([]models.SLO, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_all", "slo").Observe(duration)
+	}()
+
+	query, err := r.box.QueryOrError(models.SLO_.Name.OrderAsc(true))
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Find()
+	if err != nil {
+		return nil, err
+	}
+	slos := make([]models.SLO, len(results))
+	for i, slo := range results {
+		slos[i] = *slo
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_all", "slo").Inc()
+	return slos, nil
+}
+
+func (r *SLORepository) find(name string) (*models.SLO, error) {
+	query, err := r.box.QueryOrError(models.SLO_.Name.Equals(name, true))
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(1).Find()
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// Get returns the named SLO or ErrSLONotFound.
+func (r *SLORepository) Get(name string) (*models.SLO, error) {
+	slo, err := r.find(name)
+	if err != nil {
+		return nil, err
+	}
+	if slo == nil {
+		return nil, ErrSLONotFound
+	}
+	return slo, nil
+}
+
+// Create stores a new SLO, or returns ErrSLOExists.
+func (r *SLORepository) Create(slo *models.SLO) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("create", "slo").Observe(duration)
+	}()
+
+	err := r.ob.RunInWriteTx(func() error {
+		existing, err := r.find(slo.Name)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return ErrSLOExists
+		}
+		slo.CreatedAt = start
+		slo.UpdatedAt = start
+		_, err = r.box.Put(slo)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("create", "slo").Inc()
+	r.updateMetrics()
+	return nil
+}
+
+// Update applies fn to the named SLO and stores it unless fn returns an error.
+func (r *SLORepository) Update(name string, fn func(*models.SLO) error) (*models.SLO, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("update", "slo").Observe(duration)
+	}()
+
+	var slo *models.SLO
+	err := r.ob.RunInWriteTx(func() error {
+		var err error
+		if slo, err = r.find(name); err != nil {
+			return err
+		}
+		if slo == nil {
+			return ErrSLONotFound
+		}
+		if err := fn(slo); err != nil {
+			return err
+		}
+		slo.UpdatedAt = start
+		_, err = r.box.Put(slo)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("update", "slo").Inc()
+	return slo, nil
+}
+
+// Delete removes the named SLO or returns ErrSLONotFound.
+func (r *SLORepository) Delete(name string) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("delete", "slo").Observe(duration)
+	}()
+
+	err := r.ob.RunInWriteTx(func() error {
+		slo, err := r.find(name)
+		if err != nil {
+			return err
+		}
+		if slo == nil {
+			return ErrSLONotFound
+		}
+		return r.box.Remove(slo)
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("delete", "slo").Inc()
+	r.updateMetrics()
+	return nil
+}
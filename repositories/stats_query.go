@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"fmt"
+	"go-rest-api/models"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+// Endpoint match modes of a StatsQuery
+const (
+	EndpointExact  = "exact"
+	EndpointPrefix = "prefix"
+	// EndpointGlob matches like path.Match: '*' and '?' stay within one
+	// path segment.
+	EndpointGlob = "glob"
+	// EndpointRoute matches a route template such as
+	// /api/v1/activities/device/:device. A ':name' segment matches any one
+	// segment and a trailing '*name' segment the rest of the path, so the
+	// template matches both the recorded route and concrete paths.
+	EndpointRoute = "route"
+)
+
+// StatsQuery selects usage stats. Zero fields do not restrict the selection.
+type StatsQuery struct {
+	Endpoint string
+	// Match is how Endpoint is matched, EndpointExact when empty.
+	Match   string
+	Methods []string
+	// StatusMin and StatusMax bound Status, inclusive.
+	StatusMin int
+	StatusMax int
+	// From and To bound Timestamp to [From, To).
+	From time.Time
+	To   time.Time
+}
+
+// Validate checks the match mode and pattern and the ranges.
+func (q StatsQuery) Validate() error {
+	switch q.Match {
+	case "", EndpointExact, EndpointPrefix:
+	case EndpointGlob:
+		if _, err := path.Match(q.Endpoint, ""); err != nil {
+			return fmt.Errorf("endpoint: invalid glob %q", q.Endpoint)
+		}
+	case EndpointRoute:
+		segments := strings.Split(q.Endpoint, "/")
+		for i, segment := range segments {
+			if strings.HasPrefix(segment, "*") && i != len(segments)-1 {
+				return fmt.Errorf("endpoint: catch-all segment %q must be last", segment)
+			}
+		}
+	default:
+		return fmt.Errorf("match: unknown mode %q, expected %s, %s, %s or %s",
+			q.Match, EndpointExact, EndpointPrefix, EndpointGlob, EndpointRoute)
+	}
+	if q.Match != "" && q.Match != EndpointExact && q.Endpoint == "" {
+		return fmt.Errorf("match: %s needs an endpoint", q.Match)
+	}
+	if q.StatusMin != 0 && q.StatusMax != 0 && q.StatusMin > q.StatusMax {
+		return fmt.Errorf("status: %d is greater than %d", q.StatusMin, q.StatusMax)
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		return fmt.Errorf("from must be before to")
+	}
+	return nil
+}
+
+// IsEmpty reports whether the query selects every entry.
+func (q StatsQuery) IsEmpty() bool {
+	return q.Endpoint == "" && !q.restrictsEntries()
+}
+
+// restrictsEntries reports whether the query selects only some entries of
+// the endpoints it matches.
+func (q StatsQuery) restrictsEntries() bool {
+	return len(q.Methods) > 0 || q.StatusMin != 0 || q.StatusMax != 0 || !q.From.IsZero() || !q.To.IsZero()
+}
+
+// MatchesEndpoint reports whether endpoint is selected. The query must be valid.
+func (q StatsQuery) MatchesEndpoint(endpoint string) bool {
+	if q.Endpoint == "" {
+		return true
+	}
+	switch q.Match {
+	case EndpointPrefix:
+		return strings.HasPrefix(endpoint, q.Endpoint)
+	case EndpointGlob:
+		matched, _ := path.Match(q.Endpoint, endpoint)
+		return matched
+	case EndpointRoute:
+		return routeMatches(q.Endpoint, endpoint)
+	}
+	return endpoint == q.Endpoint
+}
+
+func routeMatches(template, endpoint string) bool {
+	patterns := strings.Split(template, "/")
+	segments := strings.Split(endpoint, "/")
+	for i, pattern := range patterns {
+		if strings.HasPrefix(pattern, "*") {
+			return true
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if strings.HasPrefix(pattern, ":") {
+			if segments[i] == "" {
+				return false
+			}
+		} else if segments[i] != pattern {
+			return false
+		}
+	}
+	return len(segments) == len(patterns)
+}
+
+// Matches evaluates the query against a single entry in memory. The query
+// must be valid.
+func (q StatsQuery) Matches(stats models.UsageStats) bool {
+	if !q.MatchesEndpoint(stats.Endpoint) {
+		return false
+	}
+	if len(q.Methods) > 0 {
+		found := false
+		for _, method := range q.Methods {
+			if stats.Method == method {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if (q.StatusMin != 0 && stats.Status < q.StatusMin) || (q.StatusMax != 0 && stats.Status > q.StatusMax) {
+		return false
+	}
+	ts := stats.Timestamp.UnixMilli()
+	if (!q.From.IsZero() && ts < q.From.UnixMilli()) || (!q.To.IsZero() && ts >= q.To.UnixMilli()) {
+		return false
+	}
+	return true
+}
+
+// endpointPrefix is the literal start every matching endpoint has.
+func (q StatsQuery) endpointPrefix() string {
+	switch q.Match {
+	case EndpointGlob:
+		if i := strings.IndexAny(q.Endpoint, `*?[\`); i >= 0 {
+			return q.Endpoint[:i]
+		}
+	case EndpointRoute:
+		for i := 0; i < len(q.Endpoint); i++ {
+			if (q.Endpoint[i] == ':' || q.Endpoint[i] == '*') && (i == 0 || q.Endpoint[i-1] == '/') {
+				return q.Endpoint[:i]
+			}
+		}
+	}
+	return q.Endpoint
+}
+
+// conditions narrows the query down in ObjectBox. Glob and route patterns
+// are only narrowed to their literal prefix; Matches does the rest.
+func (q StatsQuery) conditions() []objectbox.Condition {
+	var conditions []objectbox.Condition
+	if q.Endpoint != "" {
+		if q.Match == "" || q.Match == EndpointExact {
+			conditions = append(conditions, models.UsageStats_.Endpoint.Equals(q.Endpoint, true))
+		} else if prefix := q.endpointPrefix(); prefix != "" {
+			conditions = append(conditions, models.UsageStats_.Endpoint.HasPrefix(prefix, true))
+		}
+	}
+	if len(q.Methods) > 0 {
+		conditions = append(conditions, models.UsageStats_.Method.In(true, q.Methods...))
+	}
+	if q.StatusMin != 0 {
+		conditions = append(conditions, models.UsageStats_.Status.GreaterOrEqual(q.StatusMin))
+	}
+	if q.StatusMax != 0 {
+		conditions = append(conditions, models.UsageStats_.Status.LessOrEqual(q.StatusMax))
+	}
+	if !q.From.IsZero() {
+		conditions = append(conditions, models.UsageStats_.Timestamp.GreaterOrEqual(q.From.UnixMilli()))
+	}
+	if !q.To.IsZero() {
+		conditions = append(conditions, models.UsageStats_.Timestamp.LessThan(q.To.UnixMilli()))
+	}
+	return conditions
+}
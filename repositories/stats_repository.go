@@ -0,0 +1,372 @@
+package repositories
+
+import (
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+// StatsRepository is the StatsStore backed by ObjectBox.
+type StatsRepository struct {
+	ob      *objectbox.ObjectBox
+	box     *models.UsageStatsBox
+	rollups *models.StatsRollupBox
+}
+
+func NewStatsRepository(ob *objectbox.ObjectBox) *StatsRepository {
+	repo := &StatsRepository{
+		ob:      ob,
+		box:     models.BoxForUsageStats(ob),
+		rollups: models.BoxForStatsRollup(ob),
+	}
+	repo.updateMetrics()
+	return repo
+}
+
+func (r *StatsRepository) updateMetrics() {
+	count, err := r.box.Count()
+	if err == nil {
+		metrics.ObjectBoxEntityCount.WithLabelValues("usage_stats").Set(float64(count))
+	}
+}
+
+func (r *StatsRepository) Create(stats *models.UsageStats) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("create", "usage_stats").Observe(duration)
+	}()
+
+	err := r.ob.RunInWriteTx(func() error {
+		if _, err := r.box.Put(stats); err != nil {
+			return err
+		}
+		return r.addToRollups([]*models.UsageStats{stats})
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("create", "usage_stats").Inc()
+	r.updateMetrics()
+	return nil
+}
+
+// CreateMany stores all stats, and folds them into their rollups, in a
+// single transaction.
+func (r *StatsRepository) CreateMany(stats []*models.UsageStats) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("create_many", "usage_stats").Observe(duration)
+	}()
+
+	err := r.ob.RunInWriteTx(func() error {
+		if _, err := r.box.PutMany(stats); err != nil {
+			return err
+		}
+		return r.addToRollups(stats)
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("create_many", "usage_stats").Inc()
+	r.updateMetrics()
+	return nil
+}
+
+// addToRollups folds entries into the rollups of their buckets. It must run
+// inside a write transaction.
+func (r *StatsRepository) addToRollups(entries []*models.UsageStats) error {
+	for key, group := range groupByRollup(entries) {
+		rollup, err := r.findRollup(key)
+		if err != nil {
+			return err
+		}
+		if rollup == nil {
+			rollup = newRollup(key)
+		}
+		if err := addToRollup(rollup, group); err != nil {
+			return err
+		}
+		if _, err := r.rollups.Put(rollup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *StatsRepository) findRollup(key rollupKey) (*models.StatsRollup, error) {
+	query, err := r.rollups.QueryOrError(
+		models.StatsRollup_.Endpoint.Equals(key.endpoint, true),
+		models.StatsRollup_.BucketStart.Equals(key.bucket),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	candidates, err := query.Find()
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range candidates {
+		if candidate.Method == key.method {
+			return candidate, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetRollups returns the rollups whose bucket starts within [from, to).
+func (r *StatsRepository) GetRollups(from, to time.Time) ([]models.StatsRollup, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_rollups", "stats_rollup").Observe(duration)
+	}()
+
+	query, err := r.rollups.QueryOrError(
+		models.StatsRollup_.BucketStart.GreaterOrEqual(from.UnixMilli()),
+		models.StatsRollup_.BucketStart.LessThan(to.UnixMilli()),
+		models.StatsRollup_.BucketStart.OrderAsc(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Find()
+	if err != nil {
+		return nil, err
+	}
+	rollups := make([]models.StatsRollup, len(results))
+	for i, result := range results {
+		rollups[i] = *result
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_rollups", "stats_rollup").Inc()
+	return rollups, nil
+}
+
+func (r *StatsRepository) GetAll() ([]models.UsageStats, error) {
+	return r.find("get_all")
+}
+
+func (r *StatsRepository) GetByEndpoint(endpoint string) ([]models.UsageStats, error) {
+	return r.find("get_by_endpoint", models.UsageStats_.Endpoint.Equals(endpoint, true))
+}
+
+func (r *StatsRepository) Find(query StatsQuery) ([]models.UsageStats, error) {
+	stats, err := r.find("find", query.conditions()...)
+	if err != nil {
+		return nil, err
+	}
+	matching := stats[:0]
+	for _, entry := range stats {
+		if query.Matches(entry) {
+			matching = append(matching, entry)
+		}
+	}
+	return matching, nil
+}
+
+func (r *StatsRepository) find(operation string, conditions ...objectbox.Condition) ([]models.UsageStats, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues(operation, "usage_stats").Observe(duration)
+	}()
+
+	conditions = append(conditions, models.UsageStats_.Timestamp.OrderAsc())
+	query, err := r.box.QueryOrError(conditions...)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Find()
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]models.UsageStats, len(results))
+	for i, result := range results {
+		stats[i] = *result
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues(operation, "usage_stats").Inc()
+	return stats, nil
+}
+
+func (r *StatsRepository) DeleteByEndpoint(endpoint string) (uint64, error) {
+	removed, err := r.remove("delete_by_endpoint", []objectbox.Condition{models.UsageStats_.Endpoint.Equals(endpoint, true)}, 0)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := r.removeRollups(0, models.StatsRollup_.Endpoint.Equals(endpoint, true)); err != nil {
+		return removed, err
+	}
+	return removed, nil
+}
+
+func (r *StatsRepository) DeleteMatching(query StatsQuery) (uint64, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("delete_matching", "usage_stats").Observe(duration)
+	}()
+
+	var removed uint64
+	err := r.ob.RunInWriteTx(func() error {
+		statsQuery, err := r.box.QueryOrError(query.conditions()...)
+		if err != nil {
+			return err
+		}
+		defer statsQuery.Close()
+
+		stats, err := statsQuery.Find()
+		if err != nil {
+			return err
+		}
+		var ids []uint64
+		for _, entry := range stats {
+			if query.Matches(*entry) {
+				ids = append(ids, entry.ObjectId)
+			}
+		}
+		if len(ids) > 0 {
+			if removed, err = r.box.RemoveIds(ids...); err != nil {
+				return err
+			}
+		}
+		if query.restrictsEntries() {
+			return nil
+		}
+
+		var conditions []objectbox.Condition
+		if prefix := query.endpointPrefix(); prefix != "" {
+			conditions = append(conditions, models.StatsRollup_.Endpoint.HasPrefix(prefix, true))
+		}
+		rollupQuery, err := r.rollups.QueryOrError(conditions...)
+		if err != nil {
+			return err
+		}
+		defer rollupQuery.Close()
+
+		rollups, err := rollupQuery.Find()
+		if err != nil {
+			return err
+		}
+		ids = ids[:0]
+		for _, rollup := range rollups {
+			if query.MatchesEndpoint(rollup.Endpoint) {
+				ids = append(ids, rollup.Id)
+			}
+		}
+		if len(ids) > 0 {
+			_, err = r.rollups.RemoveIds(ids...)
+		}
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("delete_matching", "usage_stats").Inc()
+	r.updateMetrics()
+	return removed, nil
+}
+
+func (r *StatsRepository) Delete(id string) error {
+	removed, err := r.remove("delete", []objectbox.Condition{models.UsageStats_.ID.Equals(id, true)}, 0)
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return ErrStatsNotFound
+	}
+	return nil
+}
+
+func (r *StatsRepository) Count() (uint64, error) {
+	return r.box.Count()
+}
+
+func (r *StatsRepository) RemoveOlderThan(cutoff time.Time, limit int) (uint64, error) {
+	return r.remove("prune", []objectbox.Condition{
+		models.UsageStats_.Timestamp.LessThan(cutoff.UnixMilli()),
+		models.UsageStats_.Timestamp.OrderAsc(),
+	}, limit)
+}
+
+func (r *StatsRepository) RemoveRollupsBefore(cutoff time.Time, limit int) (uint64, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("prune", "stats_rollup").Observe(duration)
+	}()
+
+	// Rollups go once their whole bucket is before the cutoff.
+	bucketCutoff := cutoff.Truncate(StatsRollupInterval)
+	removed, err := r.removeRollups(limit,
+		models.StatsRollup_.BucketStart.LessThan(bucketCutoff.UnixMilli()),
+		models.StatsRollup_.BucketStart.OrderAsc())
+	if err != nil {
+		return 0, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("prune", "stats_rollup").Inc()
+	return removed, nil
+}
+
+// removeRollups deletes the matching rollups, up to limit unless limit is 0.
+func (r *StatsRepository) removeRollups(limit int, conditions ...objectbox.Condition) (uint64, error) {
+	query, err := r.rollups.QueryOrError(conditions...)
+	if err != nil {
+		return 0, err
+	}
+	defer query.Close()
+
+	if limit > 0 {
+		query.Limit(uint64(limit))
+	}
+	ids, err := query.FindIds()
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	return r.rollups.RemoveIds(ids...)
+}
+
+// remove deletes the matching entries, up to limit unless limit is 0.
+func (r *StatsRepository) remove(operation string, conditions []objectbox.Condition, limit int) (uint64, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues(operation, "usage_stats").Observe(duration)
+	}()
+
+	query, err := r.box.QueryOrError(conditions...)
+	if err != nil {
+		return 0, err
+	}
+	defer query.Close()
+
+	if limit > 0 {
+		query.Limit(uint64(limit))
+	}
+	ids, err := query.FindIds()
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	removed, err := r.box.RemoveIds(ids...)
+	if err != nil {
+		return 0, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues(operation, "usage_stats").Inc()
+	r.updateMetrics()
+	return removed, nil
+}
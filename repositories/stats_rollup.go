@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"encoding/json"
+	"go-rest-api/models"
+	"go-rest-api/utils"
+	"time"
+)
+
+// StatsRollupInterval is the bucket size of stats rollups. Summaries are
+// aligned to it.
+const StatsRollupInterval = 5 * time.Minute
+
+type rollupKey struct {
+	endpoint string
+	method   string
+	bucket   int64
+}
+
+func rollupKeyOf(stats *models.UsageStats) rollupKey {
+	return rollupKey{
+		endpoint: stats.Endpoint,
+		method:   stats.Method,
+		bucket:   stats.Timestamp.Truncate(StatsRollupInterval).UnixMilli(),
+	}
+}
+
+// groupByRollup groups entries by the rollup they belong to.
+func groupByRollup(entries []*models.UsageStats) map[rollupKey][]*models.UsageStats {
+	groups := make(map[rollupKey][]*models.UsageStats)
+	for _, entry := range entries {
+		key := rollupKeyOf(entry)
+		groups[key] = append(groups[key], entry)
+	}
+	return groups
+}
+
+func newRollup(key rollupKey) *models.StatsRollup {
+	return &models.StatsRollup{
+		Endpoint:    key.endpoint,
+		Method:      key.method,
+		BucketStart: time.UnixMilli(key.bucket),
+	}
+}
+
+// addToRollup folds entries into rollup.
+func addToRollup(rollup *models.StatsRollup, entries []*models.UsageStats) error {
+	sketch, err := RollupLatency(*rollup)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		rollup.Count++
+		switch entry.Status / 100 {
+		case 2:
+			rollup.Status2xx++
+		case 3:
+			rollup.Status3xx++
+		case 4:
+			rollup.Status4xx++
+		case 5:
+			rollup.Status5xx++
+		}
+		if entry.DurationMs > 0 {
+			sketch.Add(entry.DurationMs)
+		}
+	}
+	data, err := json.Marshal(sketch)
+	if err != nil {
+		return err
+	}
+	rollup.Latency = string(data)
+	return nil
+}
+
+// RollupLatency decodes the latency sketch of a rollup.
+func RollupLatency(rollup models.StatsRollup) (*utils.DDSketch, error) {
+	sketch := utils.NewDDSketch()
+	if rollup.Latency == "" {
+		return sketch, nil
+	}
+	if err := json.Unmarshal([]byte(rollup.Latency), sketch); err != nil {
+		return nil, err
+	}
+	return sketch, nil
+}
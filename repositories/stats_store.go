@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"errors"
+	"go-rest-api/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrStatsNotFound = errors.New("stats not found")
+
+var (
+	_ StatsStore = (*StatsRepository)(nil)
+	_ StatsStore = (*MemoryStatsStore)(nil)
+)
+
+// StatsStore stores usage statistics. Implementations are safe for
+// concurrent use; lists are ordered by Timestamp, oldest first.
+type StatsStore interface {
+	Create(stats *models.UsageStats) error
+	CreateMany(stats []*models.UsageStats) error
+	GetAll() ([]models.UsageStats, error)
+	GetByEndpoint(endpoint string) ([]models.UsageStats, error)
+	// Find returns the entries selected by a valid query.
+	Find(query StatsQuery) ([]models.UsageStats, error)
+	// DeleteByEndpoint deletes every entry of the endpoint and returns how many there were.
+	DeleteByEndpoint(endpoint string) (uint64, error)
+	// DeleteMatching deletes the entries selected by a valid query and
+	// returns how many there were. Unless the query restricts the entries of
+	// the endpoints it selects, their rollups are deleted too.
+	DeleteMatching(query StatsQuery) (uint64, error)
+	// Delete deletes the entry with the given ID or returns ErrStatsNotFound.
+	Delete(id string) error
+	Count() (uint64, error)
+	// RemoveOlderThan deletes up to limit entries recorded before cutoff and
+	// returns how many were deleted.
+	RemoveOlderThan(cutoff time.Time, limit int) (uint64, error)
+	// RemoveRollupsBefore deletes up to limit of the oldest rollups whose
+	// bucket ended before cutoff and returns how many were deleted.
+	RemoveRollupsBefore(cutoff time.Time, limit int) (uint64, error)
+	// GetRollups returns the rollups whose bucket starts within [from, to),
+	// ordered by BucketStart. Entries are folded into their rollup when they
+	// are created; deleting a single entry does not take it out again.
+	GetRollups(from, to time.Time) ([]models.StatsRollup, error)
+}
+
+// MemoryStatsStore is a StatsStore that keeps everything in memory, for tests.
+type MemoryStatsStore struct {
+	mu      sync.RWMutex
+	stats   map[string]models.UsageStats
+	rollups map[rollupKey]*models.StatsRollup
+}
+
+func NewMemoryStatsStore() *MemoryStatsStore {
+	return &MemoryStatsStore{
+		stats:   make(map[string]models.UsageStats),
+		rollups: make(map[rollupKey]*models.StatsRollup),
+	}
+}
+
+func (s *MemoryStatsStore) Create(stats *models.UsageStats) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats[stats.ID] = *stats
+	return s.addToRollups([]*models.UsageStats{stats})
+}
+
+func (s *MemoryStatsStore) CreateMany(stats []*models.UsageStats) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range stats {
+		s.stats[entry.ID] = *entry
+	}
+	return s.addToRollups(stats)
+}
+
+// addToRollups folds entries into the rollups of their buckets. The caller
+// holds the write lock.
+func (s *MemoryStatsStore) addToRollups(entries []*models.UsageStats) error {
+	for key, group := range groupByRollup(entries) {
+		rollup, ok := s.rollups[key]
+		if !ok {
+			rollup = newRollup(key)
+			s.rollups[key] = rollup
+		}
+		if err := addToRollup(rollup, group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStatsStore) GetRollups(from, to time.Time) ([]models.StatsRollup, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := make([]models.StatsRollup, 0)
+	for _, rollup := range s.rollups {
+		if !rollup.BucketStart.Before(from) && rollup.BucketStart.Before(to) {
+			result = append(result, *rollup)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].BucketStart.Before(result[j].BucketStart) })
+	return result, nil
+}
+
+func (s *MemoryStatsStore) GetAll() ([]models.UsageStats, error) {
+	return s.find(func(models.UsageStats) bool { return true }), nil
+}
+
+func (s *MemoryStatsStore) GetByEndpoint(endpoint string) ([]models.UsageStats, error) {
+	return s.find(func(stats models.UsageStats) bool { return stats.Endpoint == endpoint }), nil
+}
+
+func (s *MemoryStatsStore) Find(query StatsQuery) ([]models.UsageStats, error) {
+	return s.find(query.Matches), nil
+}
+
+func (s *MemoryStatsStore) DeleteMatching(query StatsQuery) (uint64, error) {
+	removed := s.remove(query.Matches, 0)
+	if !query.restrictsEntries() {
+		s.removeRollups(func(rollup *models.StatsRollup) bool { return query.MatchesEndpoint(rollup.Endpoint) }, 0)
+	}
+	return removed, nil
+}
+
+func (s *MemoryStatsStore) DeleteByEndpoint(endpoint string) (uint64, error) {
+	removed := s.remove(func(stats models.UsageStats) bool { return stats.Endpoint == endpoint }, 0)
+	s.removeRollups(func(rollup *models.StatsRollup) bool { return rollup.Endpoint == endpoint }, 0)
+	return removed, nil
+}
+
+func (s *MemoryStatsStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.stats[id]; !ok {
+		return ErrStatsNotFound
+	}
+	delete(s.stats, id)
+	return nil
+}
+
+func (s *MemoryStatsStore) Count() (uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return uint64(len(s.stats)), nil
+}
+
+func (s *MemoryStatsStore) RemoveOlderThan(cutoff time.Time, limit int) (uint64, error) {
+	return s.remove(func(stats models.UsageStats) bool { return stats.Timestamp.Before(cutoff) }, limit), nil
+}
+
+func (s *MemoryStatsStore) RemoveRollupsBefore(cutoff time.Time, limit int) (uint64, error) {
+	bucketCutoff := cutoff.Truncate(StatsRollupInterval)
+	return s.removeRollups(func(rollup *models.StatsRollup) bool { return rollup.BucketStart.Before(bucketCutoff) }, limit), nil
+}
+
+// removeRollups deletes the oldest matching rollups, up to limit unless limit is 0.
+func (s *MemoryStatsStore) removeRollups(match func(*models.StatsRollup) bool, limit int) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matching []rollupKey
+	for key, rollup := range s.rollups {
+		if match(rollup) {
+			matching = append(matching, key)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return s.rollups[matching[i]].BucketStart.Before(s.rollups[matching[j]].BucketStart)
+	})
+	if limit > 0 && len(matching) > limit {
+		matching = matching[:limit]
+	}
+	for _, key := range matching {
+		delete(s.rollups, key)
+	}
+	return uint64(len(matching))
+}
+
+func (s *MemoryStatsStore) find(match func(models.UsageStats) bool) []models.UsageStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := make([]models.UsageStats, 0)
+	for _, stats := range s.stats {
+		if match(stats) {
+			result = append(result, stats)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Timestamp.Before(result[j].Timestamp) })
+	return result
+}
+
+// remove deletes the matching entries, oldest first, up to limit unless limit is 0.
+func (s *MemoryStatsStore) remove(match func(models.UsageStats) bool, limit int) uint64 {
+	matching := s.find(match)
+	if limit > 0 && len(matching) > limit {
+		matching = matching[:limit]
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var removed uint64
+	for _, stats := range matching {
+		if _, ok := s.stats[stats.ID]; ok {
+			delete(s.stats, stats.ID)
+			removed++
+		}
+	}
+	return removed
+}
@@ -0,0 +1,234 @@
+package repositories
+
+import (
+	"errors"
+	"go-rest-api/models"
+	"testing"
+	"time"
+)
+
+// Both StatsStore implementations run the same cases.
+
+func TestMemoryStatsStore(t *testing.T) {
+	testStatsStore(t, func(t *testing.T) StatsStore {
+		return NewMemoryStatsStore()
+	})
+}
+
+func TestStatsRepository(t *testing.T) {
+	testStatsStore(t, func(t *testing.T) StatsStore {
+		return NewStatsRepository(openTestObjectBox(t))
+	})
+}
+
+var statsBase = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func statsEntry(id, endpoint, method string, status int, minutes int, durationMs float64) *models.UsageStats {
+	return &models.UsageStats{
+		ID:         id,
+		Endpoint:   endpoint,
+		Method:     method,
+		Status:     status,
+		Timestamp:  statsBase.Add(time.Duration(minutes) * time.Minute),
+		DurationMs: durationMs,
+	}
+}
+
+// seedStats stores entries of two endpoints in two rollup buckets.
+func seedStats(t *testing.T, store StatsStore) {
+	t.Helper()
+	err := store.CreateMany([]*models.UsageStats{
+		statsEntry("a1", "/api/v1/activities", "GET", 200, 0, 10),
+		statsEntry("a2", "/api/v1/activities", "GET", 500, 1, 30),
+		statsEntry("a3", "/api/v1/activities", "POST", 201, 2, 20),
+		statsEntry("d1", "/api/v1/devices/:name", "GET", 404, 6, 5),
+	})
+	if err != nil {
+		t.Fatalf("CreateMany: %v", err)
+	}
+	if err := store.Create(statsEntry("a4", "/api/v1/activities", "GET", 302, 7, 0)); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+}
+
+func statsIds(stats []models.UsageStats) []string {
+	ids := make([]string, len(stats))
+	for i, entry := range stats {
+		ids[i] = entry.ID
+	}
+	return ids
+}
+
+func assertStatsIds(t *testing.T, stats []models.UsageStats, err error, want ...string) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := statsIds(stats)
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func testStatsStore(t *testing.T, newStore func(t *testing.T) StatsStore) {
+	t.Run("GetAll orders by timestamp", func(t *testing.T) {
+		store := newStore(t)
+		seedStats(t, store)
+		stats, err := store.GetAll()
+		assertStatsIds(t, stats, err, "a1", "a2", "a3", "d1", "a4")
+		if count, err := store.Count(); err != nil || count != 5 {
+			t.Errorf("Count = %d, %v, want 5", count, err)
+		}
+	})
+
+	t.Run("GetByEndpoint", func(t *testing.T) {
+		store := newStore(t)
+		seedStats(t, store)
+		stats, err := store.GetByEndpoint("/api/v1/devices/:name")
+		assertStatsIds(t, stats, err, "d1")
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		store := newStore(t)
+		seedStats(t, store)
+
+		stats, err := store.Find(StatsQuery{Endpoint: "/api/v1/", Match: EndpointPrefix, Methods: []string{"GET"}})
+		assertStatsIds(t, stats, err, "a1", "a2", "d1", "a4")
+		stats, err = store.Find(StatsQuery{Endpoint: "/api/v1/devices/:device", Match: EndpointRoute})
+		assertStatsIds(t, stats, err, "d1")
+		stats, err = store.Find(StatsQuery{Endpoint: "/api/v1/*", Match: EndpointGlob, StatusMin: 300, StatusMax: 499})
+		assertStatsIds(t, stats, err, "a4")
+		stats, err = store.Find(StatsQuery{From: statsBase.Add(time.Minute), To: statsBase.Add(6 * time.Minute)})
+		assertStatsIds(t, stats, err, "a2", "a3")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		store := newStore(t)
+		seedStats(t, store)
+		if err := store.Delete("a2"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if err := store.Delete("a2"); !errors.Is(err, ErrStatsNotFound) {
+			t.Errorf("deleting twice: %v, want ErrStatsNotFound", err)
+		}
+		stats, err := store.GetAll()
+		assertStatsIds(t, stats, err, "a1", "a3", "d1", "a4")
+	})
+
+	t.Run("rollups", func(t *testing.T) {
+		store := newStore(t)
+		seedStats(t, store)
+		rollups, err := store.GetRollups(statsBase, statsBase.Add(time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rollups) != 4 {
+			t.Fatalf("got %d rollups, want 4", len(rollups))
+		}
+		for i := 1; i < len(rollups); i++ {
+			if rollups[i].BucketStart.Before(rollups[i-1].BucketStart) {
+				t.Fatalf("rollups are not ordered by bucket")
+			}
+		}
+
+		var first *models.StatsRollup
+		for i := range rollups {
+			if rollups[i].Method == "GET" && rollups[i].Endpoint == "/api/v1/activities" && rollups[i].BucketStart.Equal(statsBase) {
+				first = &rollups[i]
+			}
+		}
+		if first == nil {
+			t.Fatal("no rollup of GET /api/v1/activities in the first bucket")
+		}
+		if first.Count != 2 || first.Status2xx != 1 || first.Status5xx != 1 {
+			t.Errorf("rollup counts %d, 2xx %d, 5xx %d, want 2, 1, 1", first.Count, first.Status2xx, first.Status5xx)
+		}
+		sketch, err := RollupLatency(*first)
+		if err != nil || sketch.Count != 2 {
+			t.Errorf("latency sketch of %v entries, %v, want 2", sketch, err)
+		}
+
+		later, err := store.GetRollups(statsBase.Add(StatsRollupInterval), statsBase.Add(time.Hour))
+		if err != nil || len(later) != 2 {
+			t.Errorf("got %d rollups from the second bucket, %v, want 2", len(later), err)
+		}
+	})
+
+	t.Run("DeleteByEndpoint", func(t *testing.T) {
+		store := newStore(t)
+		seedStats(t, store)
+		removed, err := store.DeleteByEndpoint("/api/v1/activities")
+		if err != nil || removed != 4 {
+			t.Fatalf("DeleteByEndpoint = %d, %v, want 4", removed, err)
+		}
+		rollups, err := store.GetRollups(statsBase, statsBase.Add(time.Hour))
+		if err != nil || len(rollups) != 1 || rollups[0].Endpoint != "/api/v1/devices/:name" {
+			t.Errorf("rollups left: %+v, %v", rollups, err)
+		}
+	})
+
+	t.Run("DeleteMatching", func(t *testing.T) {
+		store := newStore(t)
+		seedStats(t, store)
+
+		// Restricting the entries keeps the rollups.
+		removed, err := store.DeleteMatching(StatsQuery{Endpoint: "/api/v1/activities", Methods: []string{"GET"}})
+		if err != nil || removed != 3 {
+			t.Fatalf("DeleteMatching by method = %d, %v, want 3", removed, err)
+		}
+		if rollups, _ := store.GetRollups(statsBase, statsBase.Add(time.Hour)); len(rollups) != 4 {
+			t.Errorf("got %d rollups after deleting by method, want 4", len(rollups))
+		}
+
+		removed, err = store.DeleteMatching(StatsQuery{Endpoint: "/api/v1/devices/:id", Match: EndpointRoute})
+		if err != nil || removed != 1 {
+			t.Fatalf("DeleteMatching by route = %d, %v, want 1", removed, err)
+		}
+		rollups, err := store.GetRollups(statsBase, statsBase.Add(time.Hour))
+		if err != nil || len(rollups) != 3 {
+			t.Errorf("got %d rollups after deleting the endpoint, %v, want 3", len(rollups), err)
+		}
+		stats, err := store.GetAll()
+		assertStatsIds(t, stats, err, "a3")
+	})
+
+	t.Run("RemoveOlderThan", func(t *testing.T) {
+		store := newStore(t)
+		seedStats(t, store)
+
+		removed, err := store.RemoveOlderThan(statsBase.Add(7*time.Minute), 2)
+		if err != nil || removed != 2 {
+			t.Fatalf("RemoveOlderThan with limit = %d, %v, want 2", removed, err)
+		}
+		removed, err = store.RemoveOlderThan(statsBase.Add(7*time.Minute), 10)
+		if err != nil || removed != 2 {
+			t.Fatalf("RemoveOlderThan = %d, %v, want 2", removed, err)
+		}
+		stats, err := store.GetAll()
+		assertStatsIds(t, stats, err, "a4")
+
+		// Rollups are removed on their own, and only those of buckets that
+		// ended before the cutoff.
+		rollups, err := store.GetRollups(statsBase, statsBase.Add(time.Hour))
+		if err != nil || len(rollups) != 4 {
+			t.Fatalf("got %d rollups before removing them, %v, want 4", len(rollups), err)
+		}
+		removed, err = store.RemoveRollupsBefore(statsBase.Add(7*time.Minute), 1)
+		if err != nil || removed != 1 {
+			t.Fatalf("RemoveRollupsBefore with limit = %d, %v, want 1", removed, err)
+		}
+		removed, err = store.RemoveRollupsBefore(statsBase.Add(7*time.Minute), 10)
+		if err != nil || removed != 1 {
+			t.Fatalf("RemoveRollupsBefore = %d, %v, want 1", removed, err)
+		}
+		rollups, err = store.GetRollups(statsBase, statsBase.Add(time.Hour))
+		if err != nil || len(rollups) != 2 {
+			t.Errorf("got %d rollups, %v, want the 2 of the second bucket", len(rollups), err)
+		}
+	})
+}
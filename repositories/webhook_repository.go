@@ -0,0 +1,477 @@
+package repositories
+
+import (
+	"errors"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"strconv"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook subscription not found")
+	ErrWebhookExists    = errors.New("webhook subscription already exists")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrDeliveryNotDead is returned when retrying a delivery that was not dead-lettered.
+	ErrDeliveryNotDead = errors.New("only dead deliveries can be retried")
+)
+
+// DeliveryPage is a single page of webhook deliveries, newest first, and the
+// cursor for the page after it. NextCursor is empty when there are no more results.
+type DeliveryPage struct {
+	Deliveries []models.WebhookDelivery
+	NextCursor string
+}
+
+// WebhookRepository stores webhook subscriptions and their delivery outbox.
+type WebhookRepository struct {
+	ob            *objectbox.ObjectBox
+	subscriptions *models.WebhookSubscriptionBox
+	deliveries    *models.WebhookDeliveryBox
+	checkpoints   *models.WebhookCheckpointBox
+}
+
+func NewWebhookRepository(ob *objectbox.ObjectBox) *WebhookRepository {
+	repo := &WebhookRepository{
+		ob:            ob,
+		subscriptions: models.BoxForWebhookSubscription(ob),
+		deliveries:    models.BoxForWebhookDelivery(ob),
+		checkpoints:   models.BoxForWebhookCheckpoint(ob),
+	}
+	repo.updateMetrics()
+	return repo
+}
+
+func (r *WebhookRepository) updateMetrics() {
+	if count, err := r.subscriptions.Count(); err == nil {
+		metrics.ObjectBoxEntityCount.WithLabelValues("webhook_subscription").Set(float64(count))
+	}
+	if count, err := r.deliveries.Count(); err == nil {
+		metrics.ObjectBoxEntityCount.WithLabelValues("webhook_delivery").Set(float64(count))
+	}
+	query, err := r.deliveries.QueryOrError(models.WebhookDelivery_.Status.Equals(models.DeliveryPending, true))
+	if err != nil {
+		return
+	}
+	defer query.Close()
+	if count, err := query.Count(); err == nil {
+		metrics.WebhookOutboxPending.Set(float64(count))
+	}
+}
+
+// GetSubscriptions returns every subscription ordered by name.
+func (r *WebhookRepository) GetSubscriptions() ([]models.WebhookSubscription, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_all", "webhook_subscription").Observe(duration)
+	}()
+
+	query, err := r.subscriptions.QueryOrError(models.WebhookSubscription_.Name.OrderAsc(true))
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Find()
+	if err != nil {
+		return nil, err
+	}
+	subscriptions := make([]models.WebhookSubscription, len(results))
+	for i, subscription := range results {
+		subscriptions[i] = *subscription
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_all", "webhook_subscription").Inc()
+	return subscriptions, nil
+}
+
+func (r *WebhookRepository) findSubscription(name string) (*models.WebhookSubscription, error) {
+	query, err := r.subscriptions.QueryOrError(models.WebhookSubscription_.Name.Equals(name, true))
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(1).Find()
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// GetSubscription returns the named subscription or ErrWebhookNotFound.
+func (r *WebhookRepository) GetSubscription(name string) (*models.WebhookSubscription, error) {
+	subscription, err := r.findSubscription(name)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, ErrWebhookNotFound
+	}
+	return subscription, nil
+}
+
+// CreateSubscription stores a new subscription, or returns ErrWebhookExists.
+func (r *WebhookRepository) CreateSubscription(subscription *models.WebhookSubscription) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("create", "webhook_subscription").Observe(duration)
+	}()
+
+	err := r.ob.RunInWriteTx(func() error {
+		existing, err := r.findSubscription(subscription.Name)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return ErrWebhookExists
+		}
+		subscription.CreatedAt = start
+		subscription.UpdatedAt = start
+		_, err = r.subscriptions.Put(subscription)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("create", "webhook_subscription").Inc()
+	r.updateMetrics()
+	return nil
+}
+
+// UpdateSubscription applies fn to the named subscription and stores it
+// unless fn returns an error.
+func (r *WebhookRepository) UpdateSubscription(name string, fn func(*models.WebhookSubscription) error) (*models.WebhookSubscription, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("update", "webhook_subscription").Observe(duration)
+	}()
+
+	var subscription *models.WebhookSubscription
+	err := r.ob.RunInWriteTx(func() error {
+		var err error
+		if subscription, err = r.findSubscription(name); err != nil {
+			return err
+		}
+		if subscription == nil {
+			return ErrWebhookNotFound
+		}
+		if err := fn(subscription); err != nil {
+			return err
+		}
+		subscription.UpdatedAt = start
+		_, err = r.subscriptions.Put(subscription)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("update", "webhook_subscription").Inc()
+	return subscription, nil
+}
+
+// DeleteSubscription removes the named subscription and dead-letters its
+// pending deliveries. Its delivery log is kept.
+func (r *WebhookRepository) DeleteSubscription(name string) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("delete", "webhook_subscription").Observe(duration)
+	}()
+
+	err := r.ob.RunInWriteTx(func() error {
+		subscription, err := r.findSubscription(name)
+		if err != nil {
+			return err
+		}
+		if subscription == nil {
+			return ErrWebhookNotFound
+		}
+
+		query, err := r.deliveries.QueryOrError(
+			models.WebhookDelivery_.SubscriptionId.Equals(subscription.Id),
+			models.WebhookDelivery_.Status.Equals(models.DeliveryPending, true),
+		)
+		if err != nil {
+			return err
+		}
+		defer query.Close()
+		pending, err := query.Find()
+		if err != nil {
+			return err
+		}
+		for _, delivery := range pending {
+			delivery.Status = models.DeliveryDead
+			delivery.LastError = "subscription deleted"
+			delivery.FinishedAt = start
+		}
+		if _, err := r.deliveries.PutMany(pending); err != nil {
+			return err
+		}
+		return r.subscriptions.Remove(subscription)
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("delete", "webhook_subscription").Inc()
+	r.updateMetrics()
+	return nil
+}
+
+func (r *WebhookRepository) checkpoint() (*models.WebhookCheckpoint, error) {
+	checkpoints, err := r.checkpoints.GetAll()
+	if err != nil || len(checkpoints) == 0 {
+		return &models.WebhookCheckpoint{}, err
+	}
+	return checkpoints[0], nil
+}
+
+// LastDispatched returns the last change sequence fanned out into the outbox.
+func (r *WebhookRepository) LastDispatched() (uint64, error) {
+	checkpoint, err := r.checkpoint()
+	if err != nil {
+		return 0, err
+	}
+	return checkpoint.LastSequence, nil
+}
+
+// Enqueue adds deliveries to the outbox and records that every change up to
+// lastSequence has been dispatched, in one transaction, so that each change
+// is fanned out exactly once. Deliveries to subscriptions deleted meanwhile
+// are dropped, since no worker would deliver them.
+func (r *WebhookRepository) Enqueue(deliveries []*models.WebhookDelivery, lastSequence uint64) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("enqueue", "webhook_delivery").Observe(duration)
+	}()
+
+	err := r.ob.RunInWriteTx(func() error {
+		checkpoint, err := r.checkpoint()
+		if err != nil {
+			return err
+		}
+		if lastSequence <= checkpoint.LastSequence {
+			return nil
+		}
+		exists := make(map[uint64]bool)
+		var kept []*models.WebhookDelivery
+		for _, delivery := range deliveries {
+			found, ok := exists[delivery.SubscriptionId]
+			if !ok {
+				subscription, err := r.subscriptions.Get(delivery.SubscriptionId)
+				if err != nil {
+					return err
+				}
+				found = subscription != nil
+				exists[delivery.SubscriptionId] = found
+			}
+			if !found {
+				continue
+			}
+			delivery.Status = models.DeliveryPending
+			delivery.CreatedAt = start
+			delivery.NextAttemptAt = start
+			kept = append(kept, delivery)
+		}
+		if _, err := r.deliveries.PutMany(kept); err != nil {
+			return err
+		}
+		checkpoint.LastSequence = lastSequence
+		checkpoint.UpdatedAt = start
+		_, err = r.checkpoints.Put(checkpoint)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("enqueue", "webhook_delivery").Inc()
+	if len(deliveries) > 0 {
+		r.updateMetrics()
+	}
+	return nil
+}
+
+// GetDue returns up to limit pending deliveries of a subscription whose next
+// attempt is due at now, oldest first.
+func (r *WebhookRepository) GetDue(subscriptionId uint64, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_due", "webhook_delivery").Observe(duration)
+	}()
+
+	query, err := r.deliveries.QueryOrError(
+		models.WebhookDelivery_.SubscriptionId.Equals(subscriptionId),
+		models.WebhookDelivery_.Status.Equals(models.DeliveryPending, true),
+		models.WebhookDelivery_.NextAttemptAt.LessOrEqual(now.UnixMilli()),
+		models.WebhookDelivery_.Id.OrderAsc(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(uint64(limit)).Find()
+	if err != nil {
+		return nil, err
+	}
+	deliveries := make([]models.WebhookDelivery, len(results))
+	for i, delivery := range results {
+		deliveries[i] = *delivery
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_due", "webhook_delivery").Inc()
+	return deliveries, nil
+}
+
+// UpdateDelivery stores the outcome of a delivery attempt.
+func (r *WebhookRepository) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("update", "webhook_delivery").Observe(duration)
+	}()
+
+	if _, err := r.deliveries.Put(delivery); err != nil {
+		return err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("update", "webhook_delivery").Inc()
+	if delivery.Status != models.DeliveryPending {
+		r.updateMetrics()
+	}
+	return nil
+}
+
+// GetDeliveries returns a page of a subscription's delivery log, newest
+// first, optionally only deliveries with the given status.
+func (r *WebhookRepository) GetDeliveries(subscriptionName, status, cursor string, limit int) (DeliveryPage, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("get_by_subscription", "webhook_delivery").Observe(duration)
+	}()
+
+	conditions := []objectbox.Condition{
+		models.WebhookDelivery_.SubscriptionName.Equals(subscriptionName, true),
+		models.WebhookDelivery_.Id.OrderDesc(),
+	}
+	if status != "" {
+		conditions = append(conditions, models.WebhookDelivery_.Status.Equals(status, true))
+	}
+	if cursor != "" {
+		beforeId, err := strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return DeliveryPage{}, ErrInvalidCursor
+		}
+		conditions = append(conditions, models.WebhookDelivery_.Id.LessThan(beforeId))
+	}
+
+	query, err := r.deliveries.QueryOrError(conditions...)
+	if err != nil {
+		return DeliveryPage{}, err
+	}
+	defer query.Close()
+
+	results, err := query.Limit(uint64(limit + 1)).Find()
+	if err != nil {
+		return DeliveryPage{}, err
+	}
+	var page DeliveryPage
+	if len(results) > limit {
+		results = results[:limit]
+		page.NextCursor = strconv.FormatUint(results[limit-1].Id, 10)
+	}
+	page.Deliveries = make([]models.WebhookDelivery, len(results))
+	for i, delivery := range results {
+		page.Deliveries[i] = *delivery
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("get_by_subscription", "webhook_delivery").Inc()
+	return page, nil
+}
+
+// Retry moves a dead delivery of the named subscription back into the outbox
+// with its attempts reset.
+func (r *WebhookRepository) Retry(subscriptionName string, id uint64) (*models.WebhookDelivery, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("retry", "webhook_delivery").Observe(duration)
+	}()
+
+	var delivery *models.WebhookDelivery
+	err := r.ob.RunInWriteTx(func() error {
+		subscription, err := r.findSubscription(subscriptionName)
+		if err != nil {
+			return err
+		}
+		if subscription == nil {
+			return ErrWebhookNotFound
+		}
+		if delivery, err = r.deliveries.Get(id); err != nil {
+			return err
+		}
+		if delivery == nil || delivery.SubscriptionId != subscription.Id {
+			return ErrDeliveryNotFound
+		}
+		if delivery.Status != models.DeliveryDead {
+			return ErrDeliveryNotDead
+		}
+		delivery.Status = models.DeliveryPending
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = start
+		delivery.FinishedAt = time.Time{}
+		_, err = r.deliveries.Put(delivery)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("retry", "webhook_delivery").Inc()
+	r.updateMetrics()
+	return delivery, nil
+}
+
+// RemoveFinishedBefore deletes up to limit delivered or dead deliveries that
+// finished before cutoff and returns how many were deleted.
+func (r *WebhookRepository) RemoveFinishedBefore(cutoff time.Time, limit int) (uint64, error) {
+	start := time.Now()
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.ObjectBoxOperationDuration.WithLabelValues("prune", "webhook_delivery").Observe(duration)
+	}()
+
+	query, err := r.deliveries.QueryOrError(
+		models.WebhookDelivery_.Status.NotEquals(models.DeliveryPending, true),
+		models.WebhookDelivery_.FinishedAt.LessThan(cutoff.UnixMilli()),
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer query.Close()
+
+	ids, err := query.Limit(uint64(limit)).FindIds()
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	removed, err := r.deliveries.RemoveIds(ids...)
+	if err != nil {
+		return 0, err
+	}
+
+	metrics.ObjectBoxOperationsTotal.WithLabelValues("prune", "webhook_delivery").Inc()
+	r.updateMetrics()
+	return removed, nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"go-rest-api/metrics"
+	"go-rest-api/repositories"
+	"sync"
+)
+
+// ActivityCounter counts activities per grid and device for the metrics
+// scrape. The counts are kept until the changelog has recorded another
+// change; the grid hierarchy is read on every scrape.
+type ActivityCounter struct {
+	activities *repositories.ActivityRepository
+	grids      *repositories.GridRepository
+
+	mu       sync.Mutex
+	sequence uint64
+	counts   map[string]map[string]uint64
+}
+
+func NewActivityCounter(activities *repositories.ActivityRepository, grids *repositories.GridRepository) *ActivityCounter {
+	return &ActivityCounter{activities: activities, grids: grids}
+}
+
+// Counts returns the current counts and the subtree of every registered grid.
+func (c *ActivityCounter) Counts() (metrics.ActivityCounts, error) {
+	counts, err := c.byGrid()
+	if err != nil {
+		return metrics.ActivityCounts{}, err
+	}
+	tree, err := c.grids.Tree()
+	if err != nil {
+		return metrics.ActivityCounts{}, err
+	}
+
+	subtrees := make(map[string][]string)
+	for _, grid := range tree.Grids() {
+		subtrees[grid.Name] = tree.Subtree(grid.Name)
+	}
+	return metrics.ActivityCounts{ByGrid: counts, Subtrees: subtrees}, nil
+}
+
+func (c *ActivityCounter) byGrid() (map[string]map[string]uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The sequence is read before counting, so a change committed meanwhile
+	// is counted again on the next scrape.
+	sequence, err := c.activities.Changelog().LastSequence()
+	if err != nil {
+		return nil, err
+	}
+	if c.counts != nil && sequence == c.sequence {
+		return c.counts, nil
+	}
+
+	counts, err := c.activities.CountByGridAndDevice()
+	if err != nil {
+		return nil, err
+	}
+	c.counts, c.sequence = counts, sequence
+	return counts, nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"sync"
+)
+
+// ActivityHub fans newly created activities out to live stream subscribers.
+// Every subscriber has a bounded buffer; a subscriber whose buffer is full is
+// evicted rather than allowed to slow down the write path.
+type ActivityHub struct {
+	mu          sync.Mutex
+	bufferSize  int
+	subscribers map[*Subscription]struct{}
+}
+
+// Subscription receives the activities matching its filter on C. C is closed
+// when the subscription ends, either by Unsubscribe or by eviction.
+type Subscription struct {
+	C       <-chan models.DeviceActivity
+	ch      chan models.DeviceActivity
+	filter  repositories.ActivityFilter
+	evicted bool
+}
+
+// Evicted reports whether the subscription was dropped for falling behind.
+// It is only meaningful once C has been closed.
+func (s *Subscription) Evicted() bool {
+	return s.evicted
+}
+
+func NewActivityHub(bufferSize int) *ActivityHub {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	return &ActivityHub{
+		bufferSize:  bufferSize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe registers a subscriber for activities matching filter.
+func (h *ActivityHub) Subscribe(filter repositories.ActivityFilter) *Subscription {
+	ch := make(chan models.DeviceActivity, h.bufferSize)
+	sub := &Subscription{C: ch, ch: ch, filter: filter}
+
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	metrics.ActivityStreamSubscribers.Set(float64(len(h.subscribers)))
+	h.mu.Unlock()
+	return sub
+}
+
+// Unsubscribe removes the subscriber and closes its channel. It is safe to
+// call after the subscriber has been evicted.
+func (h *ActivityHub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(sub)
+}
+
+// Publish delivers the activity to every matching subscriber without blocking.
+func (h *ActivityHub) Publish(activity models.DeviceActivity) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers {
+		if !sub.filter.Matches(activity) {
+			continue
+		}
+		select {
+		case sub.ch <- activity:
+		default:
+			sub.evicted = true
+			h.remove(sub)
+			metrics.ActivityStreamEvictionsTotal.Inc()
+		}
+	}
+}
+
+func (h *ActivityHub) remove(sub *Subscription) {
+	if _, ok := h.subscribers[sub]; !ok {
+		return
+	}
+	delete(h.subscribers, sub)
+	close(sub.ch)
+	metrics.ActivityStreamSubscribers.Set(float64(len(h.subscribers)))
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"fmt"
+	"go-rest-api/repositories"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxAggregateBuckets bounds the number of time buckets a single request may span.
+const MaxAggregateBuckets = 10000
+
+// BucketSize is the width of the time buckets activity counts are grouped into.
+type BucketSize string
+
+const (
+	BucketMinute BucketSize = "minute"
+	BucketHour   BucketSize = "hour"
+	BucketDay    BucketSize = "day"
+	BucketWeek   BucketSize = "week"
+)
+
+func ParseBucketSize(value string) (BucketSize, error) {
+	switch bucket := BucketSize(value); bucket {
+	case BucketMinute, BucketHour, BucketDay, BucketWeek:
+		return bucket, nil
+	}
+	return "", fmt.Errorf("invalid bucket %q: expected minute, hour, day or week", value)
+}
+
+// Start returns the start of the bucket containing t, using the wall clock of loc.
+// Day and week buckets begin at local midnight (weeks on Monday), so they follow
+// daylight saving changes. Minute and hour buckets are aligned to the local
+// offset in effect at t, which keeps both repeated hours of a DST change apart.
+func (b BucketSize) Start(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	switch b {
+	case BucketMinute, BucketHour:
+		unit := time.Minute
+		if b == BucketHour {
+			unit = time.Hour
+		}
+		_, offset := t.Zone()
+		shift := time.Duration(offset) * time.Second
+		return t.Add(shift).Truncate(unit).Add(-shift).In(loc)
+	case BucketDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// next returns the start of the bucket after the one starting at start.
+func (b BucketSize) next(start time.Time, loc *time.Location) time.Time {
+	// Half a bucket past the end lands inside the next bucket even across
+	// daylight saving changes.
+	return b.Start(start.Add(b.approximate()+b.approximate()/2), loc)
+}
+
+func (b BucketSize) approximate() time.Duration {
+	switch b {
+	case BucketMinute:
+		return time.Minute
+	case BucketHour:
+		return time.Hour
+	case BucketDay:
+		return 24 * time.Hour
+	}
+	return 7 * 24 * time.Hour
+}
+
+// AggregateRequest selects the activities to count and how to group them.
+type AggregateRequest struct {
+	From     time.Time
+	To       time.Time
+	Bucket   BucketSize
+	Location *time.Location
+	GroupBy  []repositories.ActivityField
+	Filter   repositories.ActivityFilter
+}
+
+// Validate checks the time range and the number of buckets it spans.
+func (req AggregateRequest) Validate() error {
+	if !req.From.Before(req.To) {
+		return fmt.Errorf("from must be before to")
+	}
+	if req.To.Sub(req.From)/req.Bucket.approximate() > MaxAggregateBuckets {
+		return fmt.Errorf("time range spans more than %d %s buckets", MaxAggregateBuckets, req.Bucket)
+	}
+	_, err := req.Filter.Condition()
+	return err
+}
+
+// AggregateRow is the number of activities in one bucket for one group.
+type AggregateRow struct {
+	BucketStart time.Time         `json:"bucket_start"`
+	Group       map[string]string `json:"group,omitempty"`
+	Count       uint64            `json:"count"`
+}
+
+// AggregateActivities counts activities in [From, To) per bucket and group.
+// Buckets without activities are omitted.
+func AggregateActivities(repo *repositories.ActivityRepository, req AggregateRequest) ([]AggregateRow, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Location == nil {
+		req.Location = time.UTC
+	}
+
+	clauses := []repositories.ActivityFilter{
+		{Timestamp: &repositories.TimeRange{From: &req.From, To: &req.To}},
+	}
+	if !req.Filter.IsEmpty() {
+		clauses = append(clauses, req.Filter)
+	}
+
+	projection, err := repo.Project(repositories.ActivityFilter{And: clauses}, req.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	type groupKey struct {
+		bucket int64
+		values string
+	}
+	counts := make(map[groupKey]uint64)
+	values := make([]string, len(req.GroupBy))
+	for i, ts := range projection.Timestamps {
+		for j := range req.GroupBy {
+			values[j] = projection.Columns[j][i]
+		}
+		key := groupKey{
+			bucket: req.Bucket.Start(time.UnixMilli(ts), req.Location).UnixMilli(),
+			values: strings.Join(values, "\x00"),
+		}
+		counts[key]++
+	}
+
+	keys := make([]groupKey, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].bucket != keys[j].bucket {
+			return keys[i].bucket < keys[j].bucket
+		}
+		return keys[i].values < keys[j].values
+	})
+
+	rows := make([]AggregateRow, len(keys))
+	for i, key := range keys {
+		rows[i] = AggregateRow{
+			BucketStart: time.UnixMilli(key.bucket).In(req.Location),
+			Count:       counts[key],
+		}
+		if len(req.GroupBy) > 0 {
+			rows[i].Group = make(map[string]string, len(req.GroupBy))
+			for j, value := range strings.Split(key.values, "\x00") {
+				rows[i].Group[string(req.GroupBy[j])] = value
+			}
+		}
+	}
+	return rows, nil
+}
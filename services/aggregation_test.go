@@ -0,0 +1,105 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestBucketSizeStart(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	kolkata := mustLoadLocation(t, "Asia/Kolkata")
+	utc := func(value string) time.Time {
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+	tests := []struct {
+		name   string
+		bucket BucketSize
+		loc    *time.Location
+		t      string
+		want   string
+	}{
+		{"minute", BucketMinute, time.UTC, "2026-01-01T12:34:56.789Z", "2026-01-01T12:34:00Z"},
+		{"hour", BucketHour, time.UTC, "2026-01-01T12:34:56Z", "2026-01-01T12:00:00Z"},
+		{"hour at a half hour offset", BucketHour, kolkata, "2026-01-01T05:15:00Z", "2026-01-01T04:30:00Z"},
+		{"day in local time", BucketDay, berlin, "2025-12-31T23:30:00Z", "2025-12-31T23:00:00Z"},
+		{"day after spring forward", BucketDay, berlin, "2026-03-29T10:00:00Z", "2026-03-28T23:00:00Z"},
+		{"first hour repeated by fall back", BucketHour, berlin, "2026-10-25T00:30:00Z", "2026-10-25T00:00:00Z"},
+		{"second hour repeated by fall back", BucketHour, berlin, "2026-10-25T01:30:00Z", "2026-10-25T01:00:00Z"},
+		{"week from Thursday", BucketWeek, time.UTC, "2026-01-01T08:00:00Z", "2025-12-29T00:00:00Z"},
+		{"week from Sunday", BucketWeek, time.UTC, "2026-01-04T23:59:59Z", "2025-12-29T00:00:00Z"},
+		{"week from Monday", BucketWeek, time.UTC, "2026-01-05T00:00:00Z", "2026-01-05T00:00:00Z"},
+		{"week in local time", BucketWeek, berlin, "2026-01-04T23:30:00Z", "2026-01-04T23:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.bucket.Start(utc(tt.t), tt.loc)
+			if want := utc(tt.want); !got.Equal(want) {
+				t.Errorf("Start(%s) = %s, want %s", tt.t, got.UTC().Format(time.RFC3339), tt.want)
+			}
+			if again := tt.bucket.Start(got, tt.loc); !again.Equal(got) {
+				t.Errorf("Start is not idempotent: %s then %s", got, again)
+			}
+		})
+	}
+}
+
+func TestBucketSizeNext(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	tests := []struct {
+		name   string
+		bucket BucketSize
+		start  time.Time
+		want   time.Duration
+	}{
+		{"minute", BucketMinute, time.Date(2026, 1, 1, 12, 59, 0, 0, berlin), time.Minute},
+		{"short day", BucketDay, time.Date(2026, 3, 29, 0, 0, 0, 0, berlin), 23 * time.Hour},
+		{"long day", BucketDay, time.Date(2026, 10, 25, 0, 0, 0, 0, berlin), 25 * time.Hour},
+		{"repeated hour", BucketHour, time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC).In(berlin), time.Hour},
+		{"week over a DST change", BucketWeek, time.Date(2026, 3, 23, 0, 0, 0, 0, berlin), 7*24*time.Hour - time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.bucket.next(tt.start, berlin).Sub(tt.start); got != tt.want {
+				t.Errorf("next(%s) is %s later, want %s", tt.start, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregateRequestValidate(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		to      time.Time
+		bucket  BucketSize
+		wantErr bool
+	}{
+		{"one bucket", from.Add(time.Minute), BucketMinute, false},
+		{"most buckets", from.Add(MaxAggregateBuckets * time.Minute), BucketMinute, false},
+		{"too many buckets", from.Add((MaxAggregateBuckets + 1) * time.Minute), BucketMinute, true},
+		{"wider buckets", from.Add((MaxAggregateBuckets + 1) * time.Minute), BucketHour, false},
+		{"empty range", from, BucketDay, true},
+		{"reversed range", from.Add(-time.Hour), BucketDay, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AggregateRequest{From: from, To: tt.to, Bucket: tt.bucket}.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-rest-api/config"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// AlertNotification is the JSON body posted to alert webhooks.
+type AlertNotification struct {
+	Id          uint64            `json:"id"`
+	Rule        string            `json:"rule"`
+	Status      string            `json:"status"`
+	Group       map[string]string `json:"group"`
+	Count       int64             `json:"count"`
+	Fingerprint string            `json:"fingerprint"`
+	FiredAt     time.Time         `json:"fired_at"`
+	ResolvedAt  *time.Time        `json:"resolved_at,omitempty"`
+	// LastActivityId is the most recent activity that counted towards the alert.
+	LastActivityId uint64 `json:"last_activity_id"`
+	Test           bool   `json:"test,omitempty"`
+}
+
+func newAlertNotification(alert models.Alert, test bool) AlertNotification {
+	group, err := alert.GetGroup()
+	if err != nil {
+		group = map[string]string{}
+	}
+	notification := AlertNotification{
+		Id:             alert.Id,
+		Rule:           alert.RuleName,
+		Status:         alert.Status,
+		Group:          group,
+		Count:          alert.Count,
+		Fingerprint:    alert.Fingerprint,
+		FiredAt:        alert.FiredAt,
+		LastActivityId: alert.LastActivityId,
+		Test:           test,
+	}
+	if alert.Status == models.AlertResolved {
+		notification.ResolvedAt = &alert.ResolvedAt
+	}
+	return notification
+}
+
+// idempotencyKey identifies one status change of one alert, so receivers can
+// drop the duplicates that retries may cause.
+func (n AlertNotification) idempotencyKey() string {
+	if n.Test {
+		return fmt.Sprintf("test-%s-%d", n.Rule, n.FiredAt.UnixNano())
+	}
+	return fmt.Sprintf("alert-%d-%s", n.Id, n.Status)
+}
+
+type alertDelivery struct {
+	alert    models.Alert
+	webhooks []string
+}
+
+func (d alertDelivery) key() string {
+	return fmt.Sprintf("%d-%s", d.alert.Id, d.alert.Status)
+}
+
+// alertStore is the part of AlertRepository the notifier records deliveries in.
+type alertStore interface {
+	UpdateAlert(id uint64, update func(alert *models.Alert)) (*models.Alert, error)
+}
+
+// AlertNotifier posts alert status changes to webhooks from a single worker,
+// so the notifications of one alert arrive in order. Failed deliveries are
+// retried with exponential backoff; a status is recorded as delivered once
+// every webhook has accepted it and is not sent again.
+type AlertNotifier struct {
+	repo        alertStore
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	queue       chan alertDelivery
+
+	mu      sync.Mutex
+	pending map[string]struct{}
+}
+
+// NewAlertNotifier creates a notifier that sends with client.
+func NewAlertNotifier(repo *repositories.AlertRepository, client *http.Client, cfg config.Config) *AlertNotifier {
+	maxAttempts := cfg.AlertWebhookMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+	queueSize := cfg.AlertQueueSize
+	if queueSize <= 0 {
+		queueSize = 1
+	}
+	return &AlertNotifier{
+		repo:        repo,
+		client:      client,
+		maxAttempts: maxAttempts,
+		backoff:     cfg.AlertWebhookBackoff,
+		queue:       make(chan alertDelivery, queueSize),
+		pending:     make(map[string]struct{}),
+	}
+}
+
+// Enqueue schedules delivery of the alert's current status. It never blocks:
+// when the queue is full the notification is dropped and stays undelivered
+// until the next start.
+func (n *AlertNotifier) Enqueue(alert models.Alert, webhooks []string) {
+	delivery := alertDelivery{alert: alert, webhooks: webhooks}
+	if alert.NotifiedStatus == alert.Status {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.pending[delivery.key()]; ok {
+		return
+	}
+	select {
+	case n.queue <- delivery:
+		n.pending[delivery.key()] = struct{}{}
+	default:
+		metrics.AlertNotificationsTotal.WithLabelValues(alert.Status, "dropped").Inc()
+		log.Printf("alert notification queue full, dropping %s notification for alert %d", alert.Status, alert.Id)
+	}
+}
+
+// Run delivers queued notifications until ctx is done.
+func (n *AlertNotifier) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case delivery := <-n.queue:
+			n.deliver(ctx, delivery)
+			n.mu.Lock()
+			delete(n.pending, delivery.key())
+			n.mu.Unlock()
+		}
+	}
+}
+
+func (n *AlertNotifier) deliver(ctx context.Context, delivery alertDelivery) {
+	notification := newAlertNotification(delivery.alert, false)
+	remaining := delivery.webhooks
+	backoff := n.backoff
+	for attempt := 1; len(remaining) > 0; attempt++ {
+		var failed []string
+		for _, webhook := range remaining {
+			if err := n.send(ctx, webhook, notification); err != nil {
+				log.Printf("alert %d %s notification to %s failed (attempt %d/%d): %v",
+					delivery.alert.Id, delivery.alert.Status, webhook, attempt, n.maxAttempts, err)
+				failed = append(failed, webhook)
+			}
+		}
+		remaining = failed
+		if len(remaining) == 0 {
+			break
+		}
+		if attempt >= n.maxAttempts {
+			metrics.AlertNotificationsTotal.WithLabelValues(delivery.alert.Status, "failed").Inc()
+			return
+		}
+		metrics.AlertNotificationsTotal.WithLabelValues(delivery.alert.Status, "retried").Inc()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	status := delivery.alert.Status
+	_, err := n.repo.UpdateAlert(delivery.alert.Id, func(alert *models.Alert) {
+		// A newer status may have been stored while this one was in flight.
+		if alert.Status == status {
+			alert.NotifiedStatus = status
+		}
+	})
+	if err != nil {
+		log.Printf("recording delivery of alert %d failed: %v", delivery.alert.Id, err)
+	}
+	metrics.AlertNotificationsTotal.WithLabelValues(status, "delivered").Inc()
+}
+
+// send posts one notification to one webhook. Any 2xx response is success.
+func (n *AlertNotifier) send(ctx context.Context, webhook string, notification AlertNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", notification.idempotencyKey())
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"go-rest-api/config"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeAlertStore keeps rules and alerts in memory in place of an AlertRepository.
+type fakeAlertStore struct {
+	mu     sync.Mutex
+	rules  []models.AlertRule
+	alerts map[uint64]*models.Alert
+	nextId uint64
+}
+
+func newFakeAlertStore(alerts ...models.Alert) *fakeAlertStore {
+	store := &fakeAlertStore{alerts: make(map[uint64]*models.Alert)}
+	for i := range alerts {
+		store.alerts[alerts[i].Id] = &alerts[i]
+	}
+	return store
+}
+
+func (s *fakeAlertStore) UpdateAlert(id uint64, update func(alert *models.Alert)) (*models.Alert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	alert, ok := s.alerts[id]
+	if !ok {
+		return nil, repositories.ErrAlertNotFound
+	}
+	update(alert)
+	updated := *alert
+	return &updated, nil
+}
+
+func (s *fakeAlertStore) get(id uint64) models.Alert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.alerts[id]
+}
+
+// alertReceiver answers every request with the next of statuses, repeating
+// the last one, and records the notifications and idempotency keys.
+type alertReceiver struct {
+	*httptest.Server
+	requests      atomic.Int32
+	mu            sync.Mutex
+	notifications []AlertNotification
+	keys          []string
+}
+
+func newAlertReceiver(t *testing.T, statuses ...int) *alertReceiver {
+	t.Helper()
+	receiver := &alertReceiver{}
+	receiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(receiver.requests.Add(1))
+		var notification AlertNotification
+		if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+			t.Errorf("decoding notification: %v", err)
+		}
+		receiver.mu.Lock()
+		receiver.notifications = append(receiver.notifications, notification)
+		receiver.keys = append(receiver.keys, r.Header.Get("Idempotency-Key"))
+		receiver.mu.Unlock()
+		w.WriteHeader(statuses[min(n, len(statuses))-1])
+	}))
+	t.Cleanup(receiver.Close)
+	return receiver
+}
+
+func testNotifier(store alertStore, maxAttempts int) *AlertNotifier {
+	notifier := NewAlertNotifier(nil, http.DefaultClient, config.Config{
+		AlertWebhookMaxAttempts: maxAttempts,
+		AlertWebhookBackoff:     time.Millisecond,
+		AlertQueueSize:          4,
+	})
+	notifier.repo = store
+	return notifier
+}
+
+func firingAlert() models.Alert {
+	alert := models.Alert{Id: 3, RuleId: 1, RuleName: "logins", Fingerprint: "1:eu", Status: models.AlertFiring,
+		Count: 6, FiredAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), LastActivityId: 42}
+	alert.SetGroup(map[string]string{"grid": "eu"})
+	return alert
+}
+
+func TestAlertNotifierDelivers(t *testing.T) {
+	alert := firingAlert()
+	store := newFakeAlertStore(alert)
+	first, second := newAlertReceiver(t, http.StatusOK), newAlertReceiver(t, http.StatusAccepted)
+
+	testNotifier(store, 3).deliver(context.Background(), alertDelivery{alert: alert, webhooks: []string{first.URL, second.URL}})
+
+	for _, receiver := range []*alertReceiver{first, second} {
+		if receiver.requests.Load() != 1 {
+			t.Fatalf("requests = %d, want 1", receiver.requests.Load())
+		}
+		notification := receiver.notifications[0]
+		if notification.Id != 3 || notification.Status != models.AlertFiring || notification.Group["grid"] != "eu" ||
+			notification.LastActivityId != 42 || notification.ResolvedAt != nil {
+			t.Errorf("notification = %+v", notification)
+		}
+		if receiver.keys[0] != "alert-3-firing" {
+			t.Errorf("Idempotency-Key = %q, want alert-3-firing", receiver.keys[0])
+		}
+	}
+	if got := store.get(3).NotifiedStatus; got != models.AlertFiring {
+		t.Errorf("NotifiedStatus = %q, want %q", got, models.AlertFiring)
+	}
+}
+
+func TestAlertNotifierRetriesFailedWebhooks(t *testing.T) {
+	alert := firingAlert()
+	store := newFakeAlertStore(alert)
+	healthy := newAlertReceiver(t, http.StatusOK)
+	flaky := newAlertReceiver(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK)
+
+	testNotifier(store, 3).deliver(context.Background(), alertDelivery{alert: alert, webhooks: []string{healthy.URL, flaky.URL}})
+
+	if healthy.requests.Load() != 1 || flaky.requests.Load() != 3 {
+		t.Errorf("requests = %d and %d, want 1 and 3", healthy.requests.Load(), flaky.requests.Load())
+	}
+	for _, key := range flaky.keys {
+		if key != "alert-3-firing" {
+			t.Errorf("retry Idempotency-Key = %q, want alert-3-firing", key)
+		}
+	}
+	if got := store.get(3).NotifiedStatus; got != models.AlertFiring {
+		t.Errorf("NotifiedStatus = %q, want %q", got, models.AlertFiring)
+	}
+}
+
+func TestAlertNotifierGivesUp(t *testing.T) {
+	alert := firingAlert()
+	store := newFakeAlertStore(alert)
+	receiver := newAlertReceiver(t, http.StatusInternalServerError)
+
+	testNotifier(store, 2).deliver(context.Background(), alertDelivery{alert: alert, webhooks: []string{receiver.URL}})
+
+	if receiver.requests.Load() != 2 {
+		t.Errorf("requests = %d, want 2", receiver.requests.Load())
+	}
+	if got := store.get(3).NotifiedStatus; got != "" {
+		t.Errorf("NotifiedStatus = %q, want it unset", got)
+	}
+}
+
+func TestAlertNotifierKeepsNewerStatus(t *testing.T) {
+	alert := firingAlert()
+	resolved := alert
+	resolved.Status = models.AlertResolved
+	resolved.ResolvedAt = alert.FiredAt.Add(time.Minute)
+	store := newFakeAlertStore(resolved)
+	receiver := newAlertReceiver(t, http.StatusOK)
+
+	testNotifier(store, 1).deliver(context.Background(), alertDelivery{alert: alert, webhooks: []string{receiver.URL}})
+
+	if got := store.get(3).NotifiedStatus; got != "" {
+		t.Errorf("NotifiedStatus = %q, want it unset while resolved is not delivered", got)
+	}
+}
+
+func TestAlertNotifierEnqueue(t *testing.T) {
+	notifier := testNotifier(newFakeAlertStore(), 1)
+	alert := firingAlert()
+
+	notifier.Enqueue(alert, []string{"https://example.com/hook"})
+	notifier.Enqueue(alert, []string{"https://example.com/hook"})
+	if len(notifier.queue) != 1 {
+		t.Errorf("queued %d deliveries of the same status, want 1", len(notifier.queue))
+	}
+
+	alert.NotifiedStatus = models.AlertFiring
+	alert.Id = 4
+	notifier.Enqueue(alert, []string{"https://example.com/hook"})
+	if len(notifier.queue) != 1 {
+		t.Errorf("queued an already notified status")
+	}
+}
@@ -0,0 +1,489 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-rest-api/config"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// compiledAlertRule is an AlertRule with its JSON and list fields decoded.
+type compiledAlertRule struct {
+	rule     models.AlertRule
+	filter   repositories.ActivityFilter
+	groupBy  []repositories.ActivityField
+	window   time.Duration
+	webhooks []string
+}
+
+// ValidateAlertRule checks that a rule can be evaluated. Invalid filters are
+// reported as *repositories.FilterError.
+func ValidateAlertRule(rule models.AlertRule) error {
+	_, err := compileAlertRule(rule)
+	return err
+}
+
+func compileAlertRule(rule models.AlertRule) (*compiledAlertRule, error) {
+	compiled := &compiledAlertRule{rule: rule, window: time.Duration(rule.WindowSeconds) * time.Second}
+	if rule.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if rule.WindowSeconds <= 0 {
+		return nil, errors.New("window_seconds must be positive")
+	}
+	if rule.Threshold < 0 {
+		return nil, errors.New("threshold must not be negative")
+	}
+
+	if rule.Filter != "" {
+		if err := json.Unmarshal([]byte(rule.Filter), &compiled.filter); err != nil {
+			return nil, &repositories.FilterError{Clause: "filter", Message: err.Error()}
+		}
+		if _, err := compiled.filter.Condition(); err != nil {
+			return nil, err
+		}
+	}
+	if rule.GroupBy != "" {
+		for _, name := range strings.Split(rule.GroupBy, ",") {
+			field, err := repositories.ParseActivityField(strings.TrimSpace(name))
+			if err != nil {
+				return nil, fmt.Errorf("group_by: %w", err)
+			}
+			compiled.groupBy = append(compiled.groupBy, field)
+		}
+	}
+
+	webhooks, err := rule.GetWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	for _, webhook := range webhooks {
+		u, err := url.Parse(webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("webhooks: %q is not an absolute http(s) URL", webhook)
+		}
+	}
+	compiled.webhooks = webhooks
+	return compiled, nil
+}
+
+// group returns the grouping values of an activity and the fingerprint that
+// identifies the rule and group.
+func (r *compiledAlertRule) group(activity models.DeviceActivity) (map[string]string, string) {
+	group := make(map[string]string, len(r.groupBy))
+	values := make([]string, len(r.groupBy))
+	for i, field := range r.groupBy {
+		values[i] = field.Value(activity)
+		group[string(field)] = values[i]
+	}
+	return group, fmt.Sprintf("%d:%s", r.rule.Id, strings.Join(values, "\x1f"))
+}
+
+// alertWindow holds the receive times of the most recent matching activities
+// of one rule and group, oldest first. Only threshold+1 entries are kept,
+// which is enough to tell whether the threshold is exceeded.
+type alertWindow struct {
+	ruleId         uint64
+	group          map[string]string
+	times          []time.Time
+	lastActivityId uint64
+}
+
+func (w *alertWindow) add(at time.Time, limit int) {
+	w.times = append(w.times, at)
+	if len(w.times) > limit {
+		w.times = w.times[len(w.times)-limit:]
+	}
+}
+
+// prune drops entries that fell out of the window ending at now.
+func (w *alertWindow) prune(now time.Time, window time.Duration) {
+	cutoff := now.Add(-window)
+	n := 0
+	for n < len(w.times) && !w.times[n].After(cutoff) {
+		n++
+	}
+	w.times = w.times[n:]
+}
+
+// alertWrite is a fired or resolved alert waiting to be stored.
+type alertWrite struct {
+	alert    models.Alert
+	webhooks []string
+}
+
+// alertEngineStore is the part of AlertRepository the engine reads rules
+// from and stores alerts in.
+type alertEngineStore interface {
+	alertStore
+	GetRules() ([]models.AlertRule, error)
+	GetFiring() ([]models.Alert, error)
+	GetUndelivered() ([]models.Alert, error)
+	PutAlert(alert *models.Alert) error
+}
+
+// AlertEngine evaluates alert rules against every created activity. Sliding
+// windows are kept in memory; alerts that fire or resolve are queued and
+// stored by Run in order, outside the lock the create hook takes, and then
+// handed to the notifier.
+type AlertEngine struct {
+	repo     alertEngineStore
+	notifier *AlertNotifier
+	interval time.Duration
+
+	mu      sync.Mutex
+	rules   map[uint64]*compiledAlertRule
+	windows map[string]*alertWindow
+	firing  map[string]*models.Alert
+	writes  []alertWrite
+	written chan struct{}
+
+	// ids are the stored Ids of alerts fired since the start, by
+	// fingerprint. Only Run uses them.
+	ids map[string]uint64
+}
+
+func NewAlertEngine(repo *repositories.AlertRepository, notifier *AlertNotifier, cfg config.Config) *AlertEngine {
+	return &AlertEngine{
+		repo:     repo,
+		notifier: notifier,
+		interval: cfg.AlertEvaluationInterval,
+		rules:    make(map[uint64]*compiledAlertRule),
+		windows:  make(map[string]*alertWindow),
+		firing:   make(map[string]*models.Alert),
+		written:  make(chan struct{}, 1),
+		ids:      make(map[string]uint64),
+	}
+}
+
+// Load reads the rules and the alerts that were firing when the service
+// stopped. Windows are not persisted, so a restored alert stays firing for one
+// full window and resolves then unless matching activities keep arriving.
+func (e *AlertEngine) Load() error {
+	if err := e.Reload(); err != nil {
+		return err
+	}
+	firing, err := e.repo.GetFiring()
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := time.Now()
+	for i := range firing {
+		alert := &firing[i]
+		metrics.AlertsFiring.WithLabelValues(alert.RuleName).Inc()
+		rule, ok := e.rules[alert.RuleId]
+		if !ok {
+			e.resolve(alert, now, e.storedWebhooks(alert.RuleId))
+			continue
+		}
+		group, err := alert.GetGroup()
+		if err != nil {
+			return err
+		}
+		window := &alertWindow{ruleId: alert.RuleId, group: group, lastActivityId: alert.LastActivityId}
+		for j := int64(0); j <= rule.rule.Threshold; j++ {
+			window.add(now, int(rule.rule.Threshold)+1)
+		}
+		e.windows[alert.Fingerprint] = window
+		e.firing[alert.Fingerprint] = alert
+	}
+	return nil
+}
+
+// Reload replaces the active rules with the enabled rules in the repository.
+// Windows of changed rules start over, and alerts of deleted or disabled
+// rules are resolved.
+func (e *AlertEngine) Reload() error {
+	rules, err := e.repo.GetRules()
+	if err != nil {
+		return err
+	}
+	compiled := make(map[uint64]*compiledAlertRule, len(rules))
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+		c, err := compileAlertRule(rule)
+		if err != nil {
+			log.Printf("skipping invalid alert rule %q: %v", rule.Name, err)
+			continue
+		}
+		compiled[rule.Id] = c
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := time.Now()
+	for fingerprint, alert := range e.firing {
+		if _, ok := compiled[alert.RuleId]; !ok {
+			e.resolve(alert, now, e.webhooks(alert.RuleId))
+			delete(e.firing, fingerprint)
+		}
+	}
+	for fingerprint, window := range e.windows {
+		old, known := e.rules[window.ruleId]
+		if c, ok := compiled[window.ruleId]; !ok || !known || !c.rule.UpdatedAt.Equal(old.rule.UpdatedAt) {
+			if _, firing := e.firing[fingerprint]; firing {
+				window.times = nil
+			} else {
+				delete(e.windows, fingerprint)
+			}
+		}
+	}
+	e.rules = compiled
+	return nil
+}
+
+// Observe counts a created activity towards the windows of every matching
+// rule and fires alerts whose threshold is exceeded. It is registered as an
+// ActivityRepository create hook.
+func (e *AlertEngine) Observe(activity models.DeviceActivity) {
+	at := activity.ReceivedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, rule := range e.rules {
+		if !rule.filter.Matches(activity) {
+			continue
+		}
+		group, fingerprint := rule.group(activity)
+		window, ok := e.windows[fingerprint]
+		if !ok {
+			window = &alertWindow{ruleId: rule.rule.Id, group: group}
+			e.windows[fingerprint] = window
+		}
+		window.prune(at, rule.window)
+		window.add(at, int(rule.rule.Threshold)+1)
+		window.lastActivityId = activity.Id
+
+		if int64(len(window.times)) <= rule.rule.Threshold {
+			continue
+		}
+		if alert, ok := e.firing[fingerprint]; ok {
+			alert.LastActivityId = activity.Id
+			continue
+		}
+		e.fire(rule, fingerprint, window, at)
+	}
+}
+
+func (e *AlertEngine) fire(rule *compiledAlertRule, fingerprint string, window *alertWindow, at time.Time) {
+	alert := &models.Alert{
+		RuleId:         rule.rule.Id,
+		RuleName:       rule.rule.Name,
+		Fingerprint:    fingerprint,
+		Status:         models.AlertFiring,
+		Count:          int64(len(window.times)),
+		FiredAt:        at,
+		LastActivityId: window.lastActivityId,
+	}
+	if err := alert.SetGroup(window.group); err != nil {
+		log.Printf("alert rule %q: %v", rule.rule.Name, err)
+		return
+	}
+	e.firing[fingerprint] = alert
+	metrics.AlertsFiring.WithLabelValues(alert.RuleName).Inc()
+	e.queue(*alert, rule.webhooks)
+}
+
+// resolve marks a firing alert resolved and queues it. The caller removes it
+// from firing.
+func (e *AlertEngine) resolve(alert *models.Alert, at time.Time, webhooks []string) {
+	resolved := *alert
+	resolved.Status = models.AlertResolved
+	resolved.ResolvedAt = at
+	metrics.AlertsFiring.WithLabelValues(alert.RuleName).Dec()
+	e.queue(resolved, webhooks)
+}
+
+// queue adds an alert to the writes and wakes up Run. The caller holds mu.
+func (e *AlertEngine) queue(alert models.Alert, webhooks []string) {
+	e.writes = append(e.writes, alertWrite{alert: alert, webhooks: webhooks})
+	select {
+	case e.written <- struct{}{}:
+	default:
+	}
+}
+
+// persist stores the queued alerts in the order they fired and resolved and
+// hands them to the notifier. Alerts fired since the start get their Id here,
+// so their resolution is looked up by fingerprint.
+func (e *AlertEngine) persist() {
+	e.mu.Lock()
+	writes := e.writes
+	e.writes = nil
+	e.mu.Unlock()
+
+	for _, write := range writes {
+		alert := write.alert
+		if alert.Status == models.AlertFiring {
+			if err := e.repo.PutAlert(&alert); err != nil {
+				log.Printf("storing alert for rule %q failed: %v", alert.RuleName, err)
+				continue
+			}
+			e.ids[alert.Fingerprint] = alert.Id
+		} else {
+			id := alert.Id
+			if id == 0 {
+				id = e.ids[alert.Fingerprint]
+			}
+			delete(e.ids, alert.Fingerprint)
+			if id == 0 {
+				// Storing it when it fired failed.
+				continue
+			}
+			resolved, err := e.repo.UpdateAlert(id, func(stored *models.Alert) {
+				stored.Status = models.AlertResolved
+				stored.ResolvedAt = alert.ResolvedAt
+				stored.LastActivityId = alert.LastActivityId
+			})
+			if err != nil {
+				log.Printf("resolving alert %d failed: %v", id, err)
+				continue
+			}
+			alert = *resolved
+		}
+		e.notifier.Enqueue(alert, write.webhooks)
+	}
+}
+
+// webhooks returns the webhooks of an active rule, if any.
+func (e *AlertEngine) webhooks(ruleId uint64) []string {
+	if rule, ok := e.rules[ruleId]; ok {
+		return rule.webhooks
+	}
+	return nil
+}
+
+// storedWebhooks returns the webhooks of a rule that is not active, such as a
+// disabled one. Deleted rules have none.
+func (e *AlertEngine) storedWebhooks(ruleId uint64) []string {
+	rules, err := e.repo.GetRules()
+	if err != nil {
+		return nil
+	}
+	for _, rule := range rules {
+		if rule.Id == ruleId {
+			webhooks, _ := rule.GetWebhooks()
+			return webhooks
+		}
+	}
+	return nil
+}
+
+// Evaluate slides every window to now, resolves firing alerts that no longer
+// exceed their threshold and forgets empty windows. It returns the number of
+// resolved alerts.
+func (e *AlertEngine) Evaluate(now time.Time) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	resolved := 0
+	for fingerprint, window := range e.windows {
+		rule, ok := e.rules[window.ruleId]
+		if !ok {
+			window.times = nil
+		} else {
+			window.prune(now, rule.window)
+		}
+
+		alert, firing := e.firing[fingerprint]
+		if firing && (!ok || int64(len(window.times)) <= rule.rule.Threshold) {
+			e.resolve(alert, now, e.webhooks(window.ruleId))
+			delete(e.firing, fingerprint)
+			firing = false
+			resolved++
+		}
+		if !firing && len(window.times) == 0 {
+			delete(e.windows, fingerprint)
+		}
+	}
+	return resolved
+}
+
+// Run stores fired and resolved alerts and delivers their notifications,
+// including those left undelivered by a previous run, and evaluates the
+// windows on every interval until ctx is done. Alerts queued by then are
+// still stored.
+func (e *AlertEngine) Run(ctx context.Context) {
+	if err := e.redeliver(); err != nil {
+		log.Printf("loading undelivered alerts failed: %v", err)
+	}
+	go e.notifier.Run(ctx)
+
+	var tick <-chan time.Time
+	if e.interval > 0 {
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		e.persist()
+		select {
+		case <-ctx.Done():
+			e.persist()
+			return
+		case <-e.written:
+		case now := <-tick:
+			e.Evaluate(now)
+		}
+	}
+}
+
+func (e *AlertEngine) redeliver() error {
+	alerts, err := e.repo.GetUndelivered()
+	if err != nil {
+		return err
+	}
+	rules, err := e.repo.GetRules()
+	if err != nil {
+		return err
+	}
+	webhooks := make(map[uint64][]string, len(rules))
+	for _, rule := range rules {
+		webhooks[rule.Id], _ = rule.GetWebhooks()
+	}
+	for _, alert := range alerts {
+		e.notifier.Enqueue(alert, webhooks[alert.RuleId])
+	}
+	return nil
+}
+
+// Test sends a test notification for rule to its webhooks once, without
+// retries, and returns the outcome for every webhook.
+func (e *AlertEngine) Test(ctx context.Context, rule models.AlertRule) (map[string]string, error) {
+	compiled, err := compileAlertRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	alert := models.Alert{
+		RuleId:   rule.Id,
+		RuleName: rule.Name,
+		Status:   models.AlertFiring,
+		FiredAt:  time.Now(),
+	}
+	results := make(map[string]string, len(compiled.webhooks))
+	for _, webhook := range compiled.webhooks {
+		if err := e.notifier.send(ctx, webhook, newAlertNotification(alert, true)); err != nil {
+			results[webhook] = err.Error()
+		} else {
+			results[webhook] = "ok"
+		}
+	}
+	return results, nil
+}
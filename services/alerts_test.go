@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"go-rest-api/config"
+	"go-rest-api/models"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+)
+
+func (s *fakeAlertStore) GetRules() ([]models.AlertRule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]models.AlertRule(nil), s.rules...), nil
+}
+
+func (s *fakeAlertStore) PutAlert(alert *models.Alert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if alert.Id == 0 {
+		s.nextId++
+		alert.Id = s.nextId
+	}
+	stored := *alert
+	s.alerts[alert.Id] = &stored
+	return nil
+}
+
+func (s *fakeAlertStore) find(match func(alert *models.Alert) bool) []models.Alert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var alerts []models.Alert
+	for _, alert := range s.alerts {
+		if match(alert) {
+			alerts = append(alerts, *alert)
+		}
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Id < alerts[j].Id })
+	return alerts
+}
+
+func (s *fakeAlertStore) GetFiring() ([]models.Alert, error) {
+	return s.find(func(alert *models.Alert) bool { return alert.Status == models.AlertFiring }), nil
+}
+
+func (s *fakeAlertStore) GetUndelivered() ([]models.Alert, error) {
+	return s.find(func(alert *models.Alert) bool { return alert.NotifiedStatus != alert.Status }), nil
+}
+
+var alertBase = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// testEngine returns an engine evaluating one rule that fires when more than
+// threshold logins of a grid arrive within a minute, notifying receiver.
+func testEngine(t *testing.T, threshold int64, receiver *alertReceiver) (*AlertEngine, *fakeAlertStore) {
+	t.Helper()
+	rule := models.AlertRule{Id: 1, Name: "logins", Enabled: true, Filter: `{"action": {"in": ["login"]}}`,
+		GroupBy: "grid", WindowSeconds: 60, Threshold: threshold, UpdatedAt: alertBase}
+	if err := rule.SetWebhooks([]string{receiver.URL}); err != nil {
+		t.Fatal(err)
+	}
+	store := newFakeAlertStore()
+	store.rules = []models.AlertRule{rule}
+
+	engine := NewAlertEngine(nil, testNotifier(store, 1), config.Config{})
+	engine.repo = store
+	if err := engine.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	return engine, store
+}
+
+// flush stores the queued alerts and delivers their notifications, as Run
+// and the notifier's Run do.
+func flush(engine *AlertEngine) {
+	engine.persist()
+	notifier := engine.notifier
+	for len(notifier.queue) > 0 {
+		delivery := <-notifier.queue
+		notifier.deliver(context.Background(), delivery)
+		notifier.mu.Lock()
+		delete(notifier.pending, delivery.key())
+		notifier.mu.Unlock()
+	}
+}
+
+func login(id uint64, grid string, at time.Time) models.DeviceActivity {
+	return models.DeviceActivity{Id: id, GridName: grid, DeviceName: "dev-1", Action: "login", ReceivedAt: at}
+}
+
+func TestAlertEngineFiresAboveThreshold(t *testing.T) {
+	receiver := newAlertReceiver(t, http.StatusOK)
+	engine, store := testEngine(t, 3, receiver)
+
+	// Reaching the threshold does not fire; only exceeding it does.
+	for i := 0; i < 3; i++ {
+		engine.Observe(login(uint64(i+1), "eu", alertBase.Add(time.Duration(i)*time.Second)))
+	}
+	engine.Observe(models.DeviceActivity{Id: 10, GridName: "eu", Action: "logout", ReceivedAt: alertBase.Add(4 * time.Second)})
+	engine.Observe(login(11, "us", alertBase.Add(4*time.Second)))
+	flush(engine)
+	if receiver.requests.Load() != 0 {
+		t.Fatalf("notified at the threshold: %+v", receiver.notifications)
+	}
+
+	engine.Observe(login(12, "eu", alertBase.Add(5*time.Second)))
+	engine.Observe(login(13, "eu", alertBase.Add(6*time.Second)))
+	flush(engine)
+	if receiver.requests.Load() != 1 {
+		t.Fatalf("requests = %d, want one firing notification", receiver.requests.Load())
+	}
+	notification := receiver.notifications[0]
+	if notification.Status != models.AlertFiring || notification.Rule != "logins" || notification.Group["grid"] != "eu" ||
+		notification.Count != 4 || notification.LastActivityId != 12 || !notification.FiredAt.Equal(alertBase.Add(5*time.Second)) {
+		t.Errorf("notification = %+v", notification)
+	}
+	firing, _ := store.GetFiring()
+	if len(firing) != 1 || firing[0].Count != 4 || firing[0].NotifiedStatus != models.AlertFiring {
+		t.Errorf("firing alerts = %+v", firing)
+	}
+}
+
+func TestAlertEngineResolvesWhenWindowExpires(t *testing.T) {
+	receiver := newAlertReceiver(t, http.StatusOK)
+	engine, store := testEngine(t, 2, receiver)
+	for i := 0; i < 3; i++ {
+		engine.Observe(login(uint64(i+1), "eu", alertBase.Add(time.Duration(i)*time.Second)))
+	}
+	flush(engine)
+	if receiver.requests.Load() != 1 {
+		t.Fatalf("requests = %d, want one firing notification", receiver.requests.Load())
+	}
+
+	// The oldest activity leaves the window exactly one window after it arrived.
+	if resolved := engine.Evaluate(alertBase.Add(time.Minute - time.Millisecond)); resolved != 0 {
+		t.Fatalf("resolved %d alerts before the window passed", resolved)
+	}
+	if resolved := engine.Evaluate(alertBase.Add(time.Minute)); resolved != 1 {
+		t.Fatalf("resolved %d alerts once the window passed, want 1", resolved)
+	}
+	flush(engine)
+	if receiver.requests.Load() != 2 {
+		t.Fatalf("requests = %d, want a resolved notification", receiver.requests.Load())
+	}
+	notification := receiver.notifications[1]
+	if notification.Status != models.AlertResolved || notification.ResolvedAt == nil ||
+		!notification.ResolvedAt.Equal(alertBase.Add(time.Minute)) || notification.Id != receiver.notifications[0].Id {
+		t.Errorf("notification = %+v", notification)
+	}
+	if receiver.keys[1] != "alert-1-resolved" {
+		t.Errorf("Idempotency-Key = %q, want alert-1-resolved", receiver.keys[1])
+	}
+	if firing, _ := store.GetFiring(); len(firing) != 0 {
+		t.Errorf("still firing: %+v", firing)
+	}
+	if len(engine.windows) != 1 {
+		t.Errorf("%d windows kept, want the one still holding activities", len(engine.windows))
+	}
+	engine.Evaluate(alertBase.Add(time.Minute + 2*time.Second))
+	if len(engine.windows) != 0 {
+		t.Errorf("empty window was not forgotten")
+	}
+}
+
+func TestAlertEngineSlidesWindow(t *testing.T) {
+	receiver := newAlertReceiver(t, http.StatusOK)
+	engine, _ := testEngine(t, 2, receiver)
+
+	// Three logins spread over more than a window never exceed two at once.
+	engine.Observe(login(1, "eu", alertBase))
+	engine.Observe(login(2, "eu", alertBase.Add(30*time.Second)))
+	engine.Observe(login(3, "eu", alertBase.Add(time.Minute)))
+	flush(engine)
+	if receiver.requests.Load() != 0 {
+		t.Fatalf("fired for activities outside the window: %+v", receiver.notifications)
+	}
+
+	engine.Observe(login(4, "eu", alertBase.Add(time.Minute+time.Second)))
+	flush(engine)
+	if receiver.requests.Load() != 1 || receiver.notifications[0].Count != 3 {
+		t.Errorf("requests = %d, notifications = %+v, want one firing with 3", receiver.requests.Load(), receiver.notifications)
+	}
+}
+
+func TestAlertEngineFiresAgainAfterResolving(t *testing.T) {
+	receiver := newAlertReceiver(t, http.StatusOK)
+	engine, store := testEngine(t, 0, receiver)
+
+	engine.Observe(login(1, "eu", alertBase))
+	engine.Observe(login(2, "eu", alertBase.Add(time.Second)))
+	engine.Evaluate(alertBase.Add(2 * time.Minute))
+	engine.Observe(login(3, "eu", alertBase.Add(3*time.Minute)))
+	flush(engine)
+
+	var statuses []string
+	for _, notification := range receiver.notifications {
+		statuses = append(statuses, notification.Status)
+	}
+	if len(statuses) != 3 || statuses[0] != models.AlertFiring || statuses[1] != models.AlertResolved || statuses[2] != models.AlertFiring {
+		t.Fatalf("notified %v, want firing, resolved, firing", statuses)
+	}
+	if receiver.notifications[0].Id == receiver.notifications[2].Id {
+		t.Errorf("firing again reused alert %d", receiver.notifications[0].Id)
+	}
+	if firing, _ := store.GetFiring(); len(firing) != 1 || firing[0].LastActivityId != 3 {
+		t.Errorf("firing alerts = %+v", firing)
+	}
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+)
+
+// BulkDeleteResult summarises a delete by filter. Sample holds up to the
+// requested number of matching activities, taken before anything is deleted.
+type BulkDeleteResult struct {
+	Matched uint64                  `json:"matched"`
+	Deleted uint64                  `json:"deleted"`
+	DryRun  bool                    `json:"dry_run"`
+	Sample  []models.DeviceActivity `json:"sample"`
+}
+
+// BulkDelete deletes every activity matching filter in batches of batchSize,
+// each in its own transaction, so that large deletes do not hold one long
+// write transaction. A hard delete also removes matching activities that are
+// already in the trash. With dryRun set it only counts and samples the matches.
+// If ctx is cancelled part way, the batches already committed stay deleted.
+func BulkDelete(ctx context.Context, repo *repositories.ActivityRepository, filter repositories.ActivityFilter,
+	batchSize, sampleSize int, hard, dryRun bool) (BulkDeleteResult, error) {
+	result := BulkDeleteResult{DryRun: dryRun, Sample: []models.DeviceActivity{}}
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	matched, err := repo.CountMatching(filter, hard)
+	if err != nil {
+		return result, err
+	}
+	result.Matched = matched
+	if sampleSize > 0 && matched > 0 {
+		search := repo.Search
+		if hard {
+			search = repo.SearchWithDeleted
+		}
+		page, err := search(filter, repositories.PageRequest{Limit: sampleSize, Sort: repositories.SortTimestampDesc})
+		if err != nil {
+			return result, err
+		}
+		result.Sample = page.Activities
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	for ctx.Err() == nil {
+		n, err := repo.DeleteMatching(filter, batchSize, hard)
+		result.Deleted += n
+		if err != nil || n < uint64(batchSize) {
+			return result, err
+		}
+	}
+	return result, ctx.Err()
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"time"
+)
+
+// WaitForChanges returns up to limit changes after the given sequence. If
+// there are none it waits up to wait for the next commit before returning,
+// possibly with no changes if wait elapses or ctx is done first.
+func WaitForChanges(ctx context.Context, changelog *repositories.ChangelogRepository, after uint64, limit int, wait time.Duration) ([]models.ActivityChange, error) {
+	var deadline <-chan time.Time
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	for {
+		changed := changelog.Changed()
+		changes, err := changelog.GetAfter(after, limit)
+		if err != nil || len(changes) > 0 || deadline == nil {
+			return changes, err
+		}
+		select {
+		case <-changed:
+		case <-deadline:
+			return changes, nil
+		case <-ctx.Done():
+			return changes, nil
+		}
+	}
+}
+
+// ChangelogFloor returns the newest sequence every consumer has processed:
+// the lowest change feed checkpoint and, while there are webhook
+// subscriptions, the webhook dispatch checkpoint. Without either it is the
+// newest sequence. Changes up to the floor may be pruned.
+func ChangelogFloor(changelog *repositories.ChangelogRepository, webhooks *repositories.WebhookRepository) (uint64, error) {
+	floor, err := changelog.LastSequence()
+	if err != nil {
+		return 0, err
+	}
+	checkpoints, err := changelog.GetCheckpoints()
+	if err != nil {
+		return 0, err
+	}
+	for _, checkpoint := range checkpoints {
+		floor = min(floor, checkpoint.Sequence)
+	}
+
+	subscriptions, err := webhooks.GetSubscriptions()
+	if err != nil {
+		return 0, err
+	}
+	if len(subscriptions) > 0 {
+		dispatched, err := webhooks.LastDispatched()
+		if err != nil {
+			return 0, err
+		}
+		floor = min(floor, dispatched)
+	}
+	return floor, nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"go-rest-api/config"
+	"go-rest-api/models"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientIPResolver derives the client IP of a request. The forwarding header
+// set by the trusted proxies is only believed when the peer, and every hop
+// after the client, is a trusted proxy.
+type ClientIPResolver struct {
+	trusted []*net.IPNet
+	header  string
+}
+
+func NewClientIPResolver(cfg config.Config) ClientIPResolver {
+	return ClientIPResolver{trusted: cfg.TrustedProxies, header: cfg.ForwardedHeader}
+}
+
+// Resolve returns the client IP. It walks the chain of the configured
+// forwarding header from the nearest hop outwards and stops at the first
+// address that is not a trusted proxy. Other forwarding headers are ignored,
+// since a client can send them through a proxy that does not overwrite them.
+func (r ClientIPResolver) Resolve(req *http.Request) string {
+	remote := parseHostIP(req.RemoteAddr)
+	if remote == nil {
+		return req.RemoteAddr
+	}
+	client := remote
+	if !r.isTrusted(client) {
+		return client.String()
+	}
+
+	hops := forwardedFor(req.Header, r.header)
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := parseHostIP(hops[i])
+		if ip == nil {
+			// Obfuscated or malformed hop; nothing before it can be attributed.
+			break
+		}
+		client = ip
+		if !r.isTrusted(client) {
+			break
+		}
+	}
+	return client.String()
+}
+
+// Apply replaces the SourceIP sent by the client with the resolved address,
+// keeping the claim in ClaimedSourceIP and flagging a disagreement.
+func (r ClientIPResolver) Apply(activity *models.DeviceActivity, req *http.Request) {
+	claimed := strings.TrimSpace(activity.SourceIP)
+	activity.ClaimedSourceIP = claimed
+	activity.SourceIP = r.Resolve(req)
+	activity.SourceIPMismatch = claimed != "" && !sameIP(claimed, activity.SourceIP)
+}
+
+func (r ClientIPResolver) isTrusted(ip net.IP) bool {
+	for _, network := range r.trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedFor returns the client chain from the named header, ordered from
+// the original client to the nearest proxy. Forwarded contributes its for=
+// parameters; X-Forwarded-For is read as a comma-separated list.
+func forwardedFor(header http.Header, name string) []string {
+	var hops []string
+	if strings.EqualFold(name, "Forwarded") {
+		for _, value := range header.Values(name) {
+			for _, element := range strings.Split(value, ",") {
+				for _, pair := range strings.Split(element, ";") {
+					key, node, found := strings.Cut(strings.TrimSpace(pair), "=")
+					if found && strings.EqualFold(key, "for") {
+						hops = append(hops, strings.Trim(node, `"`))
+					}
+				}
+			}
+		}
+		return hops
+	}
+	for _, value := range header.Values(name) {
+		for _, hop := range strings.Split(value, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	return hops
+}
+
+// parseHostIP parses an IP that may carry a port and IPv6 brackets.
+func parseHostIP(value string) net.IP {
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		value = host
+	}
+	return net.ParseIP(strings.Trim(value, "[]"))
+}
+
+func sameIP(a, b string) bool {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	if ipA == nil || ipB == nil {
+		return a == b
+	}
+	return ipA.Equal(ipB)
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"go-rest-api/config"
+	"go-rest-api/models"
+	"net"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestForwardedFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		from   string
+		want   []string
+	}{
+		{"x-forwarded-for", http.Header{"X-Forwarded-For": {"1.1.1.1, 10.0.0.2", "10.0.0.3"}}, "X-Forwarded-For",
+			[]string{"1.1.1.1", "10.0.0.2", "10.0.0.3"}},
+		{"forwarded", http.Header{"Forwarded": {`for=1.1.1.1;proto=https, for="[2001:db8::1]:4711";by=10.0.0.1`}}, "Forwarded",
+			[]string{"1.1.1.1", "[2001:db8::1]:4711"}},
+		{"spoofed forwarded is ignored", http.Header{"Forwarded": {"for=1.2.3.4"}, "X-Forwarded-For": {"5.5.5.5"}},
+			"X-Forwarded-For", []string{"5.5.5.5"}},
+		{"x-forwarded-for is ignored", http.Header{"X-Forwarded-For": {"1.2.3.4"}}, "Forwarded", nil},
+		{"no header", http.Header{}, "X-Forwarded-For", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := forwardedFor(tt.header, tt.from); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("forwardedFor = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func testResolver(t *testing.T, header string, trusted ...string) ClientIPResolver {
+	t.Helper()
+	cfg := config.Config{ForwardedHeader: header}
+	for _, cidr := range trusted {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.TrustedProxies = append(cfg.TrustedProxies, network)
+	}
+	return NewClientIPResolver(cfg)
+}
+
+func TestClientIPResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolver ClientIPResolver
+		remote   string
+		header   http.Header
+		want     string
+	}{
+		{"untrusted peer", testResolver(t, "X-Forwarded-For", "10.0.0.0/8"), "203.0.113.9:5000",
+			http.Header{"X-Forwarded-For": {"1.2.3.4"}}, "203.0.113.9"},
+		{"no proxies configured", testResolver(t, "X-Forwarded-For"), "10.0.0.1:5000",
+			http.Header{"X-Forwarded-For": {"1.2.3.4"}}, "10.0.0.1"},
+		{"trusted peer", testResolver(t, "X-Forwarded-For", "10.0.0.0/8"), "10.0.0.1:5000",
+			http.Header{"X-Forwarded-For": {"198.51.100.7"}}, "198.51.100.7"},
+		{"multiple trusted hops", testResolver(t, "X-Forwarded-For", "10.0.0.0/8"), "10.0.0.1:5000",
+			http.Header{"X-Forwarded-For": {"198.51.100.7, 10.0.0.3, 10.0.0.2"}}, "198.51.100.7"},
+		{"stops at the first untrusted hop", testResolver(t, "X-Forwarded-For", "10.0.0.0/8"), "10.0.0.1:5000",
+			http.Header{"X-Forwarded-For": {"1.2.3.4, 198.51.100.7, 10.0.0.2"}}, "198.51.100.7"},
+		{"spoofed forwarded behind an x-forwarded-for proxy", testResolver(t, "X-Forwarded-For", "10.0.0.0/8"), "10.0.0.1:5000",
+			http.Header{"Forwarded": {"for=1.2.3.4"}, "X-Forwarded-For": {"198.51.100.7"}}, "198.51.100.7"},
+		{"ipv6 with brackets and port", testResolver(t, "Forwarded", "10.0.0.0/8"), "10.0.0.1:5000",
+			http.Header{"Forwarded": {`for="[2001:db8::1]:4711"`}}, "2001:db8::1"},
+		{"ipv6 peer", testResolver(t, "X-Forwarded-For", "fd00::/8"), "[fd00::2]:5000",
+			http.Header{"X-Forwarded-For": {"2001:db8::7"}}, "2001:db8::7"},
+		{"obfuscated hop", testResolver(t, "Forwarded", "10.0.0.0/8"), "10.0.0.1:5000",
+			http.Header{"Forwarded": {"for=198.51.100.7, for=_hidden"}}, "10.0.0.1"},
+		{"unparseable remote address", testResolver(t, "X-Forwarded-For", "10.0.0.0/8"), "pipe",
+			http.Header{"X-Forwarded-For": {"1.2.3.4"}}, "pipe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &http.Request{RemoteAddr: tt.remote, Header: tt.header}
+			if got := tt.resolver.Resolve(req); got != tt.want {
+				t.Errorf("Resolve = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientIPApply(t *testing.T) {
+	resolver := testResolver(t, "X-Forwarded-For")
+	req := &http.Request{RemoteAddr: "203.0.113.9:5000", Header: http.Header{}}
+
+	activity := models.DeviceActivity{SourceIP: " 1.2.3.4 "}
+	resolver.Apply(&activity, req)
+	if activity.SourceIP != "203.0.113.9" || activity.ClaimedSourceIP != "1.2.3.4" || !activity.SourceIPMismatch {
+		t.Errorf("activity = %q claimed %q mismatch %v", activity.SourceIP, activity.ClaimedSourceIP, activity.SourceIPMismatch)
+	}
+
+	activity = models.DeviceActivity{SourceIP: "203.0.113.9"}
+	resolver.Apply(&activity, req)
+	if activity.SourceIPMismatch {
+		t.Error("matching claim flagged as a mismatch")
+	}
+}
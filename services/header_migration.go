@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"log"
+)
+
+// HeaderMigrationResult summarises a header migration run.
+type HeaderMigrationResult struct {
+	Scanned   int
+	Rewritten int
+	Skipped   int
+}
+
+// MigrateHeaders rewrites the stored headers of every activity to match the
+// policy, in Id order and in batches of batchSize. Activities whose headers
+// cannot be parsed are left untouched and counted as skipped. With dryRun set
+// nothing is written.
+func MigrateHeaders(ctx context.Context, repo *repositories.ActivityRepository, policy HeaderPolicy, batchSize int, dryRun bool) (HeaderMigrationResult, error) {
+	var result HeaderMigrationResult
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	var afterId uint64
+	for ctx.Err() == nil {
+		batch, err := repo.GetAfterIdWithDeleted(afterId, repositories.ActivityFilter{}, batchSize)
+		if err != nil {
+			return result, err
+		}
+
+		var rewritten []*models.DeviceActivity
+		for i := range batch {
+			activity := &batch[i]
+			afterId = activity.Id
+			result.Scanned++
+			if activity.Headers == "" {
+				continue
+			}
+
+			headers, err := activity.GetHeaders()
+			if err != nil {
+				log.Printf("header migration: activity %d has unreadable headers: %v", activity.Id, err)
+				result.Skipped++
+				continue
+			}
+			sanitised, changed := policy.Apply(headers)
+			if !changed {
+				continue
+			}
+			if err := activity.SetHeaders(sanitised); err != nil {
+				return result, err
+			}
+			rewritten = append(rewritten, activity)
+		}
+
+		if len(rewritten) > 0 && !dryRun {
+			if err := repo.UpdateMany(rewritten); err != nil {
+				return result, err
+			}
+		}
+		result.Rewritten += len(rewritten)
+
+		if len(batch) < batchSize {
+			return result, nil
+		}
+	}
+	return result, ctx.Err()
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"go-rest-api/config"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	// RedactedHeaderValue replaces the value of redacted headers.
+	RedactedHeaderValue = "[REDACTED]"
+	// hashedHeaderPrefix marks hashed values so stored ones are not hashed twice.
+	hashedHeaderPrefix = "sha256:"
+	maskedHeaderValue  = "***"
+)
+
+// HeaderPolicy decides which request headers are stored with an activity and
+// how their values are sanitised. Header names are compared canonically.
+type HeaderPolicy struct {
+	allowlist    bool
+	allow        map[string]bool
+	deny         map[string]bool
+	redact       map[string]bool
+	hash         map[string]bool
+	hashKey      []byte
+	maskPatterns []*regexp.Regexp
+	multiValue   bool
+}
+
+func NewHeaderPolicy(cfg config.Config) HeaderPolicy {
+	return HeaderPolicy{
+		allowlist:    cfg.HeaderMode == config.HeaderModeAllowlist,
+		allow:        headerSet(cfg.HeaderAllow),
+		deny:         headerSet(cfg.HeaderDeny),
+		redact:       headerSet(cfg.HeaderRedact),
+		hash:         headerSet(cfg.HeaderHash),
+		hashKey:      []byte(cfg.HeaderHashKey),
+		maskPatterns: cfg.HeaderMaskPatterns,
+		multiValue:   cfg.HeaderMultiValue,
+	}
+}
+
+func headerSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[http.CanonicalHeaderKey(name)] = true
+	}
+	return set
+}
+
+// Capture returns the request headers to store. Repeated headers keep their
+// first value, or all values joined with ", " when multi-value capture is on.
+func (p HeaderPolicy) Capture(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key, values := range header {
+		if len(values) == 0 {
+			continue
+		}
+		if p.multiValue {
+			headers[key] = strings.Join(values, ", ")
+		} else {
+			headers[key] = values[0]
+		}
+	}
+	result, _ := p.apply(headers, false)
+	return result
+}
+
+// Apply sanitises already captured headers and reports whether anything
+// changed. Applying a policy to its own output changes nothing, so it is safe
+// to run over stored activities more than once.
+func (p HeaderPolicy) Apply(headers map[string]string) (map[string]string, bool) {
+	return p.apply(headers, true)
+}
+
+// apply sanitises headers. Values that are stored may already have been
+// hashed or redacted and are kept then; values from a client are always
+// hashed, whatever they look like.
+func (p HeaderPolicy) apply(headers map[string]string, stored bool) (map[string]string, bool) {
+	result := make(map[string]string, len(headers))
+	changed := false
+	for key, value := range headers {
+		name := http.CanonicalHeaderKey(key)
+		if p.deny[name] || (p.allowlist && !p.allow[name]) {
+			changed = true
+			continue
+		}
+		sanitised := p.sanitise(name, value, stored)
+		if sanitised != value || name != key {
+			changed = true
+		}
+		result[name] = sanitised
+	}
+	return result, changed
+}
+
+// sanitise hashes headers on the hash list, even those redacted by default,
+// redacts the others on the redact list and masks the rest. Without a hash
+// key, headers on the hash list are redacted, since an unkeyed hash of a
+// low-entropy credential can be reversed by brute force.
+func (p HeaderPolicy) sanitise(name, value string, stored bool) string {
+	switch {
+	case p.hash[name]:
+		if stored && (strings.HasPrefix(value, hashedHeaderPrefix) || value == RedactedHeaderValue) {
+			return value
+		}
+		if len(p.hashKey) == 0 {
+			return RedactedHeaderValue
+		}
+		return hashedHeaderPrefix + p.hashValue(value)
+	case p.redact[name]:
+		return RedactedHeaderValue
+	}
+	for _, pattern := range p.maskPatterns {
+		value = pattern.ReplaceAllLiteralString(value, maskedHeaderValue)
+	}
+	return value
+}
+
+// hashValue returns the HMAC-SHA256 of the value, so equal values can still
+// be correlated.
+func (p HeaderPolicy) hashValue(value string) string {
+	mac := hmac.New(sha256.New, p.hashKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"go-rest-api/config"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func testHeaderPolicy(hashKey string) HeaderPolicy {
+	return NewHeaderPolicy(config.Config{
+		HeaderMode:         config.HeaderModeDenylist,
+		HeaderDeny:         []string{"X-Internal"},
+		HeaderRedact:       []string{"Authorization", "Cookie"},
+		HeaderHash:         []string{"Cookie", "X-User"},
+		HeaderHashKey:      hashKey,
+		HeaderMaskPatterns: []*regexp.Regexp{regexp.MustCompile(`token=\w+`)},
+	})
+}
+
+func TestHeaderPolicySanitise(t *testing.T) {
+	keyed := testHeaderPolicy("k3y")
+	tests := []struct {
+		name   string
+		policy HeaderPolicy
+		header string
+		value  string
+		stored bool
+		want   string
+	}{
+		{"redacted", keyed, "Authorization", "Bearer abc", false, RedactedHeaderValue},
+		{"hash wins over redact", keyed, "Cookie", "session=1", false, hashedHeaderPrefix + keyed.hashValue("session=1")},
+		{"hashed", keyed, "X-User", "alice", false, hashedHeaderPrefix + keyed.hashValue("alice")},
+		{"client value that looks hashed", keyed, "X-User", "sha256:abc", false, hashedHeaderPrefix + keyed.hashValue("sha256:abc")},
+		{"stored hash is kept", keyed, "X-User", "sha256:abc", true, "sha256:abc"},
+		{"stored redaction is kept", keyed, "Cookie", RedactedHeaderValue, true, RedactedHeaderValue},
+		{"masked", keyed, "Referer", "/cb?token=abc&x=1", false, "/cb?***&x=1"},
+		{"redact before mask", keyed, "Authorization", "token=abc", false, RedactedHeaderValue},
+		{"untouched", keyed, "Accept", "text/html", false, "text/html"},
+		{"no key redacts", testHeaderPolicy(""), "X-User", "alice", false, RedactedHeaderValue},
+		{"no key redacts credentials", testHeaderPolicy(""), "Cookie", "session=1", false, RedactedHeaderValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.sanitise(tt.header, tt.value, tt.stored); got != tt.want {
+				t.Errorf("sanitise(%s: %q) = %q, want %q", tt.header, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeaderPolicyHashIsKeyed(t *testing.T) {
+	a, b := testHeaderPolicy("one").hashValue("alice"), testHeaderPolicy("two").hashValue("alice")
+	if a == b {
+		t.Error("hash does not depend on the key")
+	}
+	if a != testHeaderPolicy("one").hashValue("alice") {
+		t.Error("hash is not deterministic")
+	}
+}
+
+func TestHeaderPolicyCapture(t *testing.T) {
+	policy := testHeaderPolicy("k3y")
+	headers := policy.Capture(http.Header{
+		"Authorization": {"Bearer abc"},
+		"X-Internal":    {"secret"},
+		"Accept":        {"text/html", "application/json"},
+	})
+	if _, ok := headers["X-Internal"]; ok {
+		t.Error("denied header was captured")
+	}
+	if headers["Authorization"] != RedactedHeaderValue || headers["Accept"] != "text/html" {
+		t.Errorf("headers = %v", headers)
+	}
+
+	allowlist := NewHeaderPolicy(config.Config{HeaderMode: config.HeaderModeAllowlist, HeaderAllow: []string{"accept"},
+		HeaderMultiValue: true})
+	headers = allowlist.Capture(http.Header{"Accept": {"text/html", "application/json"}, "User-Agent": {"curl"}})
+	if len(headers) != 1 || headers["Accept"] != "text/html, application/json" {
+		t.Errorf("allowlist headers = %v", headers)
+	}
+}
+
+func TestHeaderPolicyApplyIsIdempotent(t *testing.T) {
+	policy := testHeaderPolicy("k3y")
+	first, changed := policy.Apply(map[string]string{"cookie": "session=1", "x-user": "alice", "Accept": "text/html"})
+	if !changed || !strings.HasPrefix(first["Cookie"], hashedHeaderPrefix) {
+		t.Fatalf("first Apply = %v, %v", first, changed)
+	}
+	second, changed := policy.Apply(first)
+	if changed {
+		t.Errorf("applying the policy to its own output changed %v to %v", first, second)
+	}
+}
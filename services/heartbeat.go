@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"go-rest-api/config"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"go-rest-api/utils"
+	"log"
+	"time"
+)
+
+// Actions of the synthetic activities recorded on presence transitions.
+const (
+	ActionDeviceOnline  = "device_online"
+	ActionDeviceOffline = "device_offline"
+)
+
+const heartbeatPageSize = 500
+
+// HeartbeatMonitor marks devices offline when no activity arrives within
+// their expected heartbeat interval, and online again once one does.
+type HeartbeatMonitor struct {
+	activities      *repositories.ActivityRepository
+	devices         *repositories.DeviceRepository
+	grids           *repositories.GridRepository
+	defaultInterval time.Duration
+	checkInterval   time.Duration
+	// monitored are the devices the last complete check reported a
+	// DeviceOnline gauge for. Only Check uses it.
+	monitored map[string]bool
+}
+
+func NewHeartbeatMonitor(activities *repositories.ActivityRepository, devices *repositories.DeviceRepository,
+	grids *repositories.GridRepository, cfg config.Config) *HeartbeatMonitor {
+	return &HeartbeatMonitor{
+		activities:      activities,
+		devices:         devices,
+		grids:           grids,
+		defaultInterval: cfg.HeartbeatInterval,
+		checkInterval:   cfg.HeartbeatCheckInterval,
+		monitored:       make(map[string]bool),
+	}
+}
+
+// Run checks every device on each check interval until ctx is done.
+func (m *HeartbeatMonitor) Run(ctx context.Context) {
+	if m.checkInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := m.Check(ctx, time.Now()); err != nil {
+				log.Printf("heartbeat check failed: %v", err)
+			}
+		}
+	}
+}
+
+// Interval returns the expected heartbeat interval of a device: its own, else
+// that of its grid or nearest ancestor grid, else the configured default.
+// Zero means the device is not monitored.
+func (m *HeartbeatMonitor) Interval(device models.Device, tree repositories.GridTree) time.Duration {
+	if device.HeartbeatSeconds > 0 {
+		return time.Duration(device.HeartbeatSeconds) * time.Second
+	}
+	for _, grid := range tree.Ancestors(device.GridName) {
+		if grid.HeartbeatSeconds > 0 {
+			return time.Duration(grid.HeartbeatSeconds) * time.Second
+		}
+	}
+	return m.defaultInterval
+}
+
+// Check evaluates every monitored device at now, records a synthetic activity
+// for each transition and returns the number of transitions. Once every
+// device has been checked, the DeviceOnline gauges of devices that are no
+// longer monitored are deleted; the others are only ever overwritten, so a
+// scrape never sees a device disappear while the check is running.
+func (m *HeartbeatMonitor) Check(ctx context.Context, now time.Time) (int, error) {
+	tree, err := m.grids.Tree()
+	if err != nil {
+		return 0, err
+	}
+
+	monitored := make(map[string]bool, len(m.monitored))
+	transitions := 0
+	cursor := ""
+	for ctx.Err() == nil {
+		page, err := m.devices.List("", cursor, heartbeatPageSize)
+		if err != nil {
+			return transitions, err
+		}
+		for _, device := range page.Devices {
+			interval := m.Interval(device, tree)
+			if interval <= 0 {
+				continue
+			}
+
+			online := func(current models.Device) bool {
+				return now.Sub(current.LastSeen) <= interval
+			}
+			device, changed, err := m.devices.SetOnline(device.Id, online, now)
+			if err != nil {
+				return transitions, err
+			}
+			monitored[device.Name] = true
+			if device.Online {
+				metrics.DeviceOnline.WithLabelValues(device.Name).Set(1)
+			} else {
+				metrics.DeviceOnline.WithLabelValues(device.Name).Set(0)
+			}
+			if !changed {
+				continue
+			}
+
+			if err := m.recordTransition(device, interval, now); err != nil {
+				return transitions, err
+			}
+			transitions++
+		}
+		if page.NextCursor == "" {
+			for name := range m.monitored {
+				if !monitored[name] {
+					metrics.DeviceOnline.DeleteLabelValues(name)
+				}
+			}
+			m.monitored = monitored
+			return transitions, nil
+		}
+		cursor = page.NextCursor
+	}
+	return transitions, ctx.Err()
+}
+
+// recordTransition stores the transition as a synthetic activity. Its
+// Timestamp is when the transition happened: the missed deadline for going
+// offline and the first new activity for coming back online.
+func (m *HeartbeatMonitor) recordTransition(device models.Device, interval time.Duration, now time.Time) error {
+	activity := models.DeviceActivity{
+		UniqueId:   utils.GenerateUUID(),
+		DeviceName: device.Name,
+		GridName:   device.GridName,
+		SourceIP:   device.LastSourceIP,
+		ReceivedAt: now,
+		Synthetic:  true,
+	}
+	if device.Online {
+		activity.Action = ActionDeviceOnline
+		activity.Timestamp = device.LastSeen
+	} else {
+		activity.Action = ActionDeviceOffline
+		activity.Timestamp = device.LastSeen.Add(interval)
+	}
+	return m.activities.Create(activity)
+}
+
+// Offline returns the monitored devices that are currently offline.
+func (m *HeartbeatMonitor) Offline() ([]models.Device, error) {
+	tree, err := m.grids.Tree()
+	if err != nil {
+		return nil, err
+	}
+	devices, err := m.devices.GetByOnline(false)
+	if err != nil {
+		return nil, err
+	}
+
+	offline := make([]models.Device, 0, len(devices))
+	for _, device := range devices {
+		if m.Interval(device, tree) > 0 {
+			offline = append(offline, device)
+		}
+	}
+	return offline, nil
+}
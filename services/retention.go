@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"go-rest-api/config"
+	"go-rest-api/metrics"
+	"go-rest-api/repositories"
+	"log"
+	"sort"
+	"time"
+)
+
+// RetentionPolicy describes which activities are kept. Zero values disable a limit.
+type RetentionPolicy struct {
+	MaxAge     time.Duration
+	GridMaxAge map[string]time.Duration
+	MaxRows    int
+	// TrashMaxAge is how long soft-deleted activities are kept in the trash.
+	TrashMaxAge time.Duration
+}
+
+func (p RetentionPolicy) enabled() bool {
+	return p.MaxAge > 0 || len(p.GridMaxAge) > 0 || p.MaxRows > 0 || p.TrashMaxAge > 0
+}
+
+// SweepResult summarises a single retention sweep.
+type SweepResult struct {
+	Pruned   uint64
+	Duration time.Duration
+}
+
+// RetentionWorker periodically deletes expired activities in bounded batches.
+type RetentionWorker struct {
+	repo      *repositories.ActivityRepository
+	policy    RetentionPolicy
+	interval  time.Duration
+	batchSize int
+}
+
+func NewRetentionWorker(repo *repositories.ActivityRepository, cfg config.Config) *RetentionWorker {
+	batchSize := cfg.RetentionBatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	return &RetentionWorker{
+		repo: repo,
+		policy: RetentionPolicy{
+			MaxAge:     cfg.RetentionMaxAge,
+			GridMaxAge: cfg.RetentionGridMaxAge,
+			MaxRows:    cfg.RetentionMaxRows,
+
+			TrashMaxAge: cfg.TrashRetention,
+		},
+		interval:  cfg.RetentionInterval,
+		batchSize: batchSize,
+	}
+}
+
+// Run sweeps once immediately and then on every interval until ctx is done.
+// It returns straight away if no retention limit is configured.
+func (w *RetentionWorker) Run(ctx context.Context) {
+	if !w.policy.enabled() || w.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := w.Sweep(ctx, time.Now()); err != nil {
+			log.Printf("retention sweep failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep applies the retention policy relative to now.
+func (w *RetentionWorker) Sweep(ctx context.Context, now time.Time) (result SweepResult, err error) {
+	start := time.Now()
+	defer func() {
+		result.Duration = time.Since(start)
+		metrics.RetentionSweepDuration.Observe(result.Duration.Seconds())
+	}()
+
+	grids := make([]string, 0, len(w.policy.GridMaxAge))
+	for grid := range w.policy.GridMaxAge {
+		grids = append(grids, grid)
+	}
+	sort.Strings(grids)
+
+	for _, grid := range grids {
+		scope := repositories.PruneScope{Grid: grid}
+		n, err := w.pruneOlderThan(ctx, now.Add(-w.policy.GridMaxAge[grid]), scope, "grid_max_age")
+		result.Pruned += n
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if w.policy.MaxAge > 0 {
+		scope := repositories.PruneScope{ExcludeGrids: grids}
+		n, err := w.pruneOlderThan(ctx, now.Add(-w.policy.MaxAge), scope, "max_age")
+		result.Pruned += n
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if w.policy.TrashMaxAge > 0 {
+		n, err := w.purgeTrash(ctx, now.Add(-w.policy.TrashMaxAge))
+		result.Pruned += n
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if w.policy.MaxRows > 0 {
+		n, err := w.pruneExcessRows(ctx)
+		result.Pruned += n
+		if err != nil {
+			return result, err
+		}
+	}
+
+	oldest, ok, err := w.repo.OldestTimestampWithDeleted()
+	if err != nil {
+		return result, err
+	}
+	if ok {
+		metrics.RetentionOldestTimestamp.Set(float64(oldest.Unix()))
+	} else {
+		metrics.RetentionOldestTimestamp.Set(0)
+	}
+	return result, nil
+}
+
+func (w *RetentionWorker) pruneOlderThan(ctx context.Context, cutoff time.Time, scope repositories.PruneScope, reason string) (uint64, error) {
+	var total uint64
+	for ctx.Err() == nil {
+		n, err := w.repo.RemoveOlderThan(cutoff, scope, w.batchSize)
+		total += n
+		metrics.RetentionRowsPrunedTotal.WithLabelValues(reason).Add(float64(n))
+		if err != nil || n < uint64(w.batchSize) {
+			return total, err
+		}
+	}
+	return total, ctx.Err()
+}
+
+// purgeTrash hard-deletes activities that were moved to the trash before cutoff.
+func (w *RetentionWorker) purgeTrash(ctx context.Context, cutoff time.Time) (uint64, error) {
+	var total uint64
+	for ctx.Err() == nil {
+		n, err := w.repo.RemoveDeletedBefore(cutoff, w.batchSize)
+		total += n
+		metrics.RetentionRowsPrunedTotal.WithLabelValues("trash").Add(float64(n))
+		if err != nil || n < uint64(w.batchSize) {
+			return total, err
+		}
+	}
+	return total, ctx.Err()
+}
+
+func (w *RetentionWorker) pruneExcessRows(ctx context.Context) (uint64, error) {
+	count, err := w.repo.CountWithDeleted()
+	if err != nil || count <= uint64(w.policy.MaxRows) {
+		return 0, err
+	}
+
+	var total uint64
+	excess := count - uint64(w.policy.MaxRows)
+	for excess > 0 && ctx.Err() == nil {
+		batch := uint64(w.batchSize)
+		if excess < batch {
+			batch = excess
+		}
+		n, err := w.repo.RemoveOldest(int(batch))
+		total += n
+		excess -= n
+		metrics.RetentionRowsPrunedTotal.WithLabelValues("max_rows").Add(float64(n))
+		if err != nil || n == 0 {
+			return total, err
+		}
+	}
+	return total, ctx.Err()
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"go-rest-api/config"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"log"
+	"math"
+	"sort"
+	"time"
+)
+
+const sessionBatchSize = 1000
+
+// SessionBuilder keeps the persisted sessions up to date with new activities.
+type SessionBuilder struct {
+	repo     *repositories.SessionRepository
+	rules    repositories.SessionRules
+	interval time.Duration
+}
+
+func NewSessionBuilder(repo *repositories.SessionRepository, cfg config.Config) *SessionBuilder {
+	return &SessionBuilder{
+		repo: repo,
+		rules: repositories.SessionRules{
+			Pairs:       cfg.SessionPairs,
+			IdleTimeout: cfg.SessionIdleTimeout,
+			Lateness:    cfg.SessionLateness,
+		},
+		interval: cfg.SessionBuildInterval,
+	}
+}
+
+// Run catches up once immediately and then on every interval until ctx is done.
+// It returns straight away if no action pairs are configured.
+func (b *SessionBuilder) Run(ctx context.Context) {
+	if len(b.rules.Pairs) == 0 || b.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+	for {
+		if err := b.CatchUp(ctx, time.Now()); err != nil {
+			log.Printf("building sessions failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CatchUp folds every activity that is at least the lateness old at now into
+// sessions and then times out sessions that have been idle at now.
+func (b *SessionBuilder) CatchUp(ctx context.Context, now time.Time) error {
+	for ctx.Err() == nil {
+		n, err := b.repo.Build(b.rules, now, sessionBatchSize)
+		if err != nil {
+			return err
+		}
+		if n < sessionBatchSize {
+			_, err := b.repo.ExpireIdle(b.rules, now)
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// SessionStatsRequest selects the time range of session statistics and the
+// buckets concurrency is reported in.
+type SessionStatsRequest struct {
+	From     time.Time
+	To       time.Time
+	Bucket   BucketSize
+	Location *time.Location
+}
+
+// Validate checks the time range and the number of buckets it spans.
+func (req SessionStatsRequest) Validate() error {
+	if !req.From.Before(req.To) {
+		return fmt.Errorf("from must be before to")
+	}
+	if req.To.Sub(req.From)/req.Bucket.approximate() > MaxAggregateBuckets {
+		return fmt.Errorf("time range spans more than %d %s buckets", MaxAggregateBuckets, req.Bucket)
+	}
+	return nil
+}
+
+// ConcurrencyPoint is the highest number of sessions open at the same time
+// within one bucket.
+type ConcurrencyPoint struct {
+	BucketStart time.Time `json:"bucket_start"`
+	Peak        int       `json:"peak"`
+}
+
+// SessionStats summarises the sessions of a grid. Count, Open and the
+// durations cover sessions started in the range; durations only include
+// sessions that have ended.
+type SessionStats struct {
+	Count               int                `json:"count"`
+	Open                int                `json:"open"`
+	MeanDurationSeconds float64            `json:"mean_duration_seconds"`
+	P95DurationSeconds  float64            `json:"p95_duration_seconds"`
+	Concurrency         []ConcurrencyPoint `json:"concurrency"`
+}
+
+// ComputeSessionStats summarises sessions overlapping [From, To) as of now.
+func ComputeSessionStats(sessions []models.Session, req SessionStatsRequest, now time.Time) SessionStats {
+	if req.Location == nil {
+		req.Location = time.UTC
+	}
+	stats := SessionStats{Concurrency: []ConcurrencyPoint{}}
+
+	var durations []float64
+	type event struct {
+		at    time.Time
+		delta int
+	}
+	var events []event
+	for i := range sessions {
+		session := &sessions[i]
+		if !session.StartedAt.Before(req.From) && session.StartedAt.Before(req.To) {
+			stats.Count++
+			if session.Status == models.SessionOpen {
+				stats.Open++
+			} else {
+				durations = append(durations, session.Duration(now).Seconds())
+			}
+		}
+
+		start, end := session.StartedAt, session.EndedAt
+		if session.Status == models.SessionOpen {
+			end = now
+		}
+		if start.Before(req.From) {
+			start = req.From
+		}
+		if end.After(req.To) {
+			end = req.To
+		}
+		if end.After(start) {
+			events = append(events, event{start, 1}, event{end, -1})
+		}
+	}
+
+	if len(durations) > 0 {
+		sort.Float64s(durations)
+		var total float64
+		for _, d := range durations {
+			total += d
+		}
+		stats.MeanDurationSeconds = total / float64(len(durations))
+		rank := int(math.Ceil(0.95*float64(len(durations)))) - 1
+		stats.P95DurationSeconds = durations[rank]
+	}
+
+	// Sessions are open on [start, end), so at equal times ends come first.
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].at.Equal(events[j].at) {
+			return events[i].at.Before(events[j].at)
+		}
+		return events[i].delta < events[j].delta
+	})
+	current, next := 0, 0
+	for bucket := req.Bucket.Start(req.From, req.Location); bucket.Before(req.To); {
+		end := req.Bucket.next(bucket, req.Location)
+		for next < len(events) && !events[next].at.After(bucket) {
+			current += events[next].delta
+			next++
+		}
+		peak := current
+		for next < len(events) && events[next].at.Before(end) {
+			current += events[next].delta
+			next++
+			if current > peak {
+				peak = current
+			}
+		}
+		stats.Concurrency = append(stats.Concurrency, ConcurrencyPoint{BucketStart: bucket, Peak: peak})
+		bucket = end
+	}
+	return stats
+}
@@ -0,0 +1,412 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-rest-api/config"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"log"
+	"sync"
+	"time"
+)
+
+// MaxSLOWindowDays bounds the window of an SLO.
+const MaxSLOWindowDays = 90
+
+// SLOWindowLimit returns the longest SLO window, in days, whose rollups are
+// still kept after retention. Zero retention keeps them forever.
+func SLOWindowLimit(retention time.Duration) int {
+	if days := int(retention / (24 * time.Hour)); retention > 0 && days < MaxSLOWindowDays {
+		return days
+	}
+	return MaxSLOWindowDays
+}
+
+// SLO burn rate alert severities
+const (
+	SLOFastBurn = "fast_burn"
+	SLOSlowBurn = "slow_burn"
+)
+
+// sloBurnAlert fires while the error budget burns faster than its threshold
+// over both windows: the long one keeps it from firing on short spikes, the
+// short one resolves it soon after the burn stops.
+type sloBurnAlert struct {
+	severity string
+	long     time.Duration
+	short    time.Duration
+}
+
+var sloBurnAlerts = []sloBurnAlert{
+	{severity: SLOFastBurn, long: time.Hour, short: 5 * time.Minute},
+	{severity: SLOSlowBurn, long: 6 * time.Hour, short: 30 * time.Minute},
+}
+
+// SLOBurnWindows are the windows burn rates are reported for.
+var SLOBurnWindows = []time.Duration{5 * time.Minute, 30 * time.Minute, time.Hour, 6 * time.Hour}
+
+// ValidateSLO checks an SLO definition. Its window must fit within the stats
+// retention, or the report would silently cover fewer days than it claims.
+func ValidateSLO(slo models.SLO, retention time.Duration) error {
+	if slo.Name == "" {
+		return errors.New("name is required")
+	}
+	if slo.Endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+	switch slo.Objective {
+	case models.SLOAvailability:
+	case models.SLOLatency:
+		if slo.LatencyThresholdMs <= 0 {
+			return errors.New("latency_threshold_ms must be positive for a latency SLO")
+		}
+	default:
+		return fmt.Errorf("objective: unknown objective %q, expected %s or %s",
+			slo.Objective, models.SLOAvailability, models.SLOLatency)
+	}
+	if slo.Target <= 0 || slo.Target >= 1 {
+		return errors.New("target must be between 0 and 1, exclusive")
+	}
+	if limit := SLOWindowLimit(retention); slo.WindowDays < 1 || slo.WindowDays > limit {
+		return fmt.Errorf("window_days must be between 1 and %d, the days of stats kept", limit)
+	}
+	return nil
+}
+
+// SLOReport is the state of an SLO at EvaluatedAt. The SLO window ends with
+// the current rollup bucket; burn rate windows end with the last complete one,
+// so that the shortest is never just a bucket still filling up.
+type SLOReport struct {
+	models.SLO
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"`
+	// Total counts the requests within the window; for a latency SLO only
+	// those with a duration.
+	Total int64 `json:"total"`
+	Bad   int64 `json:"bad"`
+	// Compliance is the share of good requests, 1 without requests.
+	Compliance float64 `json:"compliance"`
+	// ErrorBudget is the number of bad requests the target allows.
+	ErrorBudget float64 `json:"error_budget"`
+	// ErrorBudgetRemaining is the share of the error budget left, negative
+	// once it is exhausted.
+	ErrorBudgetRemaining float64 `json:"error_budget_remaining"`
+	// BurnRates are keyed by window, e.g. "1h". A burn rate of 1 spends the
+	// budget exactly over the SLO window.
+	BurnRates   map[string]float64 `json:"burn_rates"`
+	FastBurn    bool               `json:"fast_burn"`
+	SlowBurn    bool               `json:"slow_burn"`
+	EvaluatedAt time.Time          `json:"evaluated_at"`
+}
+
+func sloWindowLabel(window time.Duration) string {
+	if window%time.Hour == 0 {
+		return fmt.Sprintf("%dh", window/time.Hour)
+	}
+	return fmt.Sprintf("%dm", window/time.Minute)
+}
+
+// sloBucket holds the requests of one rollup bucket that count towards an SLO.
+type sloBucket struct {
+	start time.Time
+	total int64
+	bad   int64
+}
+
+// sloBuckets counts the total and bad requests of slo per rollup bucket.
+func sloBuckets(slo models.SLO, rollups []models.StatsRollup) ([]sloBucket, error) {
+	var buckets []sloBucket
+	for _, rollup := range rollups {
+		if rollup.Endpoint != slo.Endpoint || (slo.Method != "" && rollup.Method != slo.Method) {
+			continue
+		}
+		bucket := sloBucket{start: rollup.BucketStart}
+		if slo.Objective == models.SLOLatency {
+			sketch, err := repositories.RollupLatency(rollup)
+			if err != nil {
+				return nil, err
+			}
+			bucket.total = int64(sketch.Count)
+			bucket.bad = int64(sketch.Count - sketch.CountAtMost(slo.LatencyThresholdMs))
+		} else {
+			bucket.total = rollup.Count
+			bucket.bad = rollup.Status5xx
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, nil
+}
+
+// sumSLOBuckets sums the buckets starting in [from, to).
+func sumSLOBuckets(buckets []sloBucket, from, to time.Time) (total, bad int64) {
+	for _, bucket := range buckets {
+		if !bucket.start.Before(from) && bucket.start.Before(to) {
+			total += bucket.total
+			bad += bucket.bad
+		}
+	}
+	return total, bad
+}
+
+// ComputeSLOReport evaluates slo at now from rollups, which must cover its
+// window. fastBurnRate and slowBurnRate are the alert thresholds.
+func ComputeSLOReport(slo models.SLO, rollups []models.StatsRollup, now time.Time, fastBurnRate, slowBurnRate float64) (SLOReport, error) {
+	end := now.Truncate(repositories.StatsRollupInterval).Add(repositories.StatsRollupInterval)
+	report := SLOReport{
+		SLO:                  slo,
+		WindowStart:          end.Add(-time.Duration(slo.WindowDays) * 24 * time.Hour),
+		WindowEnd:            end,
+		Compliance:           1,
+		ErrorBudgetRemaining: 1,
+		BurnRates:            make(map[string]float64, len(SLOBurnWindows)),
+		EvaluatedAt:          now,
+	}
+	buckets, err := sloBuckets(slo, rollups)
+	if err != nil {
+		return report, err
+	}
+
+	allowed := 1 - slo.Target
+	report.Total, report.Bad = sumSLOBuckets(buckets, report.WindowStart, end)
+	if report.Total > 0 {
+		report.Compliance = 1 - float64(report.Bad)/float64(report.Total)
+		report.ErrorBudget = allowed * float64(report.Total)
+		report.ErrorBudgetRemaining = 1 - float64(report.Bad)/report.ErrorBudget
+	}
+
+	complete := now.Truncate(repositories.StatsRollupInterval)
+	burnRate := func(window time.Duration) float64 {
+		total, bad := sumSLOBuckets(buckets, complete.Add(-window), complete)
+		if total == 0 {
+			return 0
+		}
+		return float64(bad) / float64(total) / allowed
+	}
+	for _, window := range SLOBurnWindows {
+		report.BurnRates[sloWindowLabel(window)] = burnRate(window)
+	}
+	for _, alert := range sloBurnAlerts {
+		threshold := slowBurnRate
+		if alert.severity == SLOFastBurn {
+			threshold = fastBurnRate
+		}
+		firing := burnRate(alert.long) > threshold && burnRate(alert.short) > threshold
+		if alert.severity == SLOFastBurn {
+			report.FastBurn = firing
+		} else {
+			report.SlowBurn = firing
+		}
+	}
+	return report, nil
+}
+
+// SLOEvaluator evaluates every SLO periodically, exports the reports as
+// metrics and tells the notifier when a burn rate alert fires or resolves.
+// Alert states live in memory, so firing alerts are notified again after a
+// restart.
+type SLOEvaluator struct {
+	repo         *repositories.SLORepository
+	stats        repositories.StatsStore
+	notifier     SLONotifier
+	interval     time.Duration
+	fastBurnRate float64
+	slowBurnRate float64
+
+	mu sync.Mutex
+	// firing holds the notified alerts by SLO name and severity.
+	firing map[string]map[string]SLOAlert
+	// pending holds the alerts whose notification failed by SLO name and
+	// severity. Retries keep their At, the time the state changed.
+	pending map[string]map[string]SLOAlert
+	// evaluated holds the SLOs exported by the last evaluation.
+	evaluated map[string]bool
+}
+
+func NewSLOEvaluator(repo *repositories.SLORepository, stats repositories.StatsStore, notifier SLONotifier, cfg config.Config) *SLOEvaluator {
+	return &SLOEvaluator{
+		repo:         repo,
+		stats:        stats,
+		notifier:     notifier,
+		interval:     cfg.SLOEvaluationInterval,
+		fastBurnRate: cfg.SLOFastBurnRate,
+		slowBurnRate: cfg.SLOSlowBurnRate,
+		firing:       make(map[string]map[string]SLOAlert),
+		pending:      make(map[string]map[string]SLOAlert),
+	}
+}
+
+// Report evaluates one SLO at now without notifying.
+func (e *SLOEvaluator) Report(slo models.SLO, now time.Time) (SLOReport, error) {
+	reports, err := e.reports([]models.SLO{slo}, now)
+	if err != nil {
+		return SLOReport{}, err
+	}
+	return reports[0], nil
+}
+
+// Reports evaluates every SLO at now without notifying.
+func (e *SLOEvaluator) Reports(now time.Time) ([]SLOReport, error) {
+	slos, err := e.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return e.reports(slos, now)
+}
+
+func (e *SLOEvaluator) reports(slos []models.SLO, now time.Time) ([]SLOReport, error) {
+	reports := make([]SLOReport, 0, len(slos))
+	if len(slos) == 0 {
+		return reports, nil
+	}
+	windowDays := 0
+	for _, slo := range slos {
+		windowDays = max(windowDays, slo.WindowDays)
+	}
+	end := now.Truncate(repositories.StatsRollupInterval).Add(repositories.StatsRollupInterval)
+	rollups, err := e.stats.GetRollups(end.Add(-time.Duration(windowDays)*24*time.Hour), end)
+	if err != nil {
+		return nil, err
+	}
+	for _, slo := range slos {
+		report, err := ComputeSLOReport(slo, rollups, now, e.fastBurnRate, e.slowBurnRate)
+		if err != nil {
+			return nil, fmt.Errorf("SLO %s: %w", slo.Name, err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// Run evaluates the SLOs every interval until ctx is done.
+func (e *SLOEvaluator) Run(ctx context.Context) {
+	if e.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := e.Evaluate(ctx, now); err != nil {
+				log.Printf("evaluating SLOs failed: %v", err)
+			}
+		}
+	}
+}
+
+// Evaluate updates the SLO metrics and notifies alerts that fired or
+// resolved since the last evaluation. The alerts of deleted SLOs resolve. A
+// notification that fails is tried again on the next evaluation.
+func (e *SLOEvaluator) Evaluate(ctx context.Context, now time.Time) error {
+	reports, err := e.Reports(now)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	current := make(map[string]bool, len(reports))
+	for _, report := range reports {
+		current[report.Name] = true
+		metrics.SLOCompliance.WithLabelValues(report.Name).Set(report.Compliance)
+		metrics.SLOErrorBudgetRemaining.WithLabelValues(report.Name).Set(report.ErrorBudgetRemaining)
+		for window, rate := range report.BurnRates {
+			metrics.SLOBurnRate.WithLabelValues(report.Name, window).Set(rate)
+		}
+
+		for _, alert := range sloBurnAlerts {
+			firing := report.FastBurn
+			threshold := e.fastBurnRate
+			if alert.severity == SLOSlowBurn {
+				firing, threshold = report.SlowBurn, e.slowBurnRate
+			}
+			if firing {
+				metrics.SLOAlertsFiring.WithLabelValues(report.Name, alert.severity).Set(1)
+			} else {
+				metrics.SLOAlertsFiring.WithLabelValues(report.Name, alert.severity).Set(0)
+			}
+
+			_, notified := e.firing[report.Name][alert.severity]
+			if firing == notified {
+				// A change that flipped back before it was notified is dropped.
+				e.setPending(report.Name, alert.severity, nil)
+				continue
+			}
+			status := models.AlertFiring
+			if !firing {
+				status = models.AlertResolved
+			}
+			e.notify(ctx, newSLOAlert(report, alert, threshold, status, now))
+		}
+	}
+
+	for name, alerts := range e.firing {
+		if current[name] {
+			continue
+		}
+		for _, alert := range alerts {
+			alert.Status = models.AlertResolved
+			alert.At = now
+			e.notify(ctx, alert)
+		}
+	}
+	for name := range e.pending {
+		if !current[name] && e.firing[name] == nil {
+			delete(e.pending, name)
+		}
+	}
+	for name := range e.evaluated {
+		if !current[name] {
+			metrics.ForgetSLO(name)
+		}
+	}
+	e.evaluated = current
+	return nil
+}
+
+// notify sends alert and records it as firing or resolved once sent. A
+// failed notification is kept pending, and when the same change is notified
+// again it keeps the At of the first attempt. The caller holds mu.
+func (e *SLOEvaluator) notify(ctx context.Context, alert SLOAlert) {
+	if pending, ok := e.pending[alert.SLO][alert.Severity]; ok && pending.Status == alert.Status {
+		alert.At = pending.At
+	}
+	if err := e.notifier.Notify(ctx, alert); err != nil {
+		log.Printf("SLO %s %s %s notification failed: %v", alert.SLO, alert.Severity, alert.Status, err)
+		e.setPending(alert.SLO, alert.Severity, &alert)
+		return
+	}
+	e.setPending(alert.SLO, alert.Severity, nil)
+	if alert.Status == models.AlertFiring {
+		if e.firing[alert.SLO] == nil {
+			e.firing[alert.SLO] = make(map[string]SLOAlert)
+		}
+		e.firing[alert.SLO][alert.Severity] = alert
+		return
+	}
+	delete(e.firing[alert.SLO], alert.Severity)
+	if len(e.firing[alert.SLO]) == 0 {
+		delete(e.firing, alert.SLO)
+	}
+}
+
+// setPending records alert as the pending notification of an SLO and
+// severity, or clears it when alert is nil. The caller holds mu.
+func (e *SLOEvaluator) setPending(slo, severity string, alert *SLOAlert) {
+	if alert == nil {
+		delete(e.pending[slo], severity)
+		if len(e.pending[slo]) == 0 {
+			delete(e.pending, slo)
+		}
+		return
+	}
+	if e.pending[slo] == nil {
+		e.pending[slo] = make(map[string]SLOAlert)
+	}
+	e.pending[slo][severity] = *alert
+}
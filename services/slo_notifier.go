@@ -0,0 +1,179 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-rest-api/config"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SLOAlert is a burn rate alert of an SLO that fired or resolved. It is the
+// JSON body posted by WebhookSLONotifier.
+type SLOAlert struct {
+	SLO       string `json:"slo"`
+	Endpoint  string `json:"endpoint"`
+	Method    string `json:"method,omitempty"`
+	Objective string `json:"objective"`
+	Severity  string `json:"severity"`
+	Status    string `json:"status"`
+	// Threshold is the burn rate both windows exceeded when the alert fired.
+	Threshold            float64   `json:"threshold"`
+	LongWindow           string    `json:"long_window"`
+	LongBurnRate         float64   `json:"long_burn_rate"`
+	ShortWindow          string    `json:"short_window"`
+	ShortBurnRate        float64   `json:"short_burn_rate"`
+	ErrorBudgetRemaining float64   `json:"error_budget_remaining"`
+	At                   time.Time `json:"at"`
+}
+
+func newSLOAlert(report SLOReport, alert sloBurnAlert, threshold float64, status string, now time.Time) SLOAlert {
+	long, short := sloWindowLabel(alert.long), sloWindowLabel(alert.short)
+	return SLOAlert{
+		SLO:                  report.Name,
+		Endpoint:             report.Endpoint,
+		Method:               report.Method,
+		Objective:            report.Objective,
+		Severity:             alert.severity,
+		Status:               status,
+		Threshold:            threshold,
+		LongWindow:           long,
+		LongBurnRate:         report.BurnRates[long],
+		ShortWindow:          short,
+		ShortBurnRate:        report.BurnRates[short],
+		ErrorBudgetRemaining: report.ErrorBudgetRemaining,
+		At:                   now,
+	}
+}
+
+// SLONotifier is told when a burn rate alert fires or resolves. An error
+// makes the evaluator notify the same change again on its next run.
+type SLONotifier interface {
+	Notify(ctx context.Context, alert SLOAlert) error
+}
+
+// NewSLONotifier logs SLO alerts and posts them to the configured webhooks.
+func NewSLONotifier(cfg config.Config) SLONotifier {
+	notifiers := MultiSLONotifier{LogSLONotifier{}}
+	if len(cfg.SLOAlertWebhooks) > 0 {
+		notifiers = append(notifiers, &WebhookSLONotifier{
+			Client: &http.Client{Timeout: cfg.AlertWebhookTimeout},
+			URLs:   cfg.SLOAlertWebhooks,
+		})
+	}
+	return notifiers
+}
+
+// MultiSLONotifier notifies all of its notifiers, each again when the
+// evaluator retries a change that one of them failed.
+type MultiSLONotifier []SLONotifier
+
+func (m MultiSLONotifier) Notify(ctx context.Context, alert SLOAlert) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LogSLONotifier writes SLO alerts to the log.
+type LogSLONotifier struct{}
+
+func (LogSLONotifier) Notify(_ context.Context, alert SLOAlert) error {
+	log.Printf("SLO %s %s alert %s: burn rate %.2f over %s, %.2f over %s (threshold %.2f), %.1f%% of error budget left",
+		alert.SLO, alert.Severity, alert.Status, alert.LongBurnRate, alert.LongWindow,
+		alert.ShortBurnRate, alert.ShortWindow, alert.Threshold, 100*alert.ErrorBudgetRemaining)
+	return nil
+}
+
+// sloDeliveryRetention is how long the URLs that accepted a notification
+// are remembered while others still fail.
+const sloDeliveryRetention = 24 * time.Hour
+
+// sloDelivery is a notification some URLs have accepted.
+type sloDelivery struct {
+	at        time.Time
+	delivered map[string]bool
+}
+
+// WebhookSLONotifier posts SLO alerts as JSON to every URL, with an
+// Idempotency-Key header so receivers can drop repeated notifications. The
+// key identifies the state change by SLO, severity, status and At. When
+// some URLs fail, retries of the change only go to those.
+type WebhookSLONotifier struct {
+	Client *http.Client
+	URLs   []string
+
+	mu      sync.Mutex
+	partial map[string]*sloDelivery
+}
+
+func (n *WebhookSLONotifier) Notify(ctx context.Context, alert SLOAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("slo-%s-%s-%s-%d", alert.SLO, alert.Severity, alert.Status, alert.At.Unix())
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for k, delivery := range n.partial {
+		if time.Since(delivery.at) > sloDeliveryRetention {
+			delete(n.partial, k)
+		}
+	}
+	delivery := n.partial[key]
+	if delivery == nil {
+		delivery = &sloDelivery{at: time.Now(), delivered: make(map[string]bool)}
+	}
+
+	var errs []error
+	for _, url := range n.URLs {
+		if delivery.delivered[url] {
+			continue
+		}
+		if err := n.send(ctx, url, key, body); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", url, err))
+			continue
+		}
+		delivery.delivered[url] = true
+	}
+
+	if len(errs) == 0 {
+		delete(n.partial, key)
+		return nil
+	}
+	if n.partial == nil {
+		n.partial = make(map[string]*sloDelivery)
+	}
+	n.partial[key] = delivery
+	return errors.Join(errs...)
+}
+
+func (n *WebhookSLONotifier) send(ctx context.Context, url, key string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"encoding/json"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"go-rest-api/utils"
+	"math"
+	"testing"
+	"time"
+)
+
+// sloRollups returns a rollup of endpoint /a for every bucket in [from, to).
+func sloRollups(from, to time.Time, count, bad int64) []models.StatsRollup {
+	var rollups []models.StatsRollup
+	for start := from; start.Before(to); start = start.Add(repositories.StatsRollupInterval) {
+		rollups = append(rollups, models.StatsRollup{Endpoint: "/a", Method: "GET", BucketStart: start, Count: count, Status5xx: bad})
+	}
+	return rollups
+}
+
+func TestComputeSLOReport(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 2, 0, 0, time.UTC)
+	complete := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	windowStart := complete.Add(repositories.StatsRollupInterval - 24*time.Hour)
+	join := func(parts ...[]models.StatsRollup) []models.StatsRollup {
+		var rollups []models.StatsRollup
+		for _, part := range parts {
+			rollups = append(rollups, part...)
+		}
+		return rollups
+	}
+	latency := func(start time.Time, values ...float64) models.StatsRollup {
+		sketch := utils.NewDDSketch()
+		for _, value := range values {
+			sketch.Add(value)
+		}
+		data, _ := json.Marshal(sketch)
+		return models.StatsRollup{Endpoint: "/a", Method: "GET", BucketStart: start, Count: int64(len(values)) + 1, Latency: string(data)}
+	}
+	availability := models.SLO{Endpoint: "/a", Objective: models.SLOAvailability, Target: 0.99, WindowDays: 1}
+
+	tests := []struct {
+		name      string
+		slo       models.SLO
+		rollups   []models.StatsRollup
+		total     int64
+		bad       int64
+		remaining float64
+		burnRates map[string]float64
+		fast      bool
+		slow      bool
+	}{
+		{
+			name: "no requests", slo: availability,
+			remaining: 1, burnRates: map[string]float64{"5m": 0, "30m": 0, "1h": 0, "6h": 0},
+		},
+		{
+			name: "steady within budget", slo: availability,
+			rollups: sloRollups(windowStart, complete.Add(repositories.StatsRollupInterval), 200, 1),
+			total:   288 * 200, bad: 288, remaining: 0.5,
+			burnRates: map[string]float64{"5m": 0.5, "30m": 0.5, "1h": 0.5, "6h": 0.5},
+		},
+		{
+			name: "budget exhausted", slo: availability,
+			rollups: sloRollups(windowStart, complete, 100, 3),
+			total:   287 * 100, bad: 287 * 3, remaining: -2,
+			burnRates: map[string]float64{"5m": 3, "6h": 3},
+		},
+		{
+			// 12 buckets of 20% errors burn 20x over the last hour but only
+			// 20/6 = 3.3x over six hours.
+			name: "fast burn", slo: availability,
+			rollups: join(sloRollups(windowStart, complete.Add(-time.Hour), 100, 0), sloRollups(complete.Add(-time.Hour), complete, 100, 20)),
+			total:   287 * 100, bad: 12 * 20, remaining: 1 - 240.0/287,
+			burnRates: map[string]float64{"5m": 20, "1h": 20, "6h": 20.0 / 6},
+			fast:      true,
+		},
+		{
+			// One bad bucket is not enough for either long window.
+			name: "short spike", slo: availability,
+			rollups: join(sloRollups(windowStart, complete.Add(-repositories.StatsRollupInterval), 100, 0),
+				sloRollups(complete.Add(-repositories.StatsRollupInterval), complete, 100, 50)),
+			total: 287 * 100, bad: 50, remaining: 1 - 50.0/287,
+			burnRates: map[string]float64{"5m": 50, "30m": 50.0 / 6, "1h": 50.0 / 12, "6h": 50.0 / 72},
+		},
+		{
+			// Errors two hours ago keep the six hour rate up, but the short
+			// windows show the burn has stopped.
+			name: "burn stopped", slo: availability,
+			rollups: join(sloRollups(windowStart, complete.Add(-2*time.Hour), 100, 0),
+				sloRollups(complete.Add(-2*time.Hour), complete.Add(-time.Hour), 100, 50),
+				sloRollups(complete.Add(-time.Hour), complete, 100, 0)),
+			total: 287 * 100, bad: 12 * 50, remaining: 1 - 600.0/287,
+			burnRates: map[string]float64{"5m": 0, "30m": 0, "1h": 0, "6h": 600.0 / 72},
+		},
+		{
+			name: "slow burn", slo: availability,
+			rollups: join(sloRollups(windowStart, complete.Add(-6*time.Hour), 100, 0), sloRollups(complete.Add(-6*time.Hour), complete, 100, 8)),
+			total:   287 * 100, bad: 72 * 8, remaining: 1 - 576.0/287,
+			burnRates: map[string]float64{"30m": 8, "1h": 8, "6h": 8},
+			slow:      true,
+		},
+		{
+			// The bucket still filling up counts towards the window but not
+			// towards any burn rate.
+			name: "current bucket", slo: availability,
+			rollups: join(sloRollups(windowStart, complete, 100, 0), sloRollups(complete, complete.Add(repositories.StatsRollupInterval), 100, 100)),
+			total:   288 * 100, bad: 100, remaining: 1 - 100.0/288,
+			burnRates: map[string]float64{"5m": 0, "1h": 0},
+		},
+		{
+			// Only the bucket starting exactly at the window start is counted.
+			name: "window bounds", slo: availability,
+			rollups: sloRollups(windowStart.Add(-repositories.StatsRollupInterval), windowStart.Add(repositories.StatsRollupInterval), 100, 1),
+			total:   100, bad: 1, remaining: 0,
+		},
+		{
+			name: "other endpoints and methods", slo: models.SLO{Endpoint: "/a", Method: "POST", Objective: models.SLOAvailability, Target: 0.9, WindowDays: 1},
+			rollups: []models.StatsRollup{
+				{Endpoint: "/a", Method: "GET", BucketStart: complete.Add(-5 * time.Minute), Count: 10, Status5xx: 10},
+				{Endpoint: "/b", Method: "POST", BucketStart: complete.Add(-5 * time.Minute), Count: 10, Status5xx: 10},
+				{Endpoint: "/a", Method: "POST", BucketStart: complete.Add(-5 * time.Minute), Count: 10, Status5xx: 1},
+			},
+			total: 10, bad: 1, remaining: 0, burnRates: map[string]float64{"5m": 1},
+		},
+		{
+			// Requests without a duration are not in the sketch and do not count.
+			name: "latency", slo: models.SLO{Endpoint: "/a", Objective: models.SLOLatency, Target: 0.5, LatencyThresholdMs: 100, WindowDays: 1},
+			rollups: []models.StatsRollup{latency(complete.Add(-5*time.Minute), 10, 50, 99, 250)},
+			total:   4, bad: 1, remaining: 0.5, burnRates: map[string]float64{"5m": 0.5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := ComputeSLOReport(tt.slo, tt.rollups, now, 14.4, 6)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !report.WindowStart.Equal(windowStart) || !report.WindowEnd.Equal(complete.Add(repositories.StatsRollupInterval)) {
+				t.Errorf("window = %s to %s", report.WindowStart, report.WindowEnd)
+			}
+			if report.Total != tt.total || report.Bad != tt.bad {
+				t.Errorf("total, bad = %d, %d, want %d, %d", report.Total, report.Bad, tt.total, tt.bad)
+			}
+			compliance := 1.0
+			if tt.total > 0 {
+				compliance = 1 - float64(tt.bad)/float64(tt.total)
+			}
+			if !approxEqual(report.Compliance, compliance) || !approxEqual(report.ErrorBudgetRemaining, tt.remaining) {
+				t.Errorf("compliance, budget remaining = %v, %v, want %v, %v",
+					report.Compliance, report.ErrorBudgetRemaining, compliance, tt.remaining)
+			}
+			if len(report.BurnRates) != len(SLOBurnWindows) {
+				t.Errorf("burn rates = %v", report.BurnRates)
+			}
+			for window, want := range tt.burnRates {
+				if got := report.BurnRates[window]; !approxEqual(got, want) {
+					t.Errorf("%s burn rate = %v, want %v", window, got, want)
+				}
+			}
+			if report.FastBurn != tt.fast || report.SlowBurn != tt.slow {
+				t.Errorf("fast, slow burn = %v, %v, want %v, %v", report.FastBurn, report.SlowBurn, tt.fast, tt.slow)
+			}
+		})
+	}
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+}
+
+func TestValidateSLO(t *testing.T) {
+	valid := models.SLO{Name: "api", Endpoint: "/a", Objective: models.SLOAvailability, Target: 0.999, WindowDays: 30}
+	tests := []struct {
+		name      string
+		change    func(*models.SLO)
+		retention time.Duration
+		wantErr   bool
+	}{
+		{"valid", func(*models.SLO) {}, 0, false},
+		{"no name", func(s *models.SLO) { s.Name = "" }, 0, true},
+		{"no endpoint", func(s *models.SLO) { s.Endpoint = "" }, 0, true},
+		{"unknown objective", func(s *models.SLO) { s.Objective = "uptime" }, 0, true},
+		{"latency without threshold", func(s *models.SLO) { s.Objective = models.SLOLatency }, 0, true},
+		{"latency", func(s *models.SLO) { s.Objective, s.LatencyThresholdMs = models.SLOLatency, 200 }, 0, false},
+		{"target of one", func(s *models.SLO) { s.Target = 1 }, 0, true},
+		{"target of zero", func(s *models.SLO) { s.Target = 0 }, 0, true},
+		{"no window", func(s *models.SLO) { s.WindowDays = 0 }, 0, true},
+		{"longest window", func(s *models.SLO) { s.WindowDays = MaxSLOWindowDays }, 0, false},
+		{"window too long", func(s *models.SLO) { s.WindowDays = MaxSLOWindowDays + 1 }, 0, true},
+		{"window within retention", func(*models.SLO) {}, 30 * 24 * time.Hour, false},
+		{"window beyond retention", func(*models.SLO) {}, 30*24*time.Hour - time.Minute, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slo := valid
+			tt.change(&slo)
+			if err := ValidateSLO(slo, tt.retention); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSLO() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSLOWindowLimit(t *testing.T) {
+	tests := []struct {
+		retention time.Duration
+		want      int
+	}{
+		{0, MaxSLOWindowDays},
+		{36 * time.Hour, 1},
+		{7 * 24 * time.Hour, 7},
+		{365 * 24 * time.Hour, MaxSLOWindowDays},
+	}
+	for _, tt := range tests {
+		if got := SLOWindowLimit(tt.retention); got != tt.want {
+			t.Errorf("SLOWindowLimit(%s) = %d, want %d", tt.retention, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"fmt"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"go-rest-api/utils"
+	"sort"
+	"time"
+)
+
+// MaxStatsSummaryWindow bounds the time window of a stats summary.
+const MaxStatsSummaryWindow = 90 * 24 * time.Hour
+
+// StatsSummaryRequest selects the window of a stats summary and optionally
+// one endpoint and method. From and To are aligned to rollup buckets.
+type StatsSummaryRequest struct {
+	From     time.Time
+	To       time.Time
+	Endpoint string
+	Method   string
+}
+
+// Validate checks the time window. It may not be longer than the stats
+// retention, zero keeping them forever, since older rollups are gone.
+func (req StatsSummaryRequest) Validate(retention time.Duration) error {
+	if !req.From.Before(req.To) {
+		return fmt.Errorf("from must be before to")
+	}
+	limit := MaxStatsSummaryWindow
+	if retention > 0 && retention < limit {
+		limit = retention
+	}
+	if req.To.Sub(req.From) > limit {
+		return fmt.Errorf("time window is longer than %s", limit)
+	}
+	return nil
+}
+
+// Align widens the window to whole rollup buckets.
+func (req StatsSummaryRequest) Align() StatsSummaryRequest {
+	req.From = req.From.Truncate(repositories.StatsRollupInterval)
+	if to := req.To.Truncate(repositories.StatsRollupInterval); to.Before(req.To) {
+		req.To = to.Add(repositories.StatsRollupInterval)
+	}
+	return req
+}
+
+// EndpointSummary summarises the requests to one endpoint and method.
+// Latency percentiles only cover entries with a duration.
+type EndpointSummary struct {
+	Endpoint     string  `json:"endpoint"`
+	Method       string  `json:"method"`
+	Count        int64   `json:"count"`
+	P50LatencyMs float64 `json:"p50_latency_ms"`
+	P90LatencyMs float64 `json:"p90_latency_ms"`
+	P99LatencyMs float64 `json:"p99_latency_ms"`
+	Status2xx    int64   `json:"status_2xx"`
+	Status3xx    int64   `json:"status_3xx"`
+	Status4xx    int64   `json:"status_4xx"`
+	Status5xx    int64   `json:"status_5xx"`
+	// ErrorRate is the share of requests that answered with a 5xx status.
+	ErrorRate float64 `json:"error_rate"`
+}
+
+// SummarizeStats merges the rollups matching req into one summary per
+// endpoint and method, ordered by endpoint and method.
+func SummarizeStats(rollups []models.StatsRollup, req StatsSummaryRequest) ([]EndpointSummary, error) {
+	type key struct{ endpoint, method string }
+	summaries := make(map[key]*EndpointSummary)
+	sketches := make(map[key]*utils.DDSketch)
+	for _, rollup := range rollups {
+		if (req.Endpoint != "" && rollup.Endpoint != req.Endpoint) || (req.Method != "" && rollup.Method != req.Method) {
+			continue
+		}
+		if rollup.BucketStart.Before(req.From) || !rollup.BucketStart.Before(req.To) {
+			continue
+		}
+		k := key{rollup.Endpoint, rollup.Method}
+		summary, ok := summaries[k]
+		if !ok {
+			summary = &EndpointSummary{Endpoint: rollup.Endpoint, Method: rollup.Method}
+			summaries[k] = summary
+			sketches[k] = utils.NewDDSketch()
+		}
+		summary.Count += rollup.Count
+		summary.Status2xx += rollup.Status2xx
+		summary.Status3xx += rollup.Status3xx
+		summary.Status4xx += rollup.Status4xx
+		summary.Status5xx += rollup.Status5xx
+
+		sketch, err := repositories.RollupLatency(rollup)
+		if err != nil {
+			return nil, err
+		}
+		sketches[k].Merge(sketch)
+	}
+
+	result := make([]EndpointSummary, 0, len(summaries))
+	for k, summary := range summaries {
+		sketch := sketches[k]
+		summary.P50LatencyMs = sketch.Quantile(0.5)
+		summary.P90LatencyMs = sketch.Quantile(0.9)
+		summary.P99LatencyMs = sketch.Quantile(0.99)
+		if summary.Count > 0 {
+			summary.ErrorRate = float64(summary.Status5xx) / float64(summary.Count)
+		}
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Endpoint != result[j].Endpoint {
+			return result[i].Endpoint < result[j].Endpoint
+		}
+		return result[i].Method < result[j].Method
+	})
+	return result, nil
+}
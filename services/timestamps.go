@@ -0,0 +1,144 @@
+package services
+
+import (
+	"fmt"
+	"go-rest-api/config"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TimestampPolicy decides which client supplied event times are accepted.
+type TimestampPolicy struct {
+	MaxPast   time.Duration
+	MaxFuture time.Duration
+	Reject    bool
+}
+
+func NewTimestampPolicy(cfg config.Config) TimestampPolicy {
+	return TimestampPolicy{
+		MaxPast:   cfg.TimestampMaxPast,
+		MaxFuture: cfg.TimestampMaxFuture,
+		Reject:    cfg.TimestampPolicy == config.TimestampPolicyReject,
+	}
+}
+
+// Apply stamps ReceivedAt and keeps the device's own Timestamp when it has one.
+// Out-of-bounds timestamps return an error in reject mode and set
+// TimestampFlagged otherwise. It reports whether the client supplied a time.
+func (p TimestampPolicy) Apply(activity *models.DeviceActivity, receivedAt time.Time) (bool, error) {
+	activity.ReceivedAt = receivedAt
+	activity.TimestampFlagged = false
+	if activity.Timestamp.IsZero() {
+		activity.Timestamp = receivedAt
+		return false, nil
+	}
+
+	var problem string
+	switch {
+	case p.MaxPast > 0 && activity.Timestamp.Before(receivedAt.Add(-p.MaxPast)):
+		problem = fmt.Sprintf("more than %s in the past", p.MaxPast)
+	case p.MaxFuture > 0 && activity.Timestamp.After(receivedAt.Add(p.MaxFuture)):
+		problem = fmt.Sprintf("more than %s in the future", p.MaxFuture)
+	}
+	if problem != "" {
+		if p.Reject {
+			return true, fmt.Errorf("Timestamp %s is %s", activity.Timestamp.Format(time.RFC3339), problem)
+		}
+		activity.TimestampFlagged = true
+	}
+	return true, nil
+}
+
+const clockOffsetSamples = 32
+
+// ClockOffset is the current clock offset estimate for one device.
+type ClockOffset struct {
+	Device        string    `json:"device"`
+	OffsetSeconds float64   `json:"offset_seconds"`
+	Samples       int       `json:"samples"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ClockOffsetTracker estimates how far each device clock is behind the server.
+// Each sample is ReceivedAt - Timestamp, which also includes network latency
+// and any time the event spent buffered on the device. Those delays only ever
+// add to the sample, so the minimum over recent samples is used as the estimate.
+type ClockOffsetTracker struct {
+	mu      sync.RWMutex
+	devices map[string]*deviceOffsets
+}
+
+type deviceOffsets struct {
+	samples   []time.Duration
+	next      int
+	estimate  time.Duration
+	updatedAt time.Time
+}
+
+func NewClockOffsetTracker() *ClockOffsetTracker {
+	return &ClockOffsetTracker{devices: make(map[string]*deviceOffsets)}
+}
+
+// Observe records a sample for the activity's device.
+func (t *ClockOffsetTracker) Observe(activity models.DeviceActivity) {
+	sample := activity.ReceivedAt.Sub(activity.Timestamp)
+
+	t.mu.Lock()
+	offsets, ok := t.devices[activity.DeviceName]
+	if !ok {
+		offsets = &deviceOffsets{samples: make([]time.Duration, 0, clockOffsetSamples)}
+		t.devices[activity.DeviceName] = offsets
+	}
+	if len(offsets.samples) < clockOffsetSamples {
+		offsets.samples = append(offsets.samples, sample)
+	} else {
+		offsets.samples[offsets.next] = sample
+		offsets.next = (offsets.next + 1) % clockOffsetSamples
+	}
+	estimate := offsets.samples[0]
+	for _, s := range offsets.samples[1:] {
+		if s < estimate {
+			estimate = s
+		}
+	}
+	offsets.estimate = estimate
+	offsets.updatedAt = activity.ReceivedAt
+	t.mu.Unlock()
+
+	metrics.DeviceClockOffset.WithLabelValues(activity.DeviceName).Set(estimate.Seconds())
+}
+
+// Get returns the estimate for one device.
+func (t *ClockOffsetTracker) Get(device string) (ClockOffset, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	offsets, ok := t.devices[device]
+	if !ok {
+		return ClockOffset{}, false
+	}
+	return offsets.snapshot(device), true
+}
+
+// All returns the estimates for every device, sorted by device name.
+func (t *ClockOffsetTracker) All() []ClockOffset {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	result := make([]ClockOffset, 0, len(t.devices))
+	for device, offsets := range t.devices {
+		result = append(result, offsets.snapshot(device))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Device < result[j].Device })
+	return result
+}
+
+func (o *deviceOffsets) snapshot(device string) ClockOffset {
+	return ClockOffset{
+		Device:        device,
+		OffsetSeconds: o.estimate.Seconds(),
+		Samples:       len(o.samples),
+		UpdatedAt:     o.updatedAt,
+	}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"go-rest-api/models"
+	"testing"
+	"time"
+)
+
+func TestTimestampPolicyApply(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	bounded := TimestampPolicy{MaxPast: time.Hour, MaxFuture: time.Minute}
+	rejecting := TimestampPolicy{MaxPast: time.Hour, MaxFuture: time.Minute, Reject: true}
+	tests := []struct {
+		name       string
+		policy     TimestampPolicy
+		timestamp  time.Time
+		clientTime bool
+		flagged    bool
+		wantErr    bool
+	}{
+		{"no client time", bounded, time.Time{}, false, false, false},
+		{"within bounds", bounded, now.Add(-30 * time.Minute), true, false, false},
+		{"exactly max past", bounded, now.Add(-time.Hour), true, false, false},
+		{"exactly max future", bounded, now.Add(time.Minute), true, false, false},
+		{"too old is flagged", bounded, now.Add(-time.Hour - time.Second), true, true, false},
+		{"too new is flagged", bounded, now.Add(time.Minute + time.Second), true, true, false},
+		{"too old is rejected", rejecting, now.Add(-2 * time.Hour), true, false, true},
+		{"too new is rejected", rejecting, now.Add(time.Hour), true, false, true},
+		{"unbounded", TimestampPolicy{Reject: true}, now.AddDate(-10, 0, 0), true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity := models.DeviceActivity{Timestamp: tt.timestamp, TimestampFlagged: true}
+			clientTime, err := tt.policy.Apply(&activity, now)
+			if clientTime != tt.clientTime || (err != nil) != tt.wantErr {
+				t.Fatalf("Apply() = %v, %v", clientTime, err)
+			}
+			if activity.TimestampFlagged != tt.flagged {
+				t.Errorf("TimestampFlagged = %v, want %v", activity.TimestampFlagged, tt.flagged)
+			}
+			if !activity.ReceivedAt.Equal(now) {
+				t.Errorf("ReceivedAt = %v, want %v", activity.ReceivedAt, now)
+			}
+			want := tt.timestamp
+			if want.IsZero() {
+				want = now
+			}
+			if !activity.Timestamp.Equal(want) {
+				t.Errorf("Timestamp = %v, want %v", activity.Timestamp, want)
+			}
+		})
+	}
+}
+
+func TestClockOffsetTracker(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	sample := func(device string, offset time.Duration, at time.Time) models.DeviceActivity {
+		return models.DeviceActivity{DeviceName: device, Timestamp: at.Add(-offset), ReceivedAt: at}
+	}
+	tests := []struct {
+		name    string
+		offsets []time.Duration
+		want    time.Duration
+		samples int
+	}{
+		{"single sample", []time.Duration{3 * time.Second}, 3 * time.Second, 1},
+		{"minimum of samples", []time.Duration{5 * time.Second, 2 * time.Second, 9 * time.Second}, 2 * time.Second, 3},
+		{"clock ahead", []time.Duration{-4 * time.Second, -1 * time.Second}, -4 * time.Second, 2},
+		{
+			// The 2s sample is pushed out of the window by 32 later samples.
+			"oldest sample expires",
+			append([]time.Duration{2 * time.Second}, repeat(7*time.Second, clockOffsetSamples)...),
+			7 * time.Second, clockOffsetSamples,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewClockOffsetTracker()
+			for i, offset := range tt.offsets {
+				tracker.Observe(sample("d1", offset, now.Add(time.Duration(i)*time.Minute)))
+			}
+			got, ok := tracker.Get("d1")
+			if !ok {
+				t.Fatal("no estimate for d1")
+			}
+			if got.OffsetSeconds != tt.want.Seconds() || got.Samples != tt.samples {
+				t.Errorf("Get() = %+v, want offset %v over %d samples", got, tt.want, tt.samples)
+			}
+			last := now.Add(time.Duration(len(tt.offsets)-1) * time.Minute)
+			if !got.UpdatedAt.Equal(last) {
+				t.Errorf("UpdatedAt = %v, want %v", got.UpdatedAt, last)
+			}
+		})
+	}
+
+	tracker := NewClockOffsetTracker()
+	tracker.Observe(sample("b", time.Second, now))
+	tracker.Observe(sample("a", time.Second, now))
+	if _, ok := tracker.Get("c"); ok {
+		t.Error("Get returned an estimate for an unseen device")
+	}
+	if all := tracker.All(); len(all) != 2 || all[0].Device != "a" || all[1].Device != "b" {
+		t.Errorf("All() = %+v", all)
+	}
+}
+
+func repeat(d time.Duration, n int) []time.Duration {
+	result := make([]time.Duration, n)
+	for i := range result {
+		result[i] = d
+	}
+	return result
+}
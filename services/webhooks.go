@@ -0,0 +1,506 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-rest-api/config"
+	"go-rest-api/metrics"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Headers sent with every webhook request.
+const (
+	WebhookIdHeader        = "X-Webhook-Id"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// WebhookPing is the event sent by Test.
+const WebhookPing = "ping"
+
+const (
+	webhookBatchSize     = 500
+	webhookPruneInterval = 10 * time.Minute
+)
+
+// WebhookEvent is the JSON body posted to webhook subscriptions.
+type WebhookEvent struct {
+	// Id identifies the event for one subscription and stays the same across
+	// retries, so receivers can drop duplicates.
+	Id           string          `json:"id"`
+	Event        string          `json:"event"`
+	Subscription string          `json:"subscription"`
+	Sequence     uint64          `json:"sequence"`
+	OccurredAt   time.Time       `json:"occurred_at"`
+	Activity     json.RawMessage `json:"activity,omitempty" swaggertype:"object"`
+}
+
+// SignWebhook returns the signature sent in X-Webhook-Signature: "sha256="
+// followed by the hex HMAC-SHA256, keyed with secret, of the Unix timestamp
+// from X-Webhook-Timestamp, a dot and the raw body.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// compiledWebhook is a WebhookSubscription with its filter decoded.
+type compiledWebhook struct {
+	subscription models.WebhookSubscription
+	filter       repositories.ActivityFilter
+}
+
+// ValidateWebhookSubscription checks that a subscription can be dispatched.
+// Invalid filters are reported as *repositories.FilterError.
+func ValidateWebhookSubscription(subscription models.WebhookSubscription) error {
+	_, err := compileWebhook(subscription)
+	return err
+}
+
+func compileWebhook(subscription models.WebhookSubscription) (*compiledWebhook, error) {
+	compiled := &compiledWebhook{subscription: subscription}
+	if subscription.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	u, err := url.Parse(subscription.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("url: %q is not an absolute http(s) URL", subscription.URL)
+	}
+	if subscription.Secret == "" {
+		return nil, errors.New("secret is required")
+	}
+
+	events := subscription.GetEvents()
+	if len(events) == 0 {
+		return nil, errors.New("events must not be empty")
+	}
+	for _, event := range events {
+		if event != models.WebhookActivityCreated && event != models.WebhookActivityDeleted {
+			return nil, fmt.Errorf("events: unknown event %q, expected %s or %s",
+				event, models.WebhookActivityCreated, models.WebhookActivityDeleted)
+		}
+	}
+
+	if subscription.Filter != "" {
+		if err := json.Unmarshal([]byte(subscription.Filter), &compiled.filter); err != nil {
+			return nil, &repositories.FilterError{Clause: "filter", Message: err.Error()}
+		}
+		if _, err := compiled.filter.Condition(); err != nil {
+			return nil, err
+		}
+	}
+	return compiled, nil
+}
+
+// webhookEvent returns the webhook event a change triggers, or "" if none.
+func webhookEvent(change models.ActivityChange, activity models.DeviceActivity) string {
+	switch change.Operation {
+	case models.ChangeCreated:
+		return models.WebhookActivityCreated
+	case models.ChangeDeleted:
+		return models.WebhookActivityDeleted
+	case models.ChangePurged:
+		// Purging an activity from the trash was announced when it was deleted.
+		if !activity.IsDeleted() {
+			return models.WebhookActivityDeleted
+		}
+	}
+	return ""
+}
+
+// WebhookDispatcher fans activity changes out into the webhook outbox and
+// delivers it. Every subscription has its own delivery worker, so one slow
+// receiver does not delay the others; a delivery that fails is retried with
+// exponential backoff while later events are still delivered, so receivers
+// should order events by sequence.
+type WebhookDispatcher struct {
+	repo        *repositories.WebhookRepository
+	changelog   *repositories.ChangelogRepository
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	interval    time.Duration
+	retention   time.Duration
+}
+
+// NewWebhookDispatcher creates a dispatcher that sends with client.
+func NewWebhookDispatcher(repo *repositories.WebhookRepository, changelog *repositories.ChangelogRepository, client *http.Client, cfg config.Config) *WebhookDispatcher {
+	maxAttempts := cfg.WebhookMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+	return &WebhookDispatcher{
+		repo:        repo,
+		changelog:   changelog,
+		client:      client,
+		maxAttempts: maxAttempts,
+		backoff:     cfg.WebhookBackoff,
+		maxBackoff:  cfg.WebhookMaxBackoff,
+		interval:    cfg.WebhookPollInterval,
+		retention:   cfg.WebhookDeliveryRetention,
+	}
+}
+
+// Dispatch adds a delivery to the outbox for every subscription matching one
+// of the next batch of changes and returns how many changes were processed.
+// Callers repeat it until that is fewer than a batch.
+func (d *WebhookDispatcher) Dispatch() (int, error) {
+	after, err := d.repo.LastDispatched()
+	if err != nil {
+		return 0, err
+	}
+	changes, err := d.changelog.GetAfter(after, webhookBatchSize)
+	if err != nil || len(changes) == 0 {
+		return 0, err
+	}
+	subscriptions, err := d.repo.GetSubscriptions()
+	if err != nil {
+		return 0, err
+	}
+
+	var webhooks []*compiledWebhook
+	for _, subscription := range subscriptions {
+		if !subscription.Enabled {
+			continue
+		}
+		compiled, err := compileWebhook(subscription)
+		if err != nil {
+			log.Printf("skipping invalid webhook subscription %s: %v", subscription.Name, err)
+			continue
+		}
+		webhooks = append(webhooks, compiled)
+	}
+
+	var deliveries []*models.WebhookDelivery
+	for _, change := range changes {
+		if len(webhooks) == 0 {
+			break
+		}
+		var activity models.DeviceActivity
+		if err := json.Unmarshal([]byte(change.Activity), &activity); err != nil {
+			log.Printf("skipping unreadable change %d for webhooks: %v", change.Id, err)
+			continue
+		}
+		event := webhookEvent(change, activity)
+		if event == "" {
+			continue
+		}
+		target, known, err := d.filterTarget(change, activity)
+		if err != nil {
+			return 0, err
+		}
+		for _, webhook := range webhooks {
+			subscription := webhook.subscription
+			if change.Id <= subscription.StartSequence || !subscription.Subscribes(event) {
+				continue
+			}
+			if known && !webhook.filter.Matches(target) || !known && !webhook.filter.IsEmpty() {
+				continue
+			}
+			payload, err := json.Marshal(WebhookEvent{
+				Id:           fmt.Sprintf("%d-%d", subscription.Id, change.Id),
+				Event:        event,
+				Subscription: subscription.Name,
+				Sequence:     change.Id,
+				OccurredAt:   change.ChangedAt,
+				Activity:     json.RawMessage(change.Activity),
+			})
+			if err != nil {
+				return 0, err
+			}
+			deliveries = append(deliveries, &models.WebhookDelivery{
+				SubscriptionId:   subscription.Id,
+				SubscriptionName: subscription.Name,
+				Event:            event,
+				Sequence:         change.Id,
+				ActivityId:       change.ActivityId,
+				Payload:          string(payload),
+			})
+		}
+	}
+
+	if err := d.repo.Enqueue(deliveries, changes[len(changes)-1].Id); err != nil {
+		return 0, err
+	}
+	return len(changes), nil
+}
+
+// filterTarget returns the activity subscription filters are matched
+// against. Purged changes only hold the activity's identity, so they are
+// matched against its newest change still in the changelog; when none is
+// left, known is false and only subscriptions without a filter match.
+func (d *WebhookDispatcher) filterTarget(change models.ActivityChange, activity models.DeviceActivity) (target models.DeviceActivity, known bool, err error) {
+	if change.Operation != models.ChangePurged {
+		return activity, true, nil
+	}
+	snapshot, err := d.changelog.LastSnapshot(change.ActivityId, change.Id)
+	if err != nil || snapshot == nil {
+		return target, false, err
+	}
+	if err := json.Unmarshal([]byte(snapshot.Activity), &target); err != nil {
+		log.Printf("ignoring unreadable change %d as snapshot of purged activity %d: %v", snapshot.Id, change.ActivityId, err)
+		return target, false, nil
+	}
+	return target, true, nil
+}
+
+// Deliver attempts up to one batch of the subscription's deliveries that
+// are due at now, in order and once each, and returns how many were attempted.
+func (d *WebhookDispatcher) Deliver(ctx context.Context, subscription models.WebhookSubscription, now time.Time) (int, error) {
+	due, err := d.repo.GetDue(subscription.Id, now, webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	attempted := 0
+	for i := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		d.attempt(ctx, subscription, &due[i])
+		attempted++
+		if err := d.repo.UpdateDelivery(&due[i]); err != nil {
+			return attempted, err
+		}
+	}
+	return attempted, nil
+}
+
+// attempt sends delivery once and records the outcome on it.
+func (d *WebhookDispatcher) attempt(ctx context.Context, subscription models.WebhookSubscription, delivery *models.WebhookDelivery) {
+	start := time.Now()
+	id := fmt.Sprintf("%d-%d", subscription.Id, delivery.Sequence)
+	status, err := d.send(ctx, subscription, id, delivery.Event, []byte(delivery.Payload))
+	metrics.WebhookDeliveryDuration.WithLabelValues(subscription.Name).Observe(time.Since(start).Seconds())
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = start
+	delivery.LastStatusCode = status
+	if err == nil {
+		delivery.Status = models.DeliveryDelivered
+		delivery.LastError = ""
+		delivery.FinishedAt = time.Now()
+		metrics.WebhookDeliveriesTotal.WithLabelValues(subscription.Name, "delivered").Inc()
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= d.maxAttempts {
+		delivery.Status = models.DeliveryDead
+		delivery.FinishedAt = time.Now()
+		metrics.WebhookDeliveriesTotal.WithLabelValues(subscription.Name, "dead").Inc()
+		log.Printf("webhook delivery %d to %s dead after %d attempts: %v", delivery.Id, subscription.Name, delivery.Attempts, err)
+		return
+	}
+	delivery.NextAttemptAt = start.Add(d.retryDelay(delivery.Attempts))
+	metrics.WebhookDeliveriesTotal.WithLabelValues(subscription.Name, "retried").Inc()
+}
+
+// retryDelay is the wait after the given number of failed attempts.
+func (d *WebhookDispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.backoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if d.maxBackoff > 0 && delay >= d.maxBackoff {
+			return d.maxBackoff
+		}
+	}
+	return delay
+}
+
+// send posts one signed event and returns the response status, or 0 if
+// there was no response. Any 2xx response is success.
+func (d *WebhookDispatcher) send(ctx context.Context, subscription models.WebhookSubscription, id, event string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", id)
+	req.Header.Set(WebhookIdHeader, id)
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(subscription.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Test sends a signed ping event to subscription once, without retries or
+// the outbox, and returns the response status.
+func (d *WebhookDispatcher) Test(ctx context.Context, subscription models.WebhookSubscription) (int, error) {
+	now := time.Now()
+	id := fmt.Sprintf("ping-%d", now.UnixNano())
+	body, err := json.Marshal(WebhookEvent{
+		Id:           id,
+		Event:        WebhookPing,
+		Subscription: subscription.Name,
+		OccurredAt:   now,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return d.send(ctx, subscription, id, WebhookPing, body)
+}
+
+// webhookWorker delivers the outbox of one subscription, so that a slow or
+// unreachable receiver only holds up its own deliveries.
+type webhookWorker struct {
+	wake   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu           sync.Mutex
+	subscription models.WebhookSubscription
+}
+
+// Run dispatches whenever changes are committed and on every interval, and
+// wakes the delivery worker of every subscription after each round so new
+// deliveries and due retries are picked up, until ctx is done.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	if d.interval <= 0 {
+		return
+	}
+
+	workers := make(map[uint64]*webhookWorker)
+	defer func() {
+		for _, worker := range workers {
+			worker.cancel()
+			<-worker.done
+		}
+	}()
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	var lastPrune time.Time
+	for {
+		changed := d.changelog.Changed()
+		d.dispatch(ctx)
+		d.wakeWorkers(ctx, workers)
+		if now := time.Now(); d.retention > 0 && now.Sub(lastPrune) >= webhookPruneInterval {
+			d.prune(now)
+			lastPrune = now
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *WebhookDispatcher) dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := d.Dispatch()
+		if err != nil {
+			log.Printf("dispatching webhooks failed: %v", err)
+			return
+		}
+		if n < webhookBatchSize {
+			return
+		}
+	}
+}
+
+// wakeWorkers starts a worker for every new subscription, stops those of
+// deleted subscriptions and wakes the rest with their current settings.
+func (d *WebhookDispatcher) wakeWorkers(ctx context.Context, workers map[uint64]*webhookWorker) {
+	subscriptions, err := d.repo.GetSubscriptions()
+	if err != nil {
+		log.Printf("loading webhook subscriptions failed: %v", err)
+		return
+	}
+
+	current := make(map[uint64]bool, len(subscriptions))
+	for _, subscription := range subscriptions {
+		current[subscription.Id] = true
+		worker, ok := workers[subscription.Id]
+		if !ok {
+			workerCtx, cancel := context.WithCancel(ctx)
+			worker = &webhookWorker{wake: make(chan struct{}, 1), cancel: cancel, done: make(chan struct{})}
+			workers[subscription.Id] = worker
+			go d.work(workerCtx, worker)
+		}
+		worker.mu.Lock()
+		worker.subscription = subscription
+		worker.mu.Unlock()
+		select {
+		case worker.wake <- struct{}{}:
+		default:
+			// Already woken; the worker reads the settings when it gets there.
+		}
+	}
+	for id, worker := range workers {
+		if !current[id] {
+			worker.cancel()
+			delete(workers, id)
+		}
+	}
+}
+
+// work delivers everything due for the worker's subscription each time it is
+// woken, until ctx is done.
+func (d *WebhookDispatcher) work(ctx context.Context, worker *webhookWorker) {
+	defer close(worker.done)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-worker.wake:
+		}
+		worker.mu.Lock()
+		subscription := worker.subscription
+		worker.mu.Unlock()
+
+		for ctx.Err() == nil {
+			n, err := d.Deliver(ctx, subscription, time.Now())
+			if err != nil {
+				log.Printf("delivering webhooks to %s failed: %v", subscription.Name, err)
+				break
+			}
+			if n < webhookBatchSize {
+				break
+			}
+		}
+	}
+}
+
+func (d *WebhookDispatcher) prune(now time.Time) {
+	for {
+		n, err := d.repo.RemoveFinishedBefore(now.Add(-d.retention), 1000)
+		if err != nil {
+			log.Printf("pruning webhook deliveries failed: %v", err)
+			return
+		}
+		if n < 1000 {
+			return
+		}
+	}
+}
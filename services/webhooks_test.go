@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"go-rest-api/config"
+	"go-rest-api/models"
+	"go-rest-api/repositories"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/objectbox/objectbox-go/objectbox"
+)
+
+// webhookReceiver answers every request with the next of statuses, repeating
+// the last one, after checking the signature against secret.
+func webhookReceiver(t *testing.T, secret string, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1))
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading body: %v", err)
+		}
+		timestamp, err := strconv.ParseInt(r.Header.Get(WebhookTimestampHeader), 10, 64)
+		if err != nil {
+			t.Errorf("timestamp header: %v", err)
+		}
+		if got, want := r.Header.Get(WebhookSignatureHeader), SignWebhook(secret, timestamp, body); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+		if r.Header.Get(WebhookIdHeader) == "" || r.Header.Get("Idempotency-Key") != r.Header.Get(WebhookIdHeader) {
+			t.Errorf("id headers = %q, %q", r.Header.Get(WebhookIdHeader), r.Header.Get("Idempotency-Key"))
+		}
+		w.WriteHeader(statuses[min(n, len(statuses))-1])
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func testDispatcher(client *http.Client, maxAttempts int) *WebhookDispatcher {
+	return NewWebhookDispatcher(nil, nil, client, config.Config{
+		WebhookMaxAttempts: maxAttempts,
+		WebhookBackoff:     time.Second,
+		WebhookMaxBackoff:  3 * time.Second,
+	})
+}
+
+func testSubscription(url string) models.WebhookSubscription {
+	return models.WebhookSubscription{Id: 7, Name: "audit", URL: url, Secret: "s3cret", Enabled: true,
+		Events: models.WebhookActivityCreated}
+}
+
+func TestSignWebhook(t *testing.T) {
+	signature := SignWebhook("s3cret", 1700000000, []byte(`{"id":"1-2"}`))
+	if signature != SignWebhook("s3cret", 1700000000, []byte(`{"id":"1-2"}`)) {
+		t.Fatal("signature is not deterministic")
+	}
+	for _, other := range []string{
+		SignWebhook("other", 1700000000, []byte(`{"id":"1-2"}`)),
+		SignWebhook("s3cret", 1700000001, []byte(`{"id":"1-2"}`)),
+		SignWebhook("s3cret", 1700000000, []byte(`{"id":"1-3"}`)),
+	} {
+		if other == signature {
+			t.Errorf("signature %q does not depend on secret, timestamp and body", signature)
+		}
+	}
+}
+
+func TestWebhookAttemptDelivers(t *testing.T) {
+	server, requests := webhookReceiver(t, "s3cret", http.StatusNoContent)
+	d := testDispatcher(server.Client(), 3)
+	delivery := &models.WebhookDelivery{Id: 1, Sequence: 2, Event: models.WebhookActivityCreated, Payload: `{"id":"7-2"}`,
+		Status: models.DeliveryPending}
+
+	d.attempt(context.Background(), testSubscription(server.URL), delivery)
+
+	if requests.Load() != 1 {
+		t.Fatalf("requests = %d, want 1", requests.Load())
+	}
+	if delivery.Status != models.DeliveryDelivered || delivery.Attempts != 1 || delivery.LastStatusCode != http.StatusNoContent {
+		t.Errorf("delivery = %s after %d attempts with status %d", delivery.Status, delivery.Attempts, delivery.LastStatusCode)
+	}
+	if delivery.FinishedAt.IsZero() || delivery.LastError != "" {
+		t.Errorf("finished at %v with error %q", delivery.FinishedAt, delivery.LastError)
+	}
+}
+
+func TestWebhookAttemptRetriesWithBackoff(t *testing.T) {
+	server, requests := webhookReceiver(t, "s3cret", http.StatusServiceUnavailable, http.StatusOK)
+	d := testDispatcher(server.Client(), 3)
+	subscription := testSubscription(server.URL)
+	delivery := &models.WebhookDelivery{Id: 1, Sequence: 2, Payload: `{}`, Status: models.DeliveryPending}
+
+	d.attempt(context.Background(), subscription, delivery)
+	if delivery.Status != models.DeliveryPending || delivery.LastStatusCode != http.StatusServiceUnavailable || delivery.LastError == "" {
+		t.Fatalf("after failure: status %s, code %d, error %q", delivery.Status, delivery.LastStatusCode, delivery.LastError)
+	}
+	if got := delivery.NextAttemptAt.Sub(delivery.LastAttemptAt); got != time.Second {
+		t.Errorf("first retry after %v, want 1s", got)
+	}
+
+	d.attempt(context.Background(), subscription, delivery)
+	if requests.Load() != 2 || delivery.Status != models.DeliveryDelivered || delivery.Attempts != 2 {
+		t.Errorf("after retry: %d requests, status %s, %d attempts", requests.Load(), delivery.Status, delivery.Attempts)
+	}
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	d := testDispatcher(http.DefaultClient, 10)
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 3 * time.Second, 6: 3 * time.Second} {
+		if got := d.retryDelay(attempts); got != want {
+			t.Errorf("retryDelay(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+func TestWebhookAttemptDeadLetters(t *testing.T) {
+	server, requests := webhookReceiver(t, "s3cret", http.StatusInternalServerError)
+	d := testDispatcher(server.Client(), 2)
+	subscription := testSubscription(server.URL)
+	delivery := &models.WebhookDelivery{Id: 1, Sequence: 2, Payload: `{}`, Status: models.DeliveryPending}
+
+	d.attempt(context.Background(), subscription, delivery)
+	if delivery.Status != models.DeliveryPending {
+		t.Fatalf("status after first attempt = %s, want %s", delivery.Status, models.DeliveryPending)
+	}
+	d.attempt(context.Background(), subscription, delivery)
+	if requests.Load() != 2 || delivery.Status != models.DeliveryDead || delivery.Attempts != 2 {
+		t.Errorf("%d requests, status %s after %d attempts, want dead after 2", requests.Load(), delivery.Status, delivery.Attempts)
+	}
+	if delivery.FinishedAt.IsZero() || delivery.LastStatusCode != http.StatusInternalServerError {
+		t.Errorf("finished at %v with status %d", delivery.FinishedAt, delivery.LastStatusCode)
+	}
+}
+
+func TestWebhookFilterMatches(t *testing.T) {
+	subscription := testSubscription("https://example.com/hook")
+	subscription.Filter = `{"and": [{"grid": {"glob": "eu-*"}}, {"action": {"in": ["login"]}}]}`
+	webhook, err := compileWebhook(subscription)
+	if err != nil {
+		t.Fatalf("compileWebhook: %v", err)
+	}
+
+	tests := []struct {
+		activity models.DeviceActivity
+		want     bool
+	}{
+		{models.DeviceActivity{GridName: "eu-west", Action: "login"}, true},
+		{models.DeviceActivity{GridName: "eu-west", Action: "logout"}, false},
+		{models.DeviceActivity{GridName: "us-east", Action: "login"}, false},
+	}
+	for _, tt := range tests {
+		if got := webhook.filter.Matches(tt.activity); got != tt.want {
+			t.Errorf("Matches(%s, %s) = %v, want %v", tt.activity.GridName, tt.activity.Action, got, tt.want)
+		}
+	}
+}
+
+func TestValidateWebhookSubscription(t *testing.T) {
+	valid := testSubscription("https://example.com/hook")
+	if err := ValidateWebhookSubscription(valid); err != nil {
+		t.Fatalf("valid subscription: %v", err)
+	}
+
+	invalid := map[string]func(*models.WebhookSubscription){
+		"relative url":  func(s *models.WebhookSubscription) { s.URL = "/hook" },
+		"no secret":     func(s *models.WebhookSubscription) { s.Secret = "" },
+		"unknown event": func(s *models.WebhookSubscription) { s.Events = "activity.updated" },
+		"bad filter":    func(s *models.WebhookSubscription) { s.Filter = `{"grid": 1}` },
+	}
+	for name, change := range invalid {
+		subscription := valid
+		change(&subscription)
+		if err := ValidateWebhookSubscription(subscription); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// openTestObjectBox opens an empty store in a temporary directory.
+func openTestObjectBox(t *testing.T) *objectbox.ObjectBox {
+	t.Helper()
+	ob, err := objectbox.NewBuilder().Model(models.ObjectBoxModel()).Directory(t.TempDir()).Build()
+	if err != nil {
+		t.Fatalf("opening ObjectBox: %v", err)
+	}
+	t.Cleanup(ob.Close)
+	return ob
+}
+
+func TestWebhookOutbox(t *testing.T) {
+	ob := openTestObjectBox(t)
+	activities := repositories.NewActivityRepository(ob)
+	webhooks := repositories.NewWebhookRepository(ob)
+
+	flaky, flakyRequests := webhookReceiver(t, "s3cret", http.StatusServiceUnavailable, http.StatusOK)
+	failing, failingRequests := webhookReceiver(t, "0ther", http.StatusInternalServerError)
+	for _, subscription := range []models.WebhookSubscription{
+		{Name: "flaky", URL: flaky.URL, Secret: "s3cret", Enabled: true, Events: models.WebhookActivityCreated},
+		{Name: "failing", URL: failing.URL, Secret: "0ther", Enabled: true, Events: models.WebhookActivityCreated},
+	} {
+		if err := webhooks.CreateSubscription(&subscription); err != nil {
+			t.Fatalf("CreateSubscription: %v", err)
+		}
+	}
+	err := activities.Create(models.DeviceActivity{UniqueId: "a1", DeviceName: "dev-1", GridName: "eu",
+		Action: "login", Timestamp: time.Now()})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	d := NewWebhookDispatcher(webhooks, activities.Changelog(), http.DefaultClient, config.Config{
+		WebhookMaxAttempts: 2,
+		WebhookBackoff:     time.Minute,
+	})
+	if n, err := d.Dispatch(); err != nil || n != 1 {
+		t.Fatalf("Dispatch = %d, %v, want 1", n, err)
+	}
+	if n, err := d.Dispatch(); err != nil || n != 0 {
+		t.Fatalf("second Dispatch = %d, %v, want 0", n, err)
+	}
+
+	deliver := func(name string, now time.Time) int {
+		t.Helper()
+		subscription, err := webhooks.GetSubscription(name)
+		if err != nil {
+			t.Fatalf("GetSubscription: %v", err)
+		}
+		n, err := d.Deliver(context.Background(), *subscription, now)
+		if err != nil {
+			t.Fatalf("Deliver to %s: %v", name, err)
+		}
+		return n
+	}
+	delivery := func(name string) models.WebhookDelivery {
+		t.Helper()
+		page, err := webhooks.GetDeliveries(name, "", "", 10)
+		if err != nil || len(page.Deliveries) != 1 {
+			t.Fatalf("deliveries of %s: %+v, %v", name, page.Deliveries, err)
+		}
+		return page.Deliveries[0]
+	}
+
+	// The first attempts fail and are retried after the backoff.
+	for _, name := range []string{"flaky", "failing"} {
+		if n := deliver(name, time.Now()); n != 1 {
+			t.Fatalf("first round to %s attempted %d, want 1", name, n)
+		}
+		if got := delivery(name); got.Status != models.DeliveryPending || got.Attempts != 1 {
+			t.Fatalf("%s after one attempt: %s with %d attempts", name, got.Status, got.Attempts)
+		}
+		if n := deliver(name, time.Now()); n != 0 {
+			t.Errorf("retried %s before the backoff", name)
+		}
+	}
+
+	later := time.Now().Add(2 * time.Minute)
+	deliver("flaky", later)
+	deliver("failing", later)
+	if got := delivery("flaky"); got.Status != models.DeliveryDelivered || got.Attempts != 2 || got.LastStatusCode != http.StatusOK {
+		t.Errorf("flaky: %s after %d attempts with status %d, want delivered", got.Status, got.Attempts, got.LastStatusCode)
+	}
+	if got := delivery("failing"); got.Status != models.DeliveryDead || got.Attempts != 2 {
+		t.Errorf("failing: %s after %d attempts, want dead", got.Status, got.Attempts)
+	}
+	if flakyRequests.Load() != 2 || failingRequests.Load() != 2 {
+		t.Errorf("requests = %d and %d, want 2 each", flakyRequests.Load(), failingRequests.Load())
+	}
+	if n := deliver("failing", later.Add(time.Hour)); n != 0 {
+		t.Errorf("dead delivery attempted again")
+	}
+}
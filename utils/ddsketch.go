@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"math"
+	"sort"
+)
+
+// SketchAccuracy is the relative accuracy of DDSketch quantiles: the value
+// returned for a quantile is within 1% of a value actually at that rank.
+const SketchAccuracy = 0.01
+
+// sketchMaxBins bounds the size of a sketch. Beyond it the lowest bins are
+// collapsed, which only affects the accuracy of the smallest values.
+const sketchMaxBins = 2048
+
+var (
+	sketchGamma    = (1 + SketchAccuracy) / (1 - SketchAccuracy)
+	sketchLogGamma = math.Log(sketchGamma)
+)
+
+// DDSketch is a mergeable quantile sketch for non-negative values, after
+// Masson, Rim and Lee, "DDSketch" (VLDB 2019). Values fall into
+// logarithmically sized bins, so merging two sketches is adding their bins.
+type DDSketch struct {
+	Bins  map[int]uint64 `json:"bins"`
+	Zero  uint64         `json:"zero"`
+	Count uint64         `json:"count"`
+}
+
+func NewDDSketch() *DDSketch {
+	return &DDSketch{Bins: make(map[int]uint64)}
+}
+
+// Add records a value. Negative values are recorded as zero.
+func (s *DDSketch) Add(value float64) {
+	s.Count++
+	if value <= 0 {
+		s.Zero++
+		return
+	}
+	if s.Bins == nil {
+		s.Bins = make(map[int]uint64)
+	}
+	s.Bins[sketchKey(value)]++
+	s.collapse()
+}
+
+// Merge adds all values recorded in other.
+func (s *DDSketch) Merge(other *DDSketch) {
+	if s.Bins == nil {
+		s.Bins = make(map[int]uint64)
+	}
+	for key, count := range other.Bins {
+		s.Bins[key] += count
+	}
+	s.Zero += other.Zero
+	s.Count += other.Count
+	s.collapse()
+}
+
+// Quantile returns the value at quantile q (0 to 1), or 0 for an empty sketch.
+func (s *DDSketch) Quantile(q float64) float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	q = math.Max(0, math.Min(1, q))
+	rank := uint64(q * float64(s.Count-1))
+	if rank < s.Zero {
+		return 0
+	}
+
+	seen := s.Zero
+	keys := s.sortedKeys()
+	for _, key := range keys {
+		seen += s.Bins[key]
+		if seen > rank {
+			return 2 * math.Pow(sketchGamma, float64(key)) / (sketchGamma + 1)
+		}
+	}
+	return 2 * math.Pow(sketchGamma, float64(keys[len(keys)-1])) / (sketchGamma + 1)
+}
+
+// CountAtMost returns about how many recorded values are at most value. Values
+// in the bin of value count as at most value.
+func (s *DDSketch) CountAtMost(value float64) uint64 {
+	if value < 0 {
+		return 0
+	}
+	count := s.Zero
+	if value == 0 {
+		return count
+	}
+	limit := sketchKey(value)
+	for key, n := range s.Bins {
+		if key <= limit {
+			count += n
+		}
+	}
+	return count
+}
+
+func sketchKey(value float64) int {
+	return int(math.Ceil(math.Log(value) / sketchLogGamma))
+}
+
+func (s *DDSketch) sortedKeys() []int {
+	keys := make([]int, 0, len(s.Bins))
+	for key := range s.Bins {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// collapse merges the lowest bins into one until at most sketchMaxBins remain.
+func (s *DDSketch) collapse() {
+	if len(s.Bins) <= sketchMaxBins {
+		return
+	}
+	keys := s.sortedKeys()
+	excess := len(keys) - sketchMaxBins
+	target := keys[excess]
+	for _, key := range keys[:excess] {
+		s.Bins[target] += s.Bins[key]
+		delete(s.Bins, key)
+	}
+}
@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestDDSketchQuantile(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	values := func(n int, next func(i int) float64) []float64 {
+		result := make([]float64, n)
+		for i := range result {
+			result[i] = next(i)
+		}
+		return result
+	}
+	tests := []struct {
+		name   string
+		values []float64
+	}{
+		{"single value", []float64{42}},
+		{"linear", values(1000, func(i int) float64 { return float64(i + 1) })},
+		{"exponential", values(10000, func(int) float64 { return random.ExpFloat64() * 0.2 })},
+		{"wide range", values(5000, func(int) float64 { return math.Pow(10, random.Float64()*12-6) })},
+		{"with zeros", values(1000, func(i int) float64 { return float64(i%4) * 1.5 })},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sketch := NewDDSketch()
+			for _, value := range tt.values {
+				sketch.Add(value)
+			}
+			sorted := append([]float64(nil), tt.values...)
+			sort.Float64s(sorted)
+			for _, q := range []float64{0, 0.01, 0.25, 0.5, 0.9, 0.95, 0.99, 0.999, 1} {
+				want := sorted[int(q*float64(len(sorted)-1))]
+				got := sketch.Quantile(q)
+				if math.Abs(got-want) > SketchAccuracy*want+1e-12 {
+					t.Errorf("Quantile(%v) = %v, want %v within %v", q, got, want, SketchAccuracy)
+				}
+			}
+		})
+	}
+}
+
+func TestDDSketchEdgeCases(t *testing.T) {
+	var empty DDSketch
+	if got := empty.Quantile(0.5); got != 0 {
+		t.Errorf("empty Quantile = %v, want 0", got)
+	}
+
+	sketch := NewDDSketch()
+	sketch.Add(-3)
+	sketch.Add(0)
+	sketch.Add(10)
+	if sketch.Count != 3 || sketch.Zero != 2 {
+		t.Errorf("Count, Zero = %d, %d, want 3, 2", sketch.Count, sketch.Zero)
+	}
+	tests := []struct {
+		q    float64
+		want float64
+	}{
+		{-1, 0},
+		{0.5, 0},
+		{1, 10},
+		{2, 10},
+	}
+	for _, tt := range tests {
+		if got := sketch.Quantile(tt.q); math.Abs(got-tt.want) > SketchAccuracy*tt.want {
+			t.Errorf("Quantile(%v) = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestDDSketchMerge(t *testing.T) {
+	whole, first, second := NewDDSketch(), NewDDSketch(), &DDSketch{}
+	for i := 0; i < 2000; i++ {
+		value := float64(i%100) + 0.5
+		whole.Add(value)
+		if i%3 == 0 {
+			first.Add(value)
+		} else {
+			second.Add(value)
+		}
+	}
+	first.Add(0)
+	whole.Add(0)
+	first.Merge(second)
+	if first.Count != whole.Count || first.Zero != whole.Zero || len(first.Bins) != len(whole.Bins) {
+		t.Fatalf("merged sketch %d/%d/%d, want %d/%d/%d", first.Count, first.Zero, len(first.Bins),
+			whole.Count, whole.Zero, len(whole.Bins))
+	}
+	for key, count := range whole.Bins {
+		if first.Bins[key] != count {
+			t.Errorf("bin %d = %d, want %d", key, first.Bins[key], count)
+		}
+	}
+}
+
+func TestDDSketchCountAtMost(t *testing.T) {
+	sketch := NewDDSketch()
+	sketch.Add(0)
+	for i := 1; i <= 100; i++ {
+		sketch.Add(float64(i))
+	}
+	tests := []struct {
+		value    float64
+		min, max uint64
+	}{
+		{-1, 0, 0},
+		{0, 1, 1},
+		{0.5, 1, 1},
+		{10, 11, 11},
+		{50, 50, 52},
+		{1000, 101, 101},
+	}
+	for _, tt := range tests {
+		if got := sketch.CountAtMost(tt.value); got < tt.min || got > tt.max {
+			t.Errorf("CountAtMost(%v) = %d, want %d to %d", tt.value, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestDDSketchCollapse(t *testing.T) {
+	sketch := NewDDSketch()
+	// Every value gets a bin of its own, twice as many as are kept.
+	for i := 0; i < 2*sketchMaxBins; i++ {
+		sketch.Add(math.Pow(sketchGamma, float64(i)-float64(sketchMaxBins)))
+	}
+	if len(sketch.Bins) != sketchMaxBins {
+		t.Fatalf("sketch keeps %d bins, want %d", len(sketch.Bins), sketchMaxBins)
+	}
+	var total uint64
+	for _, count := range sketch.Bins {
+		total += count
+	}
+	if total != sketch.Count {
+		t.Errorf("bins hold %d values, want %d", total, sketch.Count)
+	}
+	// Collapsing only touches the lowest values; the top ones stay accurate.
+	want := math.Pow(sketchGamma, float64(sketchMaxBins-1))
+	if got := sketch.Quantile(1); math.Abs(got-want) > SketchAccuracy*want {
+		t.Errorf("Quantile(1) = %v, want %v", got, want)
+	}
+}